@@ -3,6 +3,7 @@ package baseapp
 import (
 	"fmt"
 	"runtime/debug"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -24,6 +25,13 @@ import (
 // and to avoid affecting the Merkle root.
 var dbHeaderKey = []byte("header")
 
+// Keys to store in the DB itself the block gas limit, set by the consensus
+// params, and the gas used by the last committed block.
+var (
+	dbBlockMaxGasKey  = []byte("blockMaxGas")
+	dbBlockGasUsedKey = []byte("blockGasUsed")
+)
+
 // Enum mode for app.runTx
 type runTxMode uint8
 
@@ -57,6 +65,15 @@ type BaseApp struct {
 	endBlocker       sdk.EndBlocker   // logic to run after all txs, and to determine valset changes
 	addrPeerFilter   sdk.PeerFilter   // filter peers by address and port
 	pubkeyPeerFilter sdk.PeerFilter   // filter peers by public key
+	minimumGasPrices sdk.Coins        // gas prices txs must pay to enter the mempool
+
+	// snapshots of the committed state, none if the interval is zero
+	snapshots       store.SnapshotStore
+	snapshotOptions store.SnapshotOptions
+	snapshotting    chan struct{} // holds a token while a snapshot is taken
+
+	// set by the consensus params, blocks have no gas limit if not positive
+	blockMaxGas int64
 
 	//--------------------
 	// Volatile
@@ -69,6 +86,7 @@ type BaseApp struct {
 	deliverState     *state                  // for DeliverTx
 	valUpdates       []abci.Validator        // cached validator changes from DeliverTx
 	signedValidators []abci.SigningValidator // absent validators from begin block
+	lastBlockGasUsed int64                   // gas used by the last committed block
 }
 
 var _ abci.Application = (*BaseApp)(nil)
@@ -156,23 +174,41 @@ func (app *BaseApp) SetEndBlocker(endBlocker sdk.EndBlocker) {
 func (app *BaseApp) SetAnteHandler(ah sdk.AnteHandler) {
 	app.anteHandler = ah
 }
+func (app *BaseApp) SetAnteDecorators(decorators ...sdk.AnteDecorator) {
+	app.anteHandler = sdk.ChainAnteDecorators(decorators...)
+}
 func (app *BaseApp) SetAddrPeerFilter(pf sdk.PeerFilter) {
 	app.addrPeerFilter = pf
 }
 func (app *BaseApp) SetPubKeyPeerFilter(pf sdk.PeerFilter) {
 	app.pubkeyPeerFilter = pf
 }
+func (app *BaseApp) SetMinimumGasPrices(prices sdk.Coins) {
+	app.minimumGasPrices = prices
+}
+func (app *BaseApp) SetPruning(pruning sdk.PruningOptions) {
+	app.cms.SetPruning(pruning)
+}
+func (app *BaseApp) SetSnapshots(snapshots store.SnapshotStore, options store.SnapshotOptions) {
+	app.snapshots = snapshots
+	app.snapshotOptions = options
+	app.snapshotting = make(chan struct{}, 1)
+}
 func (app *BaseApp) Router() Router { return app.router }
 
 // load latest application version
 func (app *BaseApp) LoadLatestVersion(mainKey sdk.StoreKey) error {
-	app.cms.LoadLatestVersion()
+	if err := app.cms.LoadLatestVersion(); err != nil {
+		return err
+	}
 	return app.initFromStore(mainKey)
 }
 
 // load application version
 func (app *BaseApp) LoadVersion(version int64, mainKey sdk.StoreKey) error {
-	app.cms.LoadVersion(version)
+	if err := app.cms.LoadVersion(version); err != nil {
+		return err
+	}
 	return app.initFromStore(mainKey)
 }
 
@@ -226,12 +262,51 @@ func (app *BaseApp) initFromStore(mainKey sdk.StoreKey) error {
 		}
 	*/
 
+	// load the block gas limit and the gas used by the last block
+	blockMaxGas, err := loadInt64(app.db, dbBlockMaxGasKey)
+	if err != nil {
+		return err
+	}
+	app.blockMaxGas = blockMaxGas
+	lastBlockGasUsed, err := loadInt64(app.db, dbBlockGasUsedKey)
+	if err != nil {
+		return err
+	}
+	app.lastBlockGasUsed = lastBlockGasUsed
+
 	// initialize Check state
 	app.setCheckState(abci.Header{})
 
 	return nil
 }
 
+// load an int64 stored in the db, zero if it is not set
+func loadInt64(db dbm.DB, key []byte) (int64, error) {
+	bz := db.Get(key)
+	if bz == nil {
+		return 0, nil
+	}
+	i, err := strconv.ParseInt(string(bz), 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, fmt.Sprintf("Failed to parse db://%s", key))
+	}
+	return i, nil
+}
+
+// set the block gas limit and store it in the db
+func (app *BaseApp) setBlockMaxGas(maxGas int64) {
+	app.blockMaxGas = maxGas
+	app.db.SetSync(dbBlockMaxGasKey, []byte(strconv.FormatInt(maxGas, 10)))
+}
+
+// gas meter of a new block, limited by the consensus params
+func (app *BaseApp) newBlockGasMeter() sdk.GasMeter {
+	if app.blockMaxGas > 0 {
+		return sdk.NewGasMeter(app.blockMaxGas)
+	}
+	return sdk.NewInfiniteGasMeter()
+}
+
 // NewContext returns a new Context with the correct store, the given header, and nil txBytes.
 func (app *BaseApp) NewContext(isCheckTx bool, header abci.Header) sdk.Context {
 	if isCheckTx {
@@ -289,6 +364,8 @@ func (app *BaseApp) SetOption(req abci.RequestSetOption) (res abci.ResponseSetOp
 // Implements ABCI
 // InitChain runs the initialization logic directly on the CommitMultiStore and commits it.
 func (app *BaseApp) InitChain(req abci.RequestInitChain) (res abci.ResponseInitChain) {
+	app.setBlockMaxGas(req.ConsensusParams.GetBlockSize().GetMaxGas())
+
 	if app.initChainer == nil {
 		return
 	}
@@ -336,7 +413,14 @@ func (app *BaseApp) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 			if err != nil {
 				result = err.Result()
 			} else {
-				result = app.Simulate(tx)
+				result = app.runTx(runTxModeSimulate, txBytes, tx)
+			}
+		case "block-gas":
+			// gas used by the last committed block
+			return abci.ResponseQuery{
+				Code:   uint32(sdk.ABCICodeOK),
+				Value:  app.cdc.MustMarshalBinary(app.lastBlockGasUsed),
+				Height: app.LastBlockHeight(),
 			}
 		default:
 			result = sdk.ErrUnknownRequest(fmt.Sprintf("Unknown query: %s", path)).Result()
@@ -381,6 +465,7 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 	if app.deliverState == nil {
 		app.setDeliverState(req.Header)
 	}
+	app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(app.newBlockGasMeter())
 	app.valUpdates = nil
 	if app.beginBlocker != nil {
 		res = app.beginBlocker(app.deliverState.ctx, req)
@@ -477,28 +562,49 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 		}
 	}()
 
-	// Get the Msg.
-	var msg = tx.GetMsg()
-	if msg == nil {
-		return sdk.ErrInternal("Tx.GetMsg() returned nil").Result()
+	// Get the Msgs.
+	var msgs = tx.GetMsgs()
+	if len(msgs) == 0 {
+		return sdk.ErrInternal("Tx.GetMsgs() must return at least one message").Result()
 	}
 
-	// Validate the Msg.
-	err := msg.ValidateBasic()
-	if err != nil {
-		err = err.WithDefaultCodespace(sdk.CodespaceRoot)
-		return err.Result()
+	// Validate the Msgs.
+	for _, msg := range msgs {
+		if msg == nil {
+			return sdk.ErrInternal("Tx.GetMsgs() returned a nil message").Result()
+		}
+		err := msg.ValidateBasic()
+		if err != nil {
+			err = err.WithDefaultCodespace(sdk.CodespaceRoot)
+			return err.Result()
+		}
 	}
 
 	// Get the context
 	var ctx sdk.Context
 	if mode == runTxModeCheck || mode == runTxModeSimulate {
 		ctx = app.checkState.ctx.WithTxBytes(txBytes)
+		ctx = ctx.WithMinimumGasPrices(app.minimumGasPrices)
 	} else {
 		ctx = app.deliverState.ctx.WithTxBytes(txBytes)
 		ctx = ctx.WithSigningValidators(app.signedValidators)
 	}
 
+	// Charge the gas of delivered txs to the block, however they end,
+	// rejecting txs once the block gas limit is reached. Successful txs
+	// are charged before their state is written.
+	var blockGasCharged bool
+	if mode == runTxModeDeliver {
+		if ctx.BlockGasMeter().IsOutOfGas() {
+			return sdk.ErrOutOfGas("Block gas limit reached").Result()
+		}
+		defer func() {
+			if !blockGasCharged {
+				ctx.BlockGasMeter().ConsumeGas(ctx.GasMeter().GasConsumed(), "block gas meter")
+			}
+		}()
+	}
+
 	// Simulate a DeliverTx for gas calculation
 	if mode == runTxModeSimulate {
 		ctx = ctx.WithIsCheckTx(false)
@@ -507,19 +613,22 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 	// Run the ante handler.
 	if app.anteHandler != nil {
 		newCtx, result, abort := app.anteHandler(ctx, tx)
-		if abort {
-			return result
-		}
 		if !newCtx.IsZero() {
 			ctx = newCtx
 		}
+		if abort {
+			return result
+		}
 	}
 
-	// Match route.
-	msgType := msg.Type()
-	handler := app.router.Route(msgType)
-	if handler == nil {
-		return sdk.ErrUnknownRequest("Unrecognized Msg type: " + msgType).Result()
+	// Match routes, before running any of the Msgs.
+	handlers := make([]sdk.Handler, len(msgs))
+	for i, msg := range msgs {
+		msgType := msg.Type()
+		handlers[i] = app.router.Route(msgType)
+		if handlers[i] == nil {
+			return sdk.ErrUnknownRequest("Unrecognized Msg type: " + msgType).Result()
+		}
 	}
 
 	// Get the correct cache
@@ -534,11 +643,27 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 		ctx = ctx.WithMultiStore(msCache)
 	}
 
-	result = handler(ctx, msg)
+	result = runMsgs(ctx, msgs, handlers)
 
 	// Set gas utilized
 	result.GasUsed = ctx.GasMeter().GasConsumed()
 
+	// Report the fee the node requires for the gas used, in the first
+	// denomination of its minimum gas prices
+	if mode == runTxModeSimulate {
+		fee, ok := sdk.FeeForGas(app.minimumGasPrices, result.GasUsed)
+		if ok && len(fee) > 0 {
+			result.FeeAmount = fee[0].Amount
+			result.FeeDenom = fee[0].Denom
+		}
+	}
+
+	// A tx exceeding the block gas limit panics before its state is written
+	if mode == runTxModeDeliver {
+		blockGasCharged = true
+		ctx.BlockGasMeter().ConsumeGas(result.GasUsed, "block gas meter")
+	}
+
 	// If not a simulated run and result was successful, write to app.checkState.ms or app.deliverState.ms
 	if mode != runTxModeSimulate && result.IsOK() {
 		msCache.Write()
@@ -547,6 +672,35 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 	return result
 }
 
+// run the Msgs of a tx in order within the same multistore, stopping at the
+// first failing Msg. The data, logs, validator updates and tags of all the
+// Msgs are aggregated.
+func runMsgs(ctx sdk.Context, msgs []sdk.Msg, handlers []sdk.Handler) (result sdk.Result) {
+	var data []byte
+	var logs []string
+	var valUpdates []abci.Validator
+	var tags sdk.Tags
+	for i, msg := range msgs {
+		msgResult := handlers[i](ctx, msg)
+		if !msgResult.IsOK() {
+			msgResult.Log = fmt.Sprintf("Msg %d failed: %s", i, msgResult.Log)
+			return msgResult
+		}
+		data = append(data, msgResult.Data...)
+		if len(msgResult.Log) != 0 {
+			logs = append(logs, fmt.Sprintf("Msg %d: %s", i, msgResult.Log))
+		}
+		valUpdates = append(valUpdates, msgResult.ValidatorUpdates...)
+		tags = tags.AppendTags(msgResult.Tags)
+	}
+	return sdk.Result{
+		Data:             data,
+		Log:              strings.Join(logs, "\n"),
+		ValidatorUpdates: valUpdates,
+		Tags:             tags,
+	}
+}
+
 // Implements ABCI
 func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBlock) {
 	if app.endBlocker != nil {
@@ -554,6 +708,15 @@ func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBloc
 	} else {
 		res.ValidatorUpdates = app.valUpdates
 	}
+
+	// report the gas used by the block
+	gasUsed := app.deliverState.ctx.BlockGasMeter().GasConsumed()
+	res.Tags = append(res.Tags, sdk.MakeTag("block-gas-used", []byte(strconv.FormatInt(gasUsed, 10))))
+
+	// apply changes of the block gas limit from the next block
+	if blockSize := res.ConsensusParamUpdates.GetBlockSize(); blockSize != nil {
+		app.setBlockMaxGas(blockSize.MaxGas)
+	}
 	return
 }
 
@@ -572,9 +735,14 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	// Write the Deliver state and commit the MultiStore
 	app.deliverState.ms.Write()
 	commitID := app.cms.Commit()
+	app.lastBlockGasUsed = app.deliverState.ctx.BlockGasMeter().GasConsumed()
+	app.db.SetSync(dbBlockGasUsedKey, []byte(strconv.FormatInt(app.lastBlockGasUsed, 10)))
 	app.Logger.Debug("Commit synced",
 		"commit", commitID,
 	)
+	if interval := app.snapshotOptions.Interval; interval > 0 && commitID.Version%interval == 0 {
+		app.snapshot(commitID.Version)
+	}
 
 	// Reset the Check state to the latest committed
 	// NOTE: safe because Tendermint holds a lock on the mempool for Commit.
@@ -588,3 +756,34 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 		Data: commitID.Hash,
 	}
 }
+
+// snapshot the version just committed in the background and prune the
+// older snapshots, while the next blocks are committed. The version is
+// skipped if the previous snapshot is still being taken, and a failed
+// snapshot is logged without halting the chain.
+func (app *BaseApp) snapshot(version int64) {
+	select {
+	case app.snapshotting <- struct{}{}:
+	default:
+		app.Logger.Error("Skipping snapshot, the previous one is still being taken", "height", version)
+		return
+	}
+	go func() {
+		defer func() { <-app.snapshotting }()
+		manifest, err := store.CreateSnapshot(app.db, app.snapshots, version)
+		if err != nil {
+			app.Logger.Error("Failed to create snapshot", "height", version, "err", err)
+			return
+		}
+		app.Logger.Info("Created snapshot",
+			"height", manifest.CommitID.Version,
+			"chunks", len(manifest.ChunkHashes),
+		)
+		if app.snapshotOptions.KeepRecent > 0 {
+			err = app.snapshots.Prune(app.snapshotOptions.KeepRecent)
+			if err != nil {
+				app.Logger.Error("Failed to prune snapshots", "err", err)
+			}
+		}
+	}()
+}
@@ -3,6 +3,7 @@ package baseapp
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"testing"
 
@@ -16,6 +17,7 @@ import (
 	dbm "github.com/tendermint/tmlibs/db"
 	"github.com/tendermint/tmlibs/log"
 
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -115,6 +117,50 @@ func testLoadVersionHelper(t *testing.T, app *BaseApp, expectedHeight int64, exp
 	assert.Equal(t, expectedID, lastID)
 }
 
+// Test that snapshots are taken every interval and restore the app hash.
+func TestSnapshots(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	snapshots := store.NewSnapshotStore(dir)
+
+	logger := defaultLogger()
+	db := dbm.NewMemDB()
+	app := NewBaseApp(t.Name(), nil, logger, db)
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+	app.SetSnapshots(snapshots, store.SnapshotOptions{Interval: 2, KeepRecent: 2})
+	err = app.LoadLatestVersion(capKey)
+	require.Nil(t, err)
+
+	var commitIDs []sdk.CommitID
+	for height := int64(1); height <= 6; height++ {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		app.deliverState.ctx.KVStore(capKey).Set([]byte("height"), []byte(fmt.Sprint(height)))
+		res := app.Commit()
+		commitIDs = append(commitIDs, sdk.CommitID{height, res.Data})
+		// wait for the snapshot taken in the background
+		app.snapshotting <- struct{}{}
+		<-app.snapshotting
+	}
+
+	// the snapshot of height 2 is pruned
+	manifests, err := snapshots.List()
+	require.Nil(t, err)
+	require.Equal(t, 2, len(manifests))
+	assert.Equal(t, commitIDs[3], manifests[0].CommitID)
+	assert.Equal(t, commitIDs[5], manifests[1].CommitID)
+
+	db2 := dbm.NewMemDB()
+	_, err = store.RestoreSnapshot(db2, snapshots, 4)
+	require.Nil(t, err)
+	app = NewBaseApp(t.Name(), nil, logger, db2)
+	app.MountStoresIAVL(capKey)
+	err = app.LoadLatestVersion(capKey)
+	require.Nil(t, err)
+	testLoadVersionHelper(t, app, int64(4), commitIDs[3])
+}
+
 // Test that the app hash is static
 // TODO: https://github.com/cosmos/cosmos-sdk/issues/520
 /*func TestStaticAppHash(t *testing.T) {
@@ -262,7 +308,7 @@ type testTx struct {
 const msgType2 = "testTx"
 
 func (tx testTx) Type() string                       { return msgType2 }
-func (tx testTx) GetMsg() sdk.Msg                    { return tx }
+func (tx testTx) GetMsgs() []sdk.Msg                 { return []sdk.Msg{tx} }
 func (tx testTx) GetSignBytes() []byte               { return nil }
 func (tx testTx) GetSigners() []sdk.Address          { return nil }
 func (tx testTx) GetSignatures() []auth.StdSignature { return nil }
@@ -402,6 +448,45 @@ func TestSimulateTx(t *testing.T) {
 	}
 }
 
+// Test that simulations report the fee required by the minimum gas prices,
+// which only CheckTx enforces
+func TestSimulateTxMinimumGasPrices(t *testing.T) {
+	app := newBaseApp(t.Name())
+
+	// make a cap key and mount the store
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+	err := app.LoadLatestVersion(capKey) // needed to make stores non-nil
+	assert.Nil(t, err)
+
+	app.SetMinimumGasPrices(sdk.Coins{{"atom", 2}})
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx) (newCtx sdk.Context, res sdk.Result, abort bool) {
+		if ctx.IsCheckTx() && !ctx.MinimumGasPrices().IsZero() {
+			return ctx, sdk.ErrInsufficientFee("").Result(), true
+		}
+		return
+	})
+	app.Router().AddRoute(msgType, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		ctx.GasMeter().ConsumeGas(10, "test")
+		return sdk.Result{}
+	})
+
+	tx := testUpdatePowerTx{} // doesn't matter
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+
+	result := app.Simulate(tx)
+	require.Equal(t, sdk.ABCICodeOK, result.Code)
+	require.Equal(t, int64(10), result.GasUsed)
+	require.Equal(t, "atom", result.FeeDenom)
+	require.Equal(t, int64(20), result.FeeAmount)
+
+	result = app.Check(tx)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInsufficientFee), result.Code)
+
+	result = app.Deliver(tx)
+	require.Equal(t, sdk.ABCICodeOK, result.Code)
+}
+
 func TestRunInvalidTransaction(t *testing.T) {
 	// Initialize an app for testing
 	app := newBaseApp(t.Name())
@@ -423,6 +508,78 @@ func TestRunInvalidTransaction(t *testing.T) {
 	assert.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnknownRequest), err2.Code)
 }
 
+// A mock transaction carrying several msgs.
+type testMultiMsgTx struct {
+	msgs []sdk.Msg
+}
+
+func (tx testMultiMsgTx) GetMsgs() []sdk.Msg                 { return tx.msgs }
+func (tx testMultiMsgTx) GetSignatures() []auth.StdSignature { return nil }
+
+// Test that the msgs of a tx are run atomically and their results aggregated.
+func TestMultiMsgDeliverTx(t *testing.T) {
+	app := newBaseApp(t.Name())
+
+	// make a cap key and mount the store
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+	err := app.LoadLatestVersion(capKey) // needed to make stores non-nil
+	assert.Nil(t, err)
+
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx) (newCtx sdk.Context, res sdk.Result, abort bool) { return })
+	app.Router().AddRoute(msgType, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		updateMsg := msg.(testUpdatePowerTx)
+		if updateMsg.NewPower < 0 {
+			return sdk.ErrUnauthorized("negative power").Result()
+		}
+		store := ctx.KVStore(capKey)
+		store.Set(updateMsg.Addr, []byte{byte(updateMsg.NewPower)})
+		return sdk.Result{
+			Data: updateMsg.Addr,
+			Log:  fmt.Sprintf("power %d", updateMsg.NewPower),
+			Tags: sdk.NewTags("addr", updateMsg.Addr),
+		}
+	})
+	app.BeginBlock(abci.RequestBeginBlock{})
+
+	// all msgs succeed, results are aggregated in order
+	tx := testMultiMsgTx{[]sdk.Msg{
+		testUpdatePowerTx{[]byte("a"), 1},
+		testUpdatePowerTx{[]byte("b"), 2},
+	}}
+	res := app.Deliver(tx)
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, []byte("ab"), res.Data)
+	require.Equal(t, "Msg 0: power 1\nMsg 1: power 2", res.Log)
+	require.Equal(t, sdk.NewTags("addr", []byte("a"), "addr", []byte("b")), res.Tags)
+	store := app.deliverState.ctx.KVStore(capKey)
+	require.Equal(t, []byte{1}, store.Get([]byte("a")))
+	require.Equal(t, []byte{2}, store.Get([]byte("b")))
+
+	// a failing msg reverts the msgs before it
+	tx = testMultiMsgTx{[]sdk.Msg{
+		testUpdatePowerTx{[]byte("c"), 3},
+		testUpdatePowerTx{[]byte("d"), -1},
+	}}
+	res = app.Deliver(tx)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnauthorized), res.Code)
+	require.Contains(t, res.Log, "Msg 1 failed")
+	require.Nil(t, store.Get([]byte("c")))
+
+	// a tx without msgs is rejected
+	res = app.Deliver(testMultiMsgTx{})
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInternal), res.Code)
+
+	// an unknown route rejects the whole tx before any msg is run
+	tx = testMultiMsgTx{[]sdk.Msg{
+		testUpdatePowerTx{[]byte("e"), 5},
+		testTx{1},
+	}}
+	res = app.Deliver(tx)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnknownRequest), res.Code)
+	require.Nil(t, store.Get([]byte("e")))
+}
+
 // Test that transactions exceeding gas limits fail
 func TestTxGasLimits(t *testing.T) {
 	logger := defaultLogger()
@@ -454,6 +611,75 @@ func TestTxGasLimits(t *testing.T) {
 	app.Commit()
 }
 
+// Test that delivered txs are charged to the block gas limit
+func TestBlockGasLimit(t *testing.T) {
+	logger := defaultLogger()
+	db := dbm.NewMemDB()
+	codec := wire.NewCodec()
+	app := NewBaseApp(t.Name(), codec, logger, db)
+
+	// make a cap key and mount the store
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+	err := app.LoadLatestVersion(capKey) // needed to make stores non-nil
+	assert.Nil(t, err)
+
+	app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx) (newCtx sdk.Context, res sdk.Result, abort bool) {
+		newCtx = ctx.WithGasMeter(sdk.NewGasMeter(100))
+		return
+	})
+	counter := 0
+	app.Router().AddRoute(msgType, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		ctx.GasMeter().ConsumeGas(40, "test")
+		// write without charging gas
+		counter++
+		store := ctx.WithGasMeter(sdk.NewInfiniteGasMeter()).KVStore(capKey)
+		store.Set([]byte{byte(counter)}, []byte("value"))
+		return sdk.Result{}
+	})
+
+	app.InitChain(abci.RequestInitChain{
+		ConsensusParams: &abci.ConsensusParams{BlockSize: &abci.BlockSize{MaxGas: 100}},
+	})
+	tx := testUpdatePowerTx{} // doesn't matter
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+
+	require.True(t, app.Deliver(tx).IsOK())
+	require.True(t, app.Deliver(tx).IsOK())
+
+	// the tx exceeding the limit is not committed
+	res := app.Deliver(tx)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfGas), res.Code)
+	require.Nil(t, app.deliverState.ctx.KVStore(capKey).Get([]byte{3}))
+
+	// the block is full
+	res = app.Deliver(tx)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfGas), res.Code)
+	require.Equal(t, 3, counter)
+
+	endRes := app.EndBlock(abci.RequestEndBlock{})
+	require.Equal(t, []cmn.KVPair{sdk.MakeTag("block-gas-used", []byte("120"))}, endRes.Tags)
+	app.Commit()
+
+	query := app.Query(abci.RequestQuery{Path: "/app/block-gas"})
+	require.Equal(t, uint32(sdk.ABCICodeOK), query.Code)
+	var gasUsed int64
+	codec.MustUnmarshalBinary(query.Value, &gasUsed)
+	require.Equal(t, int64(120), gasUsed)
+
+	// each block has its own gas meter
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	require.True(t, app.Deliver(tx).IsOK())
+
+	// the limit and the gas used by the last block are reloaded from the db
+	app = NewBaseApp(t.Name(), codec, logger, db)
+	app.MountStoresIAVL(capKey)
+	err = app.LoadLatestVersion(capKey)
+	assert.Nil(t, err)
+	require.Equal(t, int64(100), app.blockMaxGas)
+	require.Equal(t, int64(120), app.lastBlockGasUsed)
+}
+
 // Test that we can only query from the latest committed state.
 func TestQuery(t *testing.T) {
 	app := newBaseApp(t.Name())
@@ -546,7 +772,7 @@ type testUpdatePowerTx struct {
 const msgType = "testUpdatePowerTx"
 
 func (tx testUpdatePowerTx) Type() string                       { return msgType }
-func (tx testUpdatePowerTx) GetMsg() sdk.Msg                    { return tx }
+func (tx testUpdatePowerTx) GetMsgs() []sdk.Msg                 { return []sdk.Msg{tx} }
 func (tx testUpdatePowerTx) GetSignBytes() []byte               { return nil }
 func (tx testUpdatePowerTx) ValidateBasic() sdk.Error           { return nil }
 func (tx testUpdatePowerTx) GetSigners() []sdk.Address          { return nil }
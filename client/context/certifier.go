@@ -0,0 +1,97 @@
+package context
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/tendermint/lite"
+	certclient "github.com/tendermint/tendermint/lite/client"
+	liteErr "github.com/tendermint/tendermint/lite/errors"
+	"github.com/tendermint/tendermint/lite/files"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// NewCertifier returns a certifier of the commits of the chain, following
+// the changes of its validator set through the commits of the node. The
+// certified commits are cached in dir and the latest of them is the trusted
+// checkpoint from which the next ones are certified. Until a commit is
+// cached, the commit of the node at the trusted height is the checkpoint if
+// its header hashes to the trusted hash.
+func NewCertifier(chainID, dir string, node rpcclient.Client, trustedHeight int64, trustedHash []byte) (*lite.InquiringCertifier, error) {
+	trusted := lite.NewCacheProvider(
+		lite.NewMemStoreProvider(),
+		files.NewProvider(dir),
+	)
+	source := certclient.NewProvider(node)
+
+	fc, err := trusted.LatestCommit()
+	if liteErr.IsCommitNotFoundErr(err) {
+		if trustedHeight <= 0 || len(trustedHash) == 0 {
+			return nil, errors.Errorf("no trusted checkpoint in %s, set the trusted height and hash of a header of the chain", dir)
+		}
+		fc, err = source.GetByHeight(trustedHeight)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(fc.Commit.Header.Hash(), trustedHash) {
+			return nil, errors.Errorf("header at height %d hashes to %X, not to the trusted hash %X",
+				trustedHeight, fc.Commit.Header.Hash(), trustedHash)
+		}
+		err = fc.ValidateBasic(chainID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return lite.NewInquiringCertifier(chainID, fc, trusted, source)
+}
+
+// CertifiedCommit gets the commit of the block at the height from the node,
+// waiting for the block if needed, and certifies it
+func (ctx CoreContext) CertifiedCommit(height int64) (commit lite.Commit, err error) {
+	ctx, err = EnsureCertifier(ctx)
+	if err != nil {
+		return commit, err
+	}
+	node, err := ctx.GetNode()
+	if err != nil {
+		return commit, err
+	}
+
+	err = rpcclient.WaitForHeight(node, height, nil)
+	if err != nil {
+		return commit, err
+	}
+	res, err := node.Commit(&height)
+	if err != nil {
+		return commit, err
+	}
+	commit = certclient.CommitFromResult(res)
+	if commit.Height() != height {
+		return commit, liteErr.ErrHeightMismatch(height, commit.Height())
+	}
+	err = ctx.Certifier.Certify(commit)
+	if err != nil {
+		return commit, errors.Wrapf(err, "failed to certify the commit at height %d", height)
+	}
+	return commit, nil
+}
+
+// VerifyTx checks the proof of the result of a tx query against the data
+// hash of its certified block
+func (ctx CoreContext) VerifyTx(res *ctypes.ResultTx) error {
+	if !bytes.Equal(res.Proof.Data, res.Tx) {
+		return errors.New("tx proof is not for the queried tx")
+	}
+	commit, err := ctx.CertifiedCommit(res.Height)
+	if err != nil {
+		return err
+	}
+	err = res.Proof.Validate(commit.Header.DataHash)
+	if err != nil {
+		return errors.Wrapf(err, "failed to verify the tx proof at height %d", res.Height)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package context
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/lite"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// node serving the commits of a chain, the other methods are left unimplemented
+type testNode struct {
+	rpcclient.Client
+	commits []lite.FullCommit
+}
+
+func (n testNode) Status() (*ctypes.ResultStatus, error) {
+	return &ctypes.ResultStatus{
+		SyncInfo: ctypes.SyncInfo{LatestBlockHeight: int64(len(n.commits))},
+	}, nil
+}
+
+func (n testNode) Commit(height *int64) (*ctypes.ResultCommit, error) {
+	h := int64(len(n.commits))
+	if height != nil {
+		h = *height
+	}
+	fc := n.commits[h-1]
+	return &ctypes.ResultCommit{SignedHeader: types.SignedHeader(fc.Commit)}, nil
+}
+
+func (n testNode) Validators(height *int64) (*ctypes.ResultValidators, error) {
+	// a nil height is the latest one
+	h := int64(len(n.commits))
+	if height != nil {
+		h = *height
+	}
+	fc := n.commits[h-1]
+	return &ctypes.ResultValidators{BlockHeight: h, Validators: fc.Validators.Validators}, nil
+}
+
+// a chain of five blocks whose validator set changes at the fourth
+func newTestNode(chainID string) testNode {
+	keys := lite.GenValKeys(4)
+	var commits []lite.FullCommit
+	for h := int64(1); h <= 5; h++ {
+		if h == 4 {
+			keys = keys.Extend(1)
+		}
+		vals := keys.ToValidators(10, 0)
+		commits = append(commits, keys.GenFullCommit(chainID, h, nil, vals, []byte("app"), []byte("params"), []byte("results"), 0, len(keys)))
+	}
+	return testNode{commits: commits}
+}
+
+func TestCertifiedCommit(t *testing.T) {
+	chainID := "test-chain"
+	node := newTestNode(chainID)
+	dir, err := ioutil.TempDir("", "lite")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// a checkpoint is needed until a commit is cached
+	_, err = NewCertifier(chainID, dir, node, 0, nil)
+	require.NotNil(t, err)
+	_, err = NewCertifier(chainID, dir, node, 1, []byte("wrong hash"))
+	require.NotNil(t, err)
+
+	trustedHash := node.commits[0].Commit.Header.Hash()
+	certifier, err := NewCertifier(chainID, dir, node, 1, trustedHash)
+	require.Nil(t, err)
+
+	// commits are certified across the change of the validator set
+	ctx := CoreContext{}.WithChainID(chainID).WithClient(node).WithCertifier(certifier)
+	commit, err := ctx.CertifiedCommit(5)
+	require.Nil(t, err)
+	require.Equal(t, node.commits[4].Commit.Header.Hash(), commit.Header.Hash())
+
+	// a forged commit is rejected
+	forged := newTestNode(chainID)
+	ctx = ctx.WithClient(forged)
+	_, err = ctx.CertifiedCommit(5)
+	require.NotNil(t, err)
+
+	// the certified commits are cached as the next checkpoint
+	certifier, err = NewCertifier(chainID, dir, node, 0, nil)
+	require.Nil(t, err)
+	require.Equal(t, int64(5), certifier.LastHeight())
+}
@@ -1,18 +1,21 @@
 package context
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/pkg/errors"
 
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	abci "github.com/tendermint/abci/types"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	cmn "github.com/tendermint/tmlibs/common"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/keys"
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -44,40 +47,85 @@ func (ctx CoreContext) BroadcastTx(tx []byte) (*ctypes.ResultBroadcastTxCommit,
 
 // Query from Tendermint with the provided key and storename
 func (ctx CoreContext) Query(key cmn.HexBytes, storeName string) (res []byte, err error) {
-	return ctx.query(key, storeName, "key")
+	resp, err := ctx.query(key, storeName, "key")
+	if err != nil {
+		return res, err
+	}
+	if !bytes.Equal(resp.Key, key) {
+		return res, errors.Errorf("response is for key %X, not %X", resp.Key, key)
+	}
+
+	// verify the response against the app hash of the next certified header
+	if !ctx.TrustNode {
+		commit, err := ctx.CertifiedCommit(resp.Height + 1)
+		if err != nil {
+			return res, err
+		}
+		err = VerifyProof(commit.Header.AppHash, storeName, key, resp)
+		if err != nil {
+			return res, err
+		}
+	}
+	return resp.Value, nil
 }
 
 // Query from Tendermint with the provided storename and subspace
 func (ctx CoreContext) QuerySubspace(cdc *wire.Codec, subspace []byte, storeName string) (res []sdk.KVPair, err error) {
-	resRaw, err := ctx.query(subspace, storeName, "subspace")
+	query := store.RangeQuery{subspace, sdk.PrefixEndBytes(subspace), 0}
+	return ctx.queryRange(cdc, subspace, storeName, "subspace", query)
+}
+
+// Query from Tendermint a page of the pairs of the store from the start key
+// up to the end key excluded, or up to the last key if end is nil, at most
+// limit of them unless it is zero. If limit pairs are returned the next page
+// starts right after the last key.
+func (ctx CoreContext) QueryRange(cdc *wire.Codec, start, end []byte, limit int, storeName string) (res []sdk.KVPair, err error) {
+	query := store.RangeQuery{start, end, limit}
+	return ctx.queryRange(cdc, query.Bytes(), storeName, "range", query)
+}
+
+func (ctx CoreContext) queryRange(cdc *wire.Codec, data cmn.HexBytes, storeName, endPath string, query store.RangeQuery) (res []sdk.KVPair, err error) {
+	resp, err := ctx.query(data, storeName, endPath)
 	if err != nil {
 		return res, err
 	}
-	cdc.MustUnmarshalBinary(resRaw, &res)
+	cdc.MustUnmarshalBinary(resp.Value, &res)
+
+	// verify the response against the app hash of the next certified header
+	if !ctx.TrustNode {
+		commit, err := ctx.CertifiedCommit(resp.Height + 1)
+		if err != nil {
+			return nil, err
+		}
+		err = VerifyRangeProof(commit.Header.AppHash, storeName, query, resp, res)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return
 }
 
 // Query from Tendermint with the provided storename and path
-func (ctx CoreContext) query(key cmn.HexBytes, storeName, endPath string) (res []byte, err error) {
+func (ctx CoreContext) query(data cmn.HexBytes, storeName, endPath string) (resp abci.ResponseQuery, err error) {
 	path := fmt.Sprintf("/store/%s/%s", storeName, endPath)
 	node, err := ctx.GetNode()
 	if err != nil {
-		return res, err
+		return resp, err
 	}
 
 	opts := rpcclient.ABCIQueryOptions{
 		Height:  ctx.Height,
 		Trusted: ctx.TrustNode,
 	}
-	result, err := node.ABCIQueryWithOptions(path, key, opts)
+	result, err := node.ABCIQueryWithOptions(path, data, opts)
 	if err != nil {
-		return res, err
+		return resp, err
 	}
-	resp := result.Response
+	resp = result.Response
 	if resp.Code != uint32(0) {
-		return res, errors.Errorf("Query failed: (%d) %s", resp.Code, resp.Log)
+		return resp, errors.Errorf("Query failed: (%d) %s", resp.Code, resp.Log)
 	}
-	return resp.Value, nil
+	return resp, nil
 }
 
 // Get the from address from the name flag
@@ -101,8 +149,8 @@ func (ctx CoreContext) GetFromAddress() (from sdk.Address, err error) {
 	return info.PubKey.Address(), nil
 }
 
-// sign and build the transaction from the msg
-func (ctx CoreContext) SignAndBuild(name, passphrase string, msg sdk.Msg, cdc *wire.Codec) ([]byte, error) {
+// sign and build the transaction from the msgs
+func (ctx CoreContext) SignAndBuild(name, passphrase string, msgs []sdk.Msg, cdc *wire.Codec) ([]byte, error) {
 
 	// build the Sign Messsage from the Standard Message
 	chainID := ctx.ChainID
@@ -112,12 +160,19 @@ func (ctx CoreContext) SignAndBuild(name, passphrase string, msg sdk.Msg, cdc *w
 	accnum := ctx.AccountNumber
 	sequence := ctx.Sequence
 
+	fee, err := ctx.stdFee()
+	if err != nil {
+		return nil, err
+	}
+
 	signMsg := auth.StdSignMsg{
 		ChainID:        chainID,
 		AccountNumbers: []int64{accnum},
 		Sequences:      []int64{sequence},
-		Msg:            msg,
-		Fee:            auth.NewStdFee(ctx.Gas, sdk.Coin{}), // TODO run simulate to estimate gas?
+		Msgs:           msgs,
+		Fee:            fee, // TODO run simulate to estimate gas?
+		Memo:           ctx.Memo,
+		TimeoutHeight:  ctx.TimeoutHeight,
 	}
 
 	keybase, err := keys.GetKeyBase()
@@ -140,13 +195,30 @@ func (ctx CoreContext) SignAndBuild(name, passphrase string, msg sdk.Msg, cdc *w
 	}}
 
 	// marshal bytes
-	tx := auth.NewStdTx(signMsg.Msg, signMsg.Fee, sigs)
+	tx := auth.NewStdTx(signMsg.Msgs, signMsg.Fee, sigs, signMsg.Memo, signMsg.TimeoutHeight)
 
 	return cdc.MarshalBinary(tx)
 }
 
-// sign and build the transaction from the msg
-func (ctx CoreContext) EnsureSignBuildBroadcast(name string, msg sdk.Msg, cdc *wire.Codec) (res *ctypes.ResultBroadcastTxCommit, err error) {
+// the fee of the transaction, paid by the fee granter if there is one
+func (ctx CoreContext) stdFee() (auth.StdFee, error) {
+	amount, err := sdk.ParseCoins(ctx.Fee)
+	if err != nil {
+		return auth.StdFee{}, err
+	}
+	fee := auth.NewStdFee(ctx.Gas, amount...)
+	if ctx.FeeGranter != "" {
+		granter, err := sdk.GetAccAddressBech32(ctx.FeeGranter)
+		if err != nil {
+			return auth.StdFee{}, err
+		}
+		fee = fee.WithGranter(granter)
+	}
+	return fee, nil
+}
+
+// sign and build the transaction from the msgs
+func (ctx CoreContext) EnsureSignBuildBroadcast(name string, msgs []sdk.Msg, cdc *wire.Codec) (res *ctypes.ResultBroadcastTxCommit, err error) {
 
 	ctx, err = EnsureAccountNumber(ctx)
 	if err != nil {
@@ -163,7 +235,7 @@ func (ctx CoreContext) EnsureSignBuildBroadcast(name string, msg sdk.Msg, cdc *w
 		return nil, err
 	}
 
-	txBytes, err := ctx.SignAndBuild(name, passphrase, msg, cdc)
+	txBytes, err := ctx.SignAndBuild(name, passphrase, msgs, cdc)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,59 @@
+package context
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+
+	abci "github.com/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// VerifyProof checks the proof of the response to a query of the key in the
+// named store, from the key and the value up to the app hash committing to the
+// queried height, which is found in the header of the following block. A
+// response without value is checked to prove the absence of the key.
+func VerifyProof(appHash []byte, storeName string, key []byte, resp abci.ResponseQuery) error {
+	if !bytes.Equal(resp.Key, key) {
+		return errors.Errorf("response is for key %X, not %X", resp.Key, key)
+	}
+	if len(resp.Proof) == 0 {
+		return errors.New("response has no proof")
+	}
+	proof, err := store.ReadMultiStoreProof(resp.Proof)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode proof")
+	}
+
+	var value []byte
+	if len(resp.Value) > 0 {
+		value = resp.Value
+	}
+	err = proof.Verify(appHash, storeName, key, value)
+	if err != nil {
+		return errors.Wrapf(err, "failed to verify proof of key %X at height %d", key, resp.Height)
+	}
+	return nil
+}
+
+// VerifyRangeProof checks the proof of the response to a subspace or range
+// query of the named store, that the decoded pairs are all the pairs of the
+// range or its first limit pairs, against the app hash committing to the
+// queried height.
+func VerifyRangeProof(appHash []byte, storeName string, query store.RangeQuery, resp abci.ResponseQuery, KVs []sdk.KVPair) error {
+	if len(resp.Proof) == 0 {
+		return errors.New("response has no proof")
+	}
+	proof, err := store.ReadMultiStoreProof(resp.Proof)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode proof")
+	}
+
+	err = proof.VerifyRange(appHash, storeName, query, KVs)
+	if err != nil {
+		return errors.Wrapf(err, "failed to verify proof of range %X-%X at height %d", query.Start, query.End, resp.Height)
+	}
+	return nil
+}
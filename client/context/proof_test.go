@@ -0,0 +1,75 @@
+package context
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/abci/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	cmn "github.com/tendermint/tmlibs/common"
+	dbm "github.com/tendermint/tmlibs/db"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestVerifyProof(t *testing.T) {
+	key := sdk.NewKVStoreKey("main")
+	multi := store.NewCommitMultiStore(dbm.NewMemDB())
+	multi.MountStoreWithDB(key, sdk.StoreTypeIAVL, nil)
+	require.Nil(t, multi.LoadLatestVersion())
+
+	k, v := []byte("wind"), []byte("blows")
+	k2, v2 := []byte("water"), []byte("flows")
+	kvStore := multi.GetKVStore(key)
+	kvStore.Set(k, v)
+	kvStore.Set(k2, v2)
+	cid := multi.Commit()
+
+	query := abci.RequestQuery{Path: "/main/key", Data: k, Height: cid.Version, Prove: true}
+	resp := multi.Query(query)
+	require.Equal(t, v, resp.Value)
+	require.Nil(t, VerifyProof(cid.Hash, "main", k, resp))
+	require.NotNil(t, VerifyProof([]byte("apphash"), "main", k, resp))
+
+	// a valid proof of another key than the requested one is rejected
+	query.Data = k2
+	resp = multi.Query(query)
+	require.Equal(t, v2, resp.Value)
+	require.Nil(t, VerifyProof(cid.Hash, "main", k2, resp))
+	require.NotNil(t, VerifyProof(cid.Hash, "main", k, resp))
+	resp.Key = k
+	require.NotNil(t, VerifyProof(cid.Hash, "main", k, resp))
+
+	// so is a response without proof
+	resp = multi.Query(query)
+	resp.Proof = nil
+	require.NotNil(t, VerifyProof(cid.Hash, "main", k2, resp))
+}
+
+// node answering every query with the same response
+type queryNode struct {
+	rpcclient.Client
+	resp abci.ResponseQuery
+}
+
+func (n queryNode) ABCIQueryWithOptions(path string, data cmn.HexBytes, opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	return &ctypes.ResultABCIQuery{Response: n.resp}, nil
+}
+
+func TestQueryOtherKey(t *testing.T) {
+	k, v := []byte("wind"), []byte("blows")
+	ctx := CoreContext{}.WithTrustNode(true)
+
+	ctx = ctx.WithClient(queryNode{resp: abci.ResponseQuery{Key: k, Value: v}})
+	res, err := ctx.Query(k, "main")
+	require.Nil(t, err)
+	require.Equal(t, v, res)
+
+	// the value of another key is not returned, even by a trusted node
+	ctx = ctx.WithClient(queryNode{resp: abci.ResponseQuery{Key: []byte("water"), Value: v}})
+	_, err = ctx.Query(k, "main")
+	require.NotNil(t, err)
+}
@@ -1,6 +1,7 @@
 package context
 
 import (
+	"github.com/tendermint/tendermint/lite"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -11,12 +12,17 @@ type CoreContext struct {
 	ChainID         string
 	Height          int64
 	Gas             int64
+	Fee             string
+	FeeGranter      string
+	Memo            string
+	TimeoutHeight   int64
 	TrustNode       bool
 	NodeURI         string
 	FromAddressName string
 	AccountNumber   int64
 	Sequence        int64
 	Client          rpcclient.Client
+	Certifier       lite.Certifier
 	Decoder         auth.AccountDecoder
 	AccountStore    string
 }
@@ -39,6 +45,30 @@ func (c CoreContext) WithGas(gas int64) CoreContext {
 	return c
 }
 
+// WithFee - return a copy of the context with an updated fee
+func (c CoreContext) WithFee(fee string) CoreContext {
+	c.Fee = fee
+	return c
+}
+
+// WithFeeGranter - return a copy of the context with an updated fee granter
+func (c CoreContext) WithFeeGranter(feeGranter string) CoreContext {
+	c.FeeGranter = feeGranter
+	return c
+}
+
+// WithMemo - return a copy of the context with an updated memo
+func (c CoreContext) WithMemo(memo string) CoreContext {
+	c.Memo = memo
+	return c
+}
+
+// WithTimeoutHeight - return a copy of the context with an updated timeout height
+func (c CoreContext) WithTimeoutHeight(timeoutHeight int64) CoreContext {
+	c.TimeoutHeight = timeoutHeight
+	return c
+}
+
 // WithTrustNode - return a copy of the context with an updated TrustNode flag
 func (c CoreContext) WithTrustNode(trustNode bool) CoreContext {
 	c.TrustNode = trustNode
@@ -76,6 +106,12 @@ func (c CoreContext) WithClient(client rpcclient.Client) CoreContext {
 	return c
 }
 
+// WithCertifier - return a copy of the context with an updated certifier
+func (c CoreContext) WithCertifier(certifier lite.Certifier) CoreContext {
+	c.Certifier = certifier
+	return c
+}
+
 // WithDecoder - return a copy of the context with an updated Decoder
 func (c CoreContext) WithDecoder(decoder auth.AccountDecoder) CoreContext {
 	c.Decoder = decoder
@@ -1,13 +1,17 @@
 package context
 
 import (
+	"encoding/hex"
 	"fmt"
+	"path/filepath"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 
 	tcmd "github.com/tendermint/tendermint/cmd/tendermint/commands"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	tmtypes "github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tmlibs/cli"
 
 	"github.com/cosmos/cosmos-sdk/client"
 )
@@ -31,6 +35,10 @@ func NewCoreContextFromViper() CoreContext {
 		ChainID:         chainID,
 		Height:          viper.GetInt64(client.FlagHeight),
 		Gas:             viper.GetInt64(client.FlagGas),
+		Fee:             viper.GetString(client.FlagFee),
+		FeeGranter:      viper.GetString(client.FlagFeeGranter),
+		Memo:            viper.GetString(client.FlagMemo),
+		TimeoutHeight:   viper.GetInt64(client.FlagTimeoutHeight),
 		TrustNode:       viper.GetBool(client.FlagTrustNode),
 		FromAddressName: viper.GetString(client.FlagName),
 		NodeURI:         nodeURI,
@@ -92,3 +100,30 @@ func EnsureSequence(ctx CoreContext) (CoreContext, error) {
 	ctx = ctx.WithSequence(seq)
 	return ctx, nil
 }
+
+// EnsureCertifier - automatically set up the certifier verifying the
+// responses of the node, from the checkpoint cached under the home directory
+// or the trusted height and hash flags
+func EnsureCertifier(ctx CoreContext) (CoreContext, error) {
+	if ctx.Certifier != nil {
+		return ctx, nil
+	}
+	if ctx.ChainID == "" {
+		return ctx, errors.New("Must define chain ID to verify responses")
+	}
+	node, err := ctx.GetNode()
+	if err != nil {
+		return ctx, err
+	}
+	trustedHash, err := hex.DecodeString(viper.GetString(client.FlagTrustedHash))
+	if err != nil {
+		return ctx, errors.Wrap(err, "invalid trusted hash")
+	}
+
+	dir := filepath.Join(viper.GetString(cli.HomeFlag), "lite", ctx.ChainID)
+	certifier, err := NewCertifier(ctx.ChainID, dir, node, viper.GetInt64(client.FlagTrustedHeight), trustedHash)
+	if err != nil {
+		return ctx, err
+	}
+	return ctx.WithCertifier(certifier), nil
+}
@@ -13,6 +13,11 @@ const (
 	FlagAccountNumber = "account-number"
 	FlagSequence      = "sequence"
 	FlagFee           = "fee"
+	FlagFeeGranter    = "fee-granter"
+	FlagMemo          = "memo"
+	FlagTimeoutHeight = "timeout-height"
+	FlagTrustedHeight = "trusted-height"
+	FlagTrustedHash   = "trusted-hash"
 )
 
 // LineBreak can be included in a command list to provide a blank line
@@ -27,6 +32,8 @@ func GetCommands(cmds ...*cobra.Command) []*cobra.Command {
 		c.Flags().String(FlagChainID, "", "Chain ID of tendermint node")
 		c.Flags().String(FlagNode, "tcp://localhost:46657", "<host>:<port> to tendermint rpc interface for this chain")
 		c.Flags().Int64(FlagHeight, 0, "block height to query, omit to get most recent provable block")
+		c.Flags().Int64(FlagTrustedHeight, 0, "Height of the header trusted to verify responses, until a header has been verified")
+		c.Flags().String(FlagTrustedHash, "", "Hex hash of the header at the trusted height")
 	}
 	return cmds
 }
@@ -38,6 +45,9 @@ func PostCommands(cmds ...*cobra.Command) []*cobra.Command {
 		c.Flags().Int64(FlagAccountNumber, 0, "AccountNumber number to sign the tx")
 		c.Flags().Int64(FlagSequence, 0, "Sequence number to sign the tx")
 		c.Flags().String(FlagFee, "", "Fee to pay along with transaction")
+		c.Flags().String(FlagFeeGranter, "", "Bech32 address of the account paying the fee from its fee allowance")
+		c.Flags().String(FlagMemo, "", "Memo to send along with transaction")
+		c.Flags().Int64(FlagTimeoutHeight, 0, "Last block height at which the transaction may be included, omit for no timeout")
 		c.Flags().String(FlagChainID, "", "Chain ID of tendermint node")
 		c.Flags().String(FlagNode, "tcp://localhost:46657", "<host>:<port> to tendermint rpc interface for this chain")
 		c.Flags().Int64(FlagGas, 200000, "gas limit to set per-transaction")
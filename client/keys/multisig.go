@@ -0,0 +1,207 @@
+package keys
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	crypto "github.com/tendermint/go-crypto"
+	keys "github.com/tendermint/go-crypto/keys"
+	"github.com/tendermint/tmlibs/cli"
+	dbm "github.com/tendermint/tmlibs/db"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/multisig"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// MultisigDBName is the name of the database, stored next to the keys,
+// holding the multisig public keys
+const MultisigDBName = "multisig"
+
+const flagThreshold = "threshold"
+
+// multisigDB is used to make GetMultisigDB a singleton
+var multisigDB dbm.DB
+
+// initialize the multisig key store based on the configuration, multisig
+// keys have no private key so they are kept outside of the keybase
+func GetMultisigDB() (dbm.DB, error) {
+	if multisigDB == nil {
+		rootDir := viper.GetString(cli.HomeFlag)
+		db, err := dbm.NewGoLevelDB(MultisigDBName, filepath.Join(rootDir, "keys"))
+		if err != nil {
+			return nil, err
+		}
+		multisigDB = db
+	}
+	return multisigDB, nil
+}
+
+// used to set the multisig key store manually in test
+func SetMultisigDB(db dbm.DB) {
+	multisigDB = db
+}
+
+// get a multisig public key by name
+func getMultisigKey(name string) (multisig.PubKeyMultisigThreshold, error) {
+	var pubkey crypto.PubKey
+	db, err := GetMultisigDB()
+	if err != nil {
+		return multisig.PubKeyMultisigThreshold{}, err
+	}
+	bz := db.Get([]byte(name))
+	if bz == nil {
+		return multisig.PubKeyMultisigThreshold{}, fmt.Errorf("No multisig key found with name %s", name)
+	}
+	err = cdc.UnmarshalBinaryBare(bz, &pubkey)
+	if err != nil {
+		return multisig.PubKeyMultisigThreshold{}, err
+	}
+	multisigKey, ok := pubkey.(multisig.PubKeyMultisigThreshold)
+	if !ok {
+		return multisig.PubKeyMultisigThreshold{}, fmt.Errorf("Key %s is not a multisig key", name)
+	}
+	return multisigKey, nil
+}
+
+func addMultisigKeyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-multisig <name> <key>...",
+		Short: "Create a multisig key from existing keys",
+		Long: `Add a multisig public key, built from the public keys of existing
+local keys, requiring --threshold of them to sign.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: runAddMultisigCmd,
+	}
+	cmd.Flags().Int(flagThreshold, 1, "Number of signatures required")
+	return cmd
+}
+
+func runAddMultisigCmd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	kb, err := GetKeyBase()
+	if err != nil {
+		return err
+	}
+
+	pubkeys := make([]crypto.PubKey, len(args)-1)
+	for i, keyName := range args[1:] {
+		info, err := kb.Get(keyName)
+		if err != nil {
+			return err
+		}
+		pubkeys[i] = info.PubKey
+	}
+
+	threshold := viper.GetInt(flagThreshold)
+	if threshold <= 0 || threshold > len(pubkeys) {
+		return errors.Errorf("threshold must be between 1 and %d", len(pubkeys))
+	}
+	pubkey := multisig.NewPubKeyMultisigThreshold(threshold, pubkeys)
+
+	db, err := GetMultisigDB()
+	if err != nil {
+		return err
+	}
+	bz, err := cdc.MarshalBinaryBare(pubkey)
+	if err != nil {
+		return err
+	}
+	db.SetSync([]byte(name), bz)
+
+	printInfo(keys.Info{Name: name, PubKey: pubkey})
+	return nil
+}
+
+// SignatureOutput is a signature along with the public key which made it
+type SignatureOutput struct {
+	PubKey    crypto.PubKey    `json:"pub_key"`
+	Signature crypto.Signature `json:"signature"`
+}
+
+func signCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign <name> <file>",
+		Short: "Sign the content of a file",
+		Long: `Sign the bytes of a file with a local key, the resulting signature
+may be combined with the signatures of other keys of a multisig.`,
+		Args: cobra.ExactArgs(2),
+		RunE: runSignCmd,
+	}
+	return cmd
+}
+
+func runSignCmd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	msg, err := ioutil.ReadFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	kb, err := GetKeyBase()
+	if err != nil {
+		return err
+	}
+	buf := client.BufferStdin()
+	pass, err := client.GetPassword(
+		"Password to sign with '"+name+"':", buf)
+	if err != nil {
+		return err
+	}
+	sig, pubkey, err := kb.Sign(name, pass, msg)
+	if err != nil {
+		return err
+	}
+	return printSignature(SignatureOutput{pubkey, sig})
+}
+
+func combineSignaturesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "combine-signatures <multisig-name> <signature-file>...",
+		Short: "Combine signatures into a multisig signature",
+		Long: `Combine signatures, as output by the sign command, of keys of a
+multisig into a signature of the multisig key.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: runCombineSignaturesCmd,
+	}
+	return cmd
+}
+
+func runCombineSignaturesCmd(cmd *cobra.Command, args []string) error {
+	pubkey, err := getMultisigKey(args[0])
+	if err != nil {
+		return err
+	}
+
+	multisignature := multisig.NewSignatureMultisig(len(pubkey.PubKeys))
+	for _, file := range args[1:] {
+		bz, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		var sig SignatureOutput
+		err = cdc.UnmarshalJSON(bz, &sig)
+		if err != nil {
+			return err
+		}
+		err = multisignature.AddSignatureFromPubKey(sig.Signature, sig.PubKey, pubkey.PubKeys)
+		if err != nil {
+			return errors.Wrap(err, file)
+		}
+	}
+	return printSignature(SignatureOutput{pubkey, *multisignature})
+}
+
+func printSignature(sig SignatureOutput) error {
+	out, err := wire.MarshalJSONIndent(cdc, sig)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
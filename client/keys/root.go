@@ -23,6 +23,10 @@ func Commands() *cobra.Command {
 		listKeysCmd,
 		showKeysCmd,
 		client.LineBreak,
+		addMultisigKeyCommand(),
+		signCommand(),
+		combineSignaturesCommand(),
+		client.LineBreak,
 		deleteKeyCommand(),
 		updateKeyCommand(),
 	)
@@ -31,6 +31,10 @@ func getKey(name string) (keys.Info, error) {
 		return keys.Info{}, err
 	}
 
+	// multisig keys have no private key and are stored apart
+	if pubkey, err := getMultisigKey(name); err == nil {
+		return keys.Info{Name: name, PubKey: pubkey}, nil
+	}
 	return kb.Get(name)
 }
 
@@ -58,6 +58,9 @@ func ServeCommand(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().String(flagCORS, "", "Set to domains that can make CORS requests (* for all)")
 	cmd.Flags().StringP(client.FlagChainID, "c", "", "ID of chain we connect to")
 	cmd.Flags().StringP(client.FlagNode, "n", "tcp://localhost:46657", "Node to connect to")
+	cmd.Flags().Bool(client.FlagTrustNode, true, "Don't verify proofs for responses")
+	cmd.Flags().Int64(client.FlagTrustedHeight, 0, "Height of the header trusted to verify responses, until a header has been verified")
+	cmd.Flags().String(client.FlagTrustedHash, "", "Hex hash of the header at the trusted height")
 	return cmd
 }
 
@@ -71,6 +74,12 @@ func createHandler(cdc *wire.Codec) http.Handler {
 	}
 
 	ctx := context.NewCoreContextFromViper()
+	if !ctx.TrustNode {
+		ctx, err = context.EnsureCertifier(ctx)
+		if err != nil {
+			panic(err)
+		}
+	}
 
 	// TODO make more functional? aka r = keys.RegisterRoutes(r)
 	keys.RegisterRoutes(r)
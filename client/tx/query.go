@@ -46,6 +46,9 @@ func QueryTxCmd(cdc *wire.Codec) *cobra.Command {
 
 	// TODO: change this to false when we can
 	cmd.Flags().Bool(client.FlagTrustNode, true, "Don't verify proofs for responses")
+	cmd.Flags().String(client.FlagChainID, "", "Chain ID of tendermint node")
+	cmd.Flags().Int64(client.FlagTrustedHeight, 0, "Height of the header trusted to verify responses, until a header has been verified")
+	cmd.Flags().String(client.FlagTrustedHash, "", "Hex hash of the header at the trusted height")
 	return cmd
 }
 
@@ -65,6 +68,12 @@ func queryTx(cdc *wire.Codec, ctx context.CoreContext, hashHexStr string, trustN
 	if err != nil {
 		return nil, err
 	}
+	if !trustNode {
+		err = ctx.VerifyTx(res)
+		if err != nil {
+			return nil, err
+		}
+	}
 	info, err := formatTxResult(cdc, res)
 	if err != nil {
 		return nil, err
@@ -74,7 +83,6 @@ func queryTx(cdc *wire.Codec, ctx context.CoreContext, hashHexStr string, trustN
 }
 
 func formatTxResult(cdc *wire.Codec, res *ctypes.ResultTx) (txInfo, error) {
-	// TODO: verify the proof if requested
 	tx, err := parseTx(cdc, res.Tx)
 	if err != nil {
 		return txInfo{}, err
@@ -2,6 +2,7 @@ package app
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 
 	abci "github.com/tendermint/abci/types"
@@ -14,8 +15,13 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	distribution "github.com/cosmos/cosmos-sdk/x/fee_distribution"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 )
@@ -36,11 +42,18 @@ type GaiaApp struct {
 	cdc *wire.Codec
 
 	// keys to access the substores
-	keyMain     *sdk.KVStoreKey
-	keyAccount  *sdk.KVStoreKey
-	keyIBC      *sdk.KVStoreKey
-	keyStake    *sdk.KVStoreKey
-	keySlashing *sdk.KVStoreKey
+	keyMain          *sdk.KVStoreKey
+	keyAccount       *sdk.KVStoreKey
+	keyBank          *sdk.KVStoreKey
+	keyIBC           *sdk.KVStoreKey
+	keyStake         *sdk.KVStoreKey
+	keySlashing      *sdk.KVStoreKey
+	keyFeeCollection *sdk.KVStoreKey
+	keyDistr         *sdk.KVStoreKey
+	keyGov           *sdk.KVStoreKey
+	keyFeeGrant      *sdk.KVStoreKey
+	keyAuthz         *sdk.KVStoreKey
+	keyParams        *sdk.KVStoreKey
 
 	// Manage getting and setting accounts
 	accountMapper       auth.AccountMapper
@@ -49,6 +62,16 @@ type GaiaApp struct {
 	ibcMapper           ibc.Mapper
 	stakeKeeper         stake.Keeper
 	slashingKeeper      slashing.Keeper
+	distrKeeper         distribution.Keeper
+	govKeeper           gov.Keeper
+	feeGrantKeeper      feegrant.Keeper
+	authzKeeper         authz.Keeper
+	paramsKeeper        params.Keeper
+
+	// invariants which must hold after every block, checked at the end of
+	// the blocks at multiples of the period unless it is zero
+	invariants     []sdk.Invariant
+	invCheckPeriod int64
 }
 
 func NewGaiaApp(logger log.Logger, db dbm.DB) *GaiaApp {
@@ -56,16 +79,24 @@ func NewGaiaApp(logger log.Logger, db dbm.DB) *GaiaApp {
 
 	// create your application object
 	var app = &GaiaApp{
-		BaseApp:     bam.NewBaseApp(appName, cdc, logger, db),
-		cdc:         cdc,
-		keyMain:     sdk.NewKVStoreKey("main"),
-		keyAccount:  sdk.NewKVStoreKey("acc"),
-		keyIBC:      sdk.NewKVStoreKey("ibc"),
-		keyStake:    sdk.NewKVStoreKey("stake"),
-		keySlashing: sdk.NewKVStoreKey("slashing"),
+		BaseApp:          bam.NewBaseApp(appName, cdc, logger, db),
+		cdc:              cdc,
+		keyMain:          sdk.NewKVStoreKey("main"),
+		keyAccount:       sdk.NewKVStoreKey("acc"),
+		keyBank:          sdk.NewKVStoreKey("bank"),
+		keyIBC:           sdk.NewKVStoreKey("ibc"),
+		keyStake:         sdk.NewKVStoreKey("stake"),
+		keySlashing:      sdk.NewKVStoreKey("slashing"),
+		keyFeeCollection: sdk.NewKVStoreKey("fee"),
+		keyDistr:         sdk.NewKVStoreKey("distr"),
+		keyGov:           sdk.NewKVStoreKey("gov"),
+		keyFeeGrant:      sdk.NewKVStoreKey("feegrant"),
+		keyAuthz:         sdk.NewKVStoreKey("authz"),
+		keyParams:        sdk.NewKVStoreKey("params"),
 	}
 
-	// define the accountMapper
+	// define the accountMapper, gaia does not reap empty accounts so it
+	// does not queue them
 	app.accountMapper = auth.NewAccountMapper(
 		app.cdc,
 		app.keyAccount,      // target store
@@ -73,24 +104,49 @@ func NewGaiaApp(logger log.Logger, db dbm.DB) *GaiaApp {
 	)
 
 	// add handlers
-	app.coinKeeper = bank.NewKeeper(app.accountMapper)
+	app.paramsKeeper = params.NewKeeper(app.cdc, app.keyParams)
+	app.feeCollectionKeeper = auth.NewFeeCollectionKeeper(app.cdc, app.keyFeeCollection)
+	app.coinKeeper = bank.NewKeeper(app.cdc, app.keyBank, app.accountMapper, app.RegisterCodespace(bank.DefaultCodespace))
 	app.ibcMapper = ibc.NewMapper(app.cdc, app.keyIBC, app.RegisterCodespace(ibc.DefaultCodespace))
-	app.stakeKeeper = stake.NewKeeper(app.cdc, app.keyStake, app.coinKeeper, app.RegisterCodespace(stake.DefaultCodespace))
-	app.slashingKeeper = slashing.NewKeeper(app.cdc, app.keySlashing, app.stakeKeeper, app.RegisterCodespace(slashing.DefaultCodespace))
+	app.stakeKeeper = stake.NewKeeper(app.cdc, app.keyStake, app.coinKeeper,
+		app.paramsKeeper.Subspace(stake.DefaultParamspace), app.RegisterCodespace(stake.DefaultCodespace))
+	app.distrKeeper = distribution.NewKeeper(app.cdc, app.keyDistr, app.coinKeeper, app.feeCollectionKeeper,
+		app.stakeKeeper, app.stakeKeeper, app.paramsKeeper.Subspace(distribution.DefaultParamspace),
+		app.RegisterCodespace(distribution.DefaultCodespace))
+	app.stakeKeeper = app.stakeKeeper.WithHooks(app.distrKeeper.Hooks())
+	app.slashingKeeper = slashing.NewKeeper(app.cdc, app.keySlashing, app.stakeKeeper,
+		app.paramsKeeper.Subspace(slashing.DefaultParamspace), app.RegisterCodespace(slashing.DefaultCodespace))
+	app.govKeeper = gov.NewKeeper(app.cdc, app.keyGov, app.coinKeeper, app.stakeKeeper, app.stakeKeeper,
+		app.paramsKeeper.Subspace(gov.DefaultParamspace), app.RegisterCodespace(gov.DefaultCodespace)).
+		AddProposalHandler(gov.ProposalTypeParameterChange, gov.NewParamChangeProposalHandler(app.paramsKeeper))
+	app.feeGrantKeeper = feegrant.NewKeeper(app.cdc, app.keyFeeGrant, app.RegisterCodespace(feegrant.DefaultCodespace))
+	app.authzKeeper = authz.NewKeeper(app.cdc, app.keyAuthz, app.RegisterCodespace(authz.DefaultCodespace))
+
+	// register the invariants
+	app.invariants = []sdk.Invariant{
+		bank.SupplyInvariant(app.coinKeeper, app.coinHolders()...),
+		auth.AccountNumberInvariant(app.accountMapper),
+	}
 
 	// register message routes
 	app.Router().
 		AddRoute("bank", bank.NewHandler(app.coinKeeper)).
 		AddRoute("ibc", ibc.NewHandler(app.ibcMapper, app.coinKeeper)).
 		AddRoute("stake", stake.NewHandler(app.stakeKeeper)).
-		AddRoute("slashing", slashing.NewHandler(app.slashingKeeper))
+		AddRoute("slashing", slashing.NewHandler(app.slashingKeeper)).
+		AddRoute("distr", distribution.NewHandler(app.distrKeeper)).
+		AddRoute("gov", gov.NewHandler(app.govKeeper)).
+		AddRoute("feegrant", feegrant.NewHandler(app.feeGrantKeeper)).
+		AddRoute("authz", authz.NewHandler(app.authzKeeper, app.Router()))
 
 	// initialize BaseApp
 	app.SetInitChainer(app.initChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetEndBlocker(app.EndBlocker)
-	app.SetAnteHandler(auth.NewAnteHandler(app.accountMapper, app.feeCollectionKeeper))
-	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyIBC, app.keyStake, app.keySlashing)
+	app.SetAnteDecorators(auth.NewAnteDecorators(app.accountMapper, app.feeCollectionKeeper,
+		app.feeGrantKeeper, auth.DefaultGasConfig())...)
+	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyBank, app.keyIBC, app.keyStake, app.keySlashing,
+		app.keyFeeCollection, app.keyDistr, app.keyGov, app.keyFeeGrant, app.keyAuthz, app.keyParams)
 	err := app.LoadLatestVersion(app.keyMain)
 	if err != nil {
 		cmn.Exit(err.Error())
@@ -106,6 +162,10 @@ func MakeCodec() *wire.Codec {
 	bank.RegisterWire(cdc)
 	stake.RegisterWire(cdc)
 	slashing.RegisterWire(cdc)
+	distribution.RegisterWire(cdc)
+	gov.RegisterWire(cdc)
+	feegrant.RegisterWire(cdc)
+	authz.RegisterWire(cdc)
 	auth.RegisterWire(cdc)
 	sdk.RegisterWire(cdc)
 	wire.RegisterCrypto(cdc)
@@ -114,6 +174,8 @@ func MakeCodec() *wire.Codec {
 
 // application updates every end block
 func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	// distribute the fees collected in the previous block before any slashing
+	distribution.BeginBlocker(ctx, req, app.distrKeeper)
 	tags := slashing.BeginBlocker(ctx, req, app.slashingKeeper)
 
 	return abci.ResponseBeginBlock{
@@ -123,10 +185,19 @@ func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) ab
 
 // application updates every end block
 func (app *GaiaApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
+	tags := gov.EndBlocker(ctx, app.govKeeper)
 	validatorUpdates := stake.EndBlocker(ctx, app.stakeKeeper)
 
+	// halt rather than commit a state breaking an invariant
+	if app.invCheckPeriod > 0 && ctx.BlockHeight()%app.invCheckPeriod == 0 {
+		if err := app.CheckInvariants(ctx); err != nil {
+			panic(fmt.Sprintf("invariant broken at height %d: %v", ctx.BlockHeight(), err))
+		}
+	}
+
 	return abci.ResponseEndBlock{
 		ValidatorUpdates: validatorUpdates,
+		Tags:             tags.ToKVPairs(),
 	}
 }
 
@@ -142,22 +213,89 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 		// return sdk.ErrGenesisParse("").TraceCause(err, "")
 	}
 
-	// load the accounts
+	// load the accounts, the accounts of an exported state keep their
+	// account numbers while new genesis accounts are numbered in order
 	for _, gacc := range genesisState.Accounts {
+		if err := gacc.validate(); err != nil {
+			panic(err)
+		}
 		acc := gacc.ToAccount()
-		acc.AccountNumber = app.accountMapper.GetNextAccountNumber(ctx)
+		if genesisState.AuthData.NextAccountNumber == 0 {
+			acc.SetAccountNumber(app.accountMapper.GetNextAccountNumber(ctx))
+		}
 		app.accountMapper.SetAccount(ctx, acc)
 	}
+	auth.InitGenesis(ctx, app.accountMapper, app.feeCollectionKeeper, genesisState.AuthData)
+
+	// load the issued denominations
+	bank.InitGenesis(ctx, app.coinKeeper, genesisState.BankData)
+
+	// load the IBC state of the chains
+	ibc.InitGenesis(ctx, app.ibcMapper, genesisState.IBCData)
 
 	// load the initial stake information
 	stake.InitGenesis(ctx, app.stakeKeeper, genesisState.StakeData)
 
+	// load the initial slashing information
+	slashing.InitGenesis(ctx, app.slashingKeeper, genesisState.SlashingData)
+
+	// load the initial fee distribution information
+	distribution.InitGenesis(ctx, app.distrKeeper, genesisState.DistrData)
+
+	// load the initial governance information
+	gov.InitGenesis(ctx, app.govKeeper, genesisState.GovData)
+
+	// load the fee allowances and authorizations
+	feegrant.InitGenesis(ctx, app.feeGrantKeeper, genesisState.FeeGrantData)
+	authz.InitGenesis(ctx, app.authzKeeper, genesisState.AuthzData)
+
+	// record the supply once all coins have been allocated
+	bank.InitSupply(ctx, app.coinKeeper, app.coinHolders()...)
+
 	return abci.ResponseInitChain{}
 }
 
-// export the state of gaia for a genesis file
-func (app *GaiaApp) ExportAppStateAndValidators() (appState json.RawMessage, validators []tmtypes.GenesisValidator, err error) {
-	ctx := app.NewContext(true, abci.Header{})
+// the modules holding coins outside of any account
+func (app *GaiaApp) coinHolders() []bank.CoinHolder {
+	return []bank.CoinHolder{
+		app.feeCollectionKeeper.GetCollectedFees,
+		app.ibcMapper.HeldCoins,
+		app.stakeKeeper.HeldCoins,
+		app.distrKeeper.HeldCoins,
+		app.govKeeper.HeldCoins,
+	}
+}
+
+// set how often the invariants are checked at the end of a block, never if
+// the period is zero
+func (app *GaiaApp) SetInvariantCheckPeriod(period int64) {
+	app.invCheckPeriod = period
+}
+
+// CheckInvariants returns the first violated invariant of the state of gaia
+func (app *GaiaApp) CheckInvariants(ctx sdk.Context) error {
+	for _, invariant := range app.invariants {
+		if err := invariant(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// load the state of gaia at a height, for exports of past heights
+func (app *GaiaApp) LoadHeight(height int64) error {
+	return app.LoadVersion(height, app.keyMain)
+}
+
+// export the state of gaia for a genesis file, rebased for a chain
+// restarting at height zero if forZeroHeight is set
+func (app *GaiaApp) ExportAppStateAndValidators(forZeroHeight bool) (appState json.RawMessage, validators []tmtypes.GenesisValidator, err error) {
+	ctx := app.NewContext(true, abci.Header{Height: app.LastBlockHeight()})
+
+	// a broken state is not exported into a new genesis
+	if err := app.CheckInvariants(ctx); err != nil {
+		return nil, nil, err
+	}
 
 	// iterate to get the accounts
 	accounts := []GenesisAccount{}
@@ -169,8 +307,19 @@ func (app *GaiaApp) ExportAppStateAndValidators() (appState json.RawMessage, val
 	app.accountMapper.IterateAccounts(ctx, appendAccount)
 
 	genState := GenesisState{
-		Accounts:  accounts,
-		StakeData: stake.WriteGenesis(ctx, app.stakeKeeper),
+		Accounts:     accounts,
+		AuthData:     auth.WriteGenesis(ctx, app.accountMapper, app.feeCollectionKeeper),
+		BankData:     bank.WriteGenesis(ctx, app.coinKeeper),
+		IBCData:      ibc.WriteGenesis(ctx, app.ibcMapper),
+		StakeData:    stake.WriteGenesis(ctx, app.stakeKeeper),
+		SlashingData: slashing.WriteGenesis(ctx, app.slashingKeeper),
+		DistrData:    distribution.WriteGenesis(ctx, app.distrKeeper),
+		GovData:      gov.WriteGenesis(ctx, app.govKeeper),
+		FeeGrantData: feegrant.WriteGenesis(ctx, app.feeGrantKeeper),
+		AuthzData:    authz.WriteGenesis(ctx, app.authzKeeper),
+	}
+	if forZeroHeight {
+		genState = genState.ForZeroHeight(app.LastBlockHeight())
 	}
 	appState, err = wire.MarshalJSONIndent(app.cdc, genState)
 	if err != nil {
@@ -1,11 +1,28 @@
 package app
 
 import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	distribution "github.com/cosmos/cosmos-sdk/x/fee_distribution"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 
 	abci "github.com/tendermint/abci/types"
+	crypto "github.com/tendermint/go-crypto"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tmlibs/db"
+	"github.com/tendermint/tmlibs/log"
 )
 
 func setGenesis(gapp *GaiaApp, accs ...*auth.BaseAccount) error {
@@ -15,8 +32,16 @@ func setGenesis(gapp *GaiaApp, accs ...*auth.BaseAccount) error {
 	}
 
 	genesisState := GenesisState{
-		Accounts:  genaccs,
-		StakeData: stake.DefaultGenesisState(),
+		Accounts:     genaccs,
+		AuthData:     auth.DefaultGenesisState(),
+		BankData:     bank.DefaultGenesisState(),
+		IBCData:      ibc.DefaultGenesisState(),
+		StakeData:    stake.DefaultGenesisState(),
+		SlashingData: slashing.DefaultGenesisState(),
+		DistrData:    distribution.DefaultGenesisState(),
+		GovData:      gov.DefaultGenesisState(),
+		FeeGrantData: feegrant.DefaultGenesisState(),
+		AuthzData:    authz.DefaultGenesisState(),
 	}
 
 	stateBytes, err := wire.MarshalJSONIndent(gapp.cdc, genesisState)
@@ -31,3 +56,126 @@ func setGenesis(gapp *GaiaApp, accs ...*auth.BaseAccount) error {
 
 	return nil
 }
+
+func TestGaiaSupplyInvariant(t *testing.T) {
+	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "sdk/app")
+	gapp := NewGaiaApp(logger, dbm.NewMemDB())
+
+	addr1 := crypto.GenPrivKeyEd25519().PubKey().Address()
+	addr2 := crypto.GenPrivKeyEd25519().PubKey().Address()
+	acc1 := &auth.BaseAccount{Address: addr1, Coins: sdk.Coins{{"foocoin", 10}, {"steak", 100}}}
+	acc2 := &auth.BaseAccount{Address: addr2, Coins: sdk.Coins{{"steak", 50}}}
+	require.Nil(t, setGenesis(gapp, acc1, acc2))
+
+	// the supply is recorded at genesis
+	ctx := gapp.NewContext(true, abci.Header{})
+	require.Equal(t, sdk.Coins{{"foocoin", 10}, {"steak", 150}}, gapp.coinKeeper.GetSupply(ctx))
+	require.Nil(t, gapp.CheckInvariants(ctx))
+
+	// coins created without going through the supply break the invariant
+	gapp.coinKeeper.AddCoins(ctx, addr2, sdk.Coins{{"steak", 1}})
+	require.NotNil(t, gapp.CheckInvariants(ctx))
+}
+
+func TestGaiaInvariantCheckPeriod(t *testing.T) {
+	gapp := NewGaiaApp(log.NewNopLogger(), dbm.NewMemDB())
+	addr1 := crypto.GenPrivKeyEd25519().PubKey().Address()
+	acc1 := &auth.BaseAccount{Address: addr1, Coins: sdk.Coins{{"steak", 100}}}
+	require.Nil(t, setGenesis(gapp, acc1))
+	gapp.SetInvariantCheckPeriod(2)
+
+	// the invariants hold at the end of the checked blocks
+	runBlock(t, gapp, 2, nil)
+
+	// blocks between the checks are not checked
+	header := abci.Header{Height: 3}
+	gapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	gapp.coinKeeper.AddCoins(gapp.NewContext(false, header), addr1, sdk.Coins{{"steak", 1}})
+	gapp.EndBlock(abci.RequestEndBlock{Height: 3})
+	gapp.Commit()
+
+	// the broken state is neither exported nor carried past the next check
+	_, _, err := gapp.ExportAppStateAndValidators(false)
+	require.NotNil(t, err)
+	header = abci.Header{Height: 4}
+	gapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	require.Panics(t, func() { gapp.EndBlock(abci.RequestEndBlock{Height: 4}) })
+}
+
+// deliver the msgs in a block, without going through the ante handler
+func runBlock(t *testing.T, gapp *GaiaApp, height int64, validators []abci.SigningValidator, msgs ...sdk.Msg) {
+	header := abci.Header{Height: height, Time: height * 10}
+	gapp.BeginBlock(abci.RequestBeginBlock{Header: header, Validators: validators})
+	ctx := gapp.NewContext(false, header)
+	for _, msg := range msgs {
+		res := gapp.Router().Route(msg.Type())(ctx, msg)
+		require.True(t, res.IsOK(), res.Log)
+	}
+	gapp.EndBlock(abci.RequestEndBlock{Height: height})
+	gapp.Commit()
+}
+
+func TestGaiaExportImport(t *testing.T) {
+	gapp := NewGaiaApp(log.NewNopLogger(), dbm.NewMemDB())
+
+	priv1 := crypto.GenPrivKeyEd25519()
+	addr1 := priv1.PubKey().Address()
+	addr2 := crypto.GenPrivKeyEd25519().PubKey().Address()
+	addr3 := crypto.GenPrivKeyEd25519().PubKey().Address()
+	acc1 := &auth.BaseAccount{Address: addr1, Coins: sdk.Coins{{"foocoin", 100}, {"steak", 1000}}}
+	acc2 := &auth.BaseAccount{Address: addr2, Coins: sdk.Coins{{"steak", 100}}}
+	acc3 := &auth.BaseAccount{Address: addr3}
+	require.Nil(t, setGenesis(gapp, acc1, acc2, acc3))
+
+	// change the state of every module over a few blocks
+	validators := []abci.SigningValidator{{
+		Validator:       tmtypes.TM2PB.ValidatorFromPubKeyAndPower(priv1.PubKey(), 100),
+		SignedLastBlock: true,
+	}}
+	runBlock(t, gapp, 2, nil,
+		stake.NewMsgCreateValidator(addr1, priv1.PubKey(), sdk.Coin{"steak", 100}, stake.Description{Moniker: "val"}),
+		bank.NewMsgSend([]bank.Input{bank.NewInput(addr1, sdk.Coins{{"foocoin", 10}})},
+			[]bank.Output{bank.NewOutput(addr2, sdk.Coins{{"foocoin", 10}})}),
+		ibc.IBCTransferMsg{ibc.NewIBCPacket(addr1, addr2, sdk.Coins{{"foocoin", 5}}, "gaia", "other-chain")},
+		feegrant.NewMsgGrantFeeAllowance(addr1, addr2, sdk.Coins{{"steak", 10}}, 0),
+		authz.NewMsgGrantAuthorization(addr1, addr2, authz.NewSendAuthorization(sdk.Coins{{"foocoin", 10}}), 1000),
+		gov.NewMsgSubmitProposal("title", "description", gov.ProposalTypeText, addr2, sdk.Coins{{"steak", 5}}),
+	)
+	runBlock(t, gapp, 3, validators, stake.NewMsgDelegate(addr2, addr1, sdk.Coin{"steak", 50}))
+	runBlock(t, gapp, 4, validators, stake.NewMsgUnbond(addr2, addr1, "10"))
+	runBlock(t, gapp, 5, validators)
+
+	appState, validatorSet, err := gapp.ExportAppStateAndValidators(false)
+	require.Nil(t, err)
+	require.Len(t, validatorSet, 1)
+
+	// the exported state is imported as it was
+	newGapp := NewGaiaApp(log.NewNopLogger(), dbm.NewMemDB())
+	newGapp.InitChain(abci.RequestInitChain{AppStateBytes: appState})
+	newGapp.Commit()
+	newAppState, newValidatorSet, err := newGapp.ExportAppStateAndValidators(false)
+	require.Nil(t, err)
+	require.Equal(t, string(appState), string(newAppState))
+	require.Equal(t, validatorSet, newValidatorSet)
+	require.Nil(t, newGapp.CheckInvariants(newGapp.NewContext(true, abci.Header{})))
+
+	// the exported state holds what was not exported before
+	var genesisState GenesisState
+	require.Nil(t, gapp.cdc.UnmarshalJSON(appState, &genesisState))
+	require.Equal(t, int64(3), genesisState.AuthData.NextAccountNumber)
+	require.Empty(t, genesisState.AuthData.EmptyAccounts)
+	require.Len(t, genesisState.IBCData.Chains, 1)
+	require.Len(t, genesisState.SlashingData.SigningInfos, 1)
+	require.Len(t, genesisState.FeeGrantData.FeeAllowances, 1)
+	require.Len(t, genesisState.AuthzData.Grants, 1)
+	require.Len(t, genesisState.StakeData.UnbondingDelegations, 1)
+	require.Equal(t, int64(4), genesisState.StakeData.UnbondingDelegations[0].CreationHeight)
+
+	// exports for zero height rebase the heights
+	appState, _, err = gapp.ExportAppStateAndValidators(true)
+	require.Nil(t, err)
+	require.Nil(t, gapp.cdc.UnmarshalJSON(appState, &genesisState))
+	require.Equal(t, int64(-1), genesisState.StakeData.UnbondingDelegations[0].CreationHeight)
+	require.Equal(t, int64(0), genesisState.GovData.Proposals[0].SubmitBlock)
+	require.Equal(t, int64(0), genesisState.SlashingData.SigningInfos[0].SigningInfo.StartHeight)
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -13,41 +14,149 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	distribution "github.com/cosmos/cosmos-sdk/x/fee_distribution"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 )
 
 // State to Unmarshal
 type GenesisState struct {
-	Accounts  []GenesisAccount   `json:"accounts"`
-	StakeData stake.GenesisState `json:"stake"`
+	Accounts     []GenesisAccount          `json:"accounts"`
+	AuthData     auth.GenesisState         `json:"auth"`
+	BankData     bank.GenesisState         `json:"bank"`
+	IBCData      ibc.GenesisState          `json:"ibc"`
+	StakeData    stake.GenesisState        `json:"stake"`
+	SlashingData slashing.GenesisState     `json:"slashing"`
+	DistrData    distribution.GenesisState `json:"distr"`
+	GovData      gov.GenesisState          `json:"gov"`
+	FeeGrantData feegrant.GenesisState     `json:"feegrant"`
+	AuthzData    authz.GenesisState        `json:"authz"`
 }
 
-// GenesisAccount doesn't need pubkey or sequence
+// ForZeroHeight rebases the state exported at a height for a chain
+// restarting at height zero
+func (gs GenesisState) ForZeroHeight(height int64) GenesisState {
+	gs.AuthData = gs.AuthData.ForZeroHeight(height)
+	gs.StakeData = gs.StakeData.ForZeroHeight(height)
+	gs.SlashingData = gs.SlashingData.ForZeroHeight(height)
+	gs.GovData = gs.GovData.ForZeroHeight(height)
+	return gs
+}
+
+// GenesisAccount doesn't need pubkey, account number or sequence, which are
+// only set for accounts of an exported state
 type GenesisAccount struct {
-	Address sdk.Address `json:"address"`
-	Coins   sdk.Coins   `json:"coins"`
+	Address       sdk.Address   `json:"address"`
+	Coins         sdk.Coins     `json:"coins"`
+	PubKey        crypto.PubKey `json:"public_key,omitempty"`
+	AccountNumber int64         `json:"account_number,omitempty"`
+	Sequence      int64         `json:"sequence,omitempty"`
+
+	// vesting schedule, only set for vesting accounts which vest
+	// continuously from the start time or all at once at the end time
+	VestingType      string    `json:"vesting_type,omitempty"`
+	OriginalVesting  sdk.Coins `json:"original_vesting,omitempty"`
+	DelegatedFree    sdk.Coins `json:"delegated_free,omitempty"`
+	DelegatedVesting sdk.Coins `json:"delegated_vesting,omitempty"`
+	StartTime        int64     `json:"start_time,omitempty"`
+	EndTime          int64     `json:"end_time,omitempty"`
 }
 
+// vesting types of genesis accounts
+const (
+	VestingTypeContinuous = "continuous"
+	VestingTypeDelayed    = "delayed"
+)
+
 func NewGenesisAccount(acc *auth.BaseAccount) GenesisAccount {
 	return GenesisAccount{
-		Address: acc.Address,
-		Coins:   acc.Coins,
+		Address:       acc.Address,
+		Coins:         acc.Coins,
+		PubKey:        acc.PubKey,
+		AccountNumber: acc.AccountNumber,
+		Sequence:      acc.Sequence,
 	}
 }
 
 func NewGenesisAccountI(acc auth.Account) GenesisAccount {
-	return GenesisAccount{
-		Address: acc.GetAddress(),
-		Coins:   acc.GetCoins(),
+	gacc := GenesisAccount{
+		Address:       acc.GetAddress(),
+		Coins:         acc.GetCoins(),
+		PubKey:        acc.GetPubKey(),
+		AccountNumber: acc.GetAccountNumber(),
+		Sequence:      acc.GetSequence(),
+	}
+	switch acc.(type) {
+	case *auth.ContinuousVestingAccount:
+		gacc.VestingType = VestingTypeContinuous
+	case *auth.DelayedVestingAccount:
+		gacc.VestingType = VestingTypeDelayed
+	}
+	if vacc, ok := acc.(auth.VestingAccount); ok {
+		gacc.OriginalVesting = vacc.GetOriginalVesting()
+		gacc.DelegatedFree = vacc.GetDelegatedFree()
+		gacc.DelegatedVesting = vacc.GetDelegatedVesting()
+		gacc.StartTime = vacc.GetStartTime()
+		gacc.EndTime = vacc.GetEndTime()
 	}
+	return gacc
 }
 
-// convert GenesisAccount to auth.BaseAccount
-func (ga *GenesisAccount) ToAccount() (acc *auth.BaseAccount) {
-	return &auth.BaseAccount{
-		Address: ga.Address,
-		Coins:   ga.Coins.Sort(),
+// convert GenesisAccount to an auth.Account, a vesting account if it has
+// a vesting schedule
+func (ga *GenesisAccount) ToAccount() auth.Account {
+	bacc := auth.BaseAccount{
+		Address:       ga.Address,
+		Coins:         ga.Coins.Sort(),
+		PubKey:        ga.PubKey,
+		AccountNumber: ga.AccountNumber,
+		Sequence:      ga.Sequence,
+	}
+	if ga.OriginalVesting.IsZero() {
+		return &bacc
+	}
+	bva := auth.BaseVestingAccount{
+		BaseAccount:      bacc,
+		OriginalVesting:  ga.OriginalVesting.Sort(),
+		DelegatedFree:    ga.DelegatedFree.Sort(),
+		DelegatedVesting: ga.DelegatedVesting.Sort(),
+		EndTime:          ga.EndTime,
+	}
+	if ga.VestingType == VestingTypeContinuous {
+		return &auth.ContinuousVestingAccount{BaseVestingAccount: bva, StartTime: ga.StartTime}
+	}
+	return &auth.DelayedVestingAccount{BaseVestingAccount: bva}
+}
+
+// check the vesting schedule of a genesis account
+func (ga *GenesisAccount) validate() error {
+	if ga.OriginalVesting.IsZero() {
+		return nil
+	}
+	if ga.EndTime <= 0 {
+		return fmt.Errorf("vesting account %s has no end time", ga.Address)
+	}
+	switch ga.VestingType {
+	case VestingTypeContinuous:
+		if ga.StartTime >= ga.EndTime {
+			return fmt.Errorf("vesting account %s starts vesting after it ends", ga.Address)
+		}
+	case VestingTypeDelayed:
+		if ga.StartTime != 0 {
+			return fmt.Errorf("delayed vesting account %s has a start time", ga.Address)
+		}
+	default:
+		return fmt.Errorf("vesting account %s has unknown vesting type %q", ga.Address, ga.VestingType)
+	}
+	if !ga.Coins.Plus(ga.DelegatedFree).Plus(ga.DelegatedVesting).IsGTE(ga.OriginalVesting) {
+		return fmt.Errorf("vesting account %s holds less than its original vesting coins", ga.Address)
 	}
+	return nil
 }
 
 var (
@@ -108,7 +217,7 @@ func GaiaAppGenTx(cdc *wire.Codec, pk crypto.PubKey) (
 		return
 	}
 	cliPrint = json.RawMessage(bz)
-	appGenTx,_,validator,err = GaiaAppGenTxNF(cdc, pk, addr, name, overwrite)
+	appGenTx, _, validator, err = GaiaAppGenTxNF(cdc, pk, addr, name, overwrite)
 	return
 }
 
@@ -182,8 +291,16 @@ func GaiaAppGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (genesisState
 
 	// create the final app state
 	genesisState = GenesisState{
-		Accounts:  genaccs,
-		StakeData: stakeData,
+		Accounts:     genaccs,
+		AuthData:     auth.DefaultGenesisState(),
+		BankData:     bank.DefaultGenesisState(),
+		IBCData:      ibc.DefaultGenesisState(),
+		StakeData:    stakeData,
+		SlashingData: slashing.DefaultGenesisState(),
+		DistrData:    distribution.DefaultGenesisState(),
+		GovData:      gov.DefaultGenesisState(),
+		FeeGrantData: feegrant.DefaultGenesisState(),
+		AuthzData:    authz.DefaultGenesisState(),
 	}
 	return
 }
@@ -6,6 +6,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	crypto "github.com/tendermint/go-crypto"
 )
 
@@ -14,7 +15,47 @@ func TestToAccount(t *testing.T) {
 	addr := sdk.Address(priv.PubKey().Address())
 	authAcc := auth.NewBaseAccountWithAddress(addr)
 	genAcc := NewGenesisAccount(&authAcc)
-	assert.Equal(t, authAcc, *genAcc.ToAccount())
+	assert.Equal(t, &authAcc, genAcc.ToAccount())
+}
+
+func TestToVestingAccount(t *testing.T) {
+	priv := crypto.GenPrivKeyEd25519()
+	addr := sdk.Address(priv.PubKey().Address())
+	authAcc := auth.NewBaseAccountWithAddress(addr)
+	authAcc.Coins = sdk.Coins{{"steak", 100}}
+
+	// accounts vesting from a start time vest continuously
+	cva, err := auth.NewContinuousVestingAccount(authAcc, 100, 200)
+	require.Nil(t, err)
+	genAcc := NewGenesisAccountI(cva)
+	require.Nil(t, genAcc.validate())
+	assert.Equal(t, cva, genAcc.ToAccount())
+
+	// including from the genesis time
+	cva, err = auth.NewContinuousVestingAccount(authAcc, 0, 200)
+	require.Nil(t, err)
+	genAcc = NewGenesisAccountI(cva)
+	require.Nil(t, genAcc.validate())
+	assert.Equal(t, cva, genAcc.ToAccount())
+
+	// accounts without a start time vest all at once
+	dva := auth.NewDelayedVestingAccount(authAcc, 200)
+	genAcc = NewGenesisAccountI(dva)
+	require.Nil(t, genAcc.validate())
+	assert.Equal(t, dva, genAcc.ToAccount())
+
+	// the vesting schedule must be consistent
+	genAcc.EndTime = 0
+	require.NotNil(t, genAcc.validate())
+	genAcc = NewGenesisAccountI(dva)
+	genAcc.StartTime = 100
+	require.NotNil(t, genAcc.validate())
+	genAcc = NewGenesisAccountI(dva)
+	genAcc.VestingType = ""
+	require.NotNil(t, genAcc.validate())
+	genAcc = NewGenesisAccountI(cva)
+	genAcc.Coins = sdk.Coins{{"steak", 50}}
+	require.NotNil(t, genAcc.validate())
 }
 
 func TestGaiaAppGenTx(t *testing.T) {
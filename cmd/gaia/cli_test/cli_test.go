@@ -132,8 +132,9 @@ func TestGaiaCLICreateValidator(t *testing.T) {
 	executeWrite(t, unbondStr, pass)
 	tests.WaitForNextHeightTM(port)
 
+	// the unbonded steak is held back until the unbonding period has passed
 	barAcc = executeGetAccount(t, fmt.Sprintf("gaiacli account %v %v", barCech, flags))
-	require.Equal(t, int64(9), barAcc.GetCoins().AmountOf("steak"), "%v", barAcc)
+	require.Equal(t, int64(8), barAcc.GetCoins().AmountOf("steak"), "%v", barAcc)
 	validator = executeGetValidator(t, fmt.Sprintf("gaiacli stake validator %v --output=json %v", barCech, flags))
 	assert.Equal(t, "1/1", validator.PoolShares.Amount.String())
 }
@@ -12,7 +12,11 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	"github.com/cosmos/cosmos-sdk/version"
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authzcmd "github.com/cosmos/cosmos-sdk/x/authz/client/cli"
 	bankcmd "github.com/cosmos/cosmos-sdk/x/bank/client/cli"
+	distrcmd "github.com/cosmos/cosmos-sdk/x/fee_distribution/client/cli"
+	feegrantcmd "github.com/cosmos/cosmos-sdk/x/feegrant/client/cli"
+	govcmd "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
 	ibccmd "github.com/cosmos/cosmos-sdk/x/ibc/client/cli"
 	slashingcmd "github.com/cosmos/cosmos-sdk/x/slashing/client/cli"
 	stakecmd "github.com/cosmos/cosmos-sdk/x/stake/client/cli"
@@ -87,6 +91,12 @@ func main() {
 			stakecmd.GetCmdQueryValidators("stake", cdc),
 			stakecmd.GetCmdQueryDelegation("stake", cdc),
 			stakecmd.GetCmdQueryDelegations("stake", cdc),
+			stakecmd.GetCmdQueryUnbondingDelegation("stake", cdc),
+			stakecmd.GetCmdQueryUnbondingDelegations("stake", cdc),
+			stakecmd.GetCmdQueryValidatorUnbondingDelegations("stake", cdc),
+			stakecmd.GetCmdQueryRedelegation("stake", cdc),
+			stakecmd.GetCmdQueryRedelegations("stake", cdc),
+			stakecmd.GetCmdQueryValidatorRedelegations("stake", cdc),
 			slashingcmd.GetCmdQuerySigningInfo("slashing", cdc),
 		)...)
 	stakeCmd.AddCommand(
@@ -95,12 +105,77 @@ func main() {
 			stakecmd.GetCmdEditValidator(cdc),
 			stakecmd.GetCmdDelegate(cdc),
 			stakecmd.GetCmdUnbond(cdc),
+			stakecmd.GetCmdBeginRedelegate(cdc),
+			stakecmd.GetCmdCompleteRedelegate(cdc),
 			slashingcmd.GetCmdUnrevoke(cdc),
+			distrcmd.GetCmdWithdrawDelegatorRewards(cdc),
+			distrcmd.GetCmdWithdrawValidatorRewards(cdc),
 		)...)
 	rootCmd.AddCommand(
 		stakeCmd,
 	)
 
+	//Add gov commands
+	govCmd := &cobra.Command{
+		Use:   "gov",
+		Short: "Governance subcommands",
+	}
+	govCmd.AddCommand(
+		client.GetCommands(
+			govcmd.GetCmdQueryProposal("gov", cdc),
+			govcmd.GetCmdQueryVote("gov", cdc),
+			govcmd.GetCmdQueryVotes("gov", cdc),
+			govcmd.GetCmdQueryDeposit("gov", cdc),
+		)...)
+	govCmd.AddCommand(
+		client.PostCommands(
+			govcmd.GetCmdSubmitProposal(cdc),
+			govcmd.GetCmdDeposit(cdc),
+			govcmd.GetCmdVote(cdc),
+		)...)
+	rootCmd.AddCommand(
+		govCmd,
+	)
+
+	//Add fee grant commands
+	feegrantCmd := &cobra.Command{
+		Use:   "feegrant",
+		Short: "Fee allowance subcommands",
+	}
+	feegrantCmd.AddCommand(
+		client.GetCommands(
+			feegrantcmd.GetCmdQueryFeeAllowance("feegrant", cdc),
+			feegrantcmd.GetCmdQueryFeeAllowances("feegrant", cdc),
+		)...)
+	feegrantCmd.AddCommand(
+		client.PostCommands(
+			feegrantcmd.GetCmdGrantFeeAllowance(cdc),
+			feegrantcmd.GetCmdRevokeFeeAllowance(cdc),
+		)...)
+	rootCmd.AddCommand(
+		feegrantCmd,
+	)
+
+	//Add authz commands
+	authzCmd := &cobra.Command{
+		Use:   "authz",
+		Short: "Authorization subcommands",
+	}
+	authzCmd.AddCommand(
+		client.GetCommands(
+			authzcmd.GetCmdQueryGrant("authz", cdc),
+			authzcmd.GetCmdQueryGrants("authz", cdc),
+		)...)
+	authzCmd.AddCommand(
+		client.PostCommands(
+			authzcmd.GetCmdGrantAuthorization(cdc),
+			authzcmd.GetCmdRevokeAuthorization(cdc),
+			authzcmd.GetCmdExec(cdc),
+		)...)
+	rootCmd.AddCommand(
+		authzCmd,
+	)
+
 	//Add auth and bank commands
 	rootCmd.AddCommand(
 		client.GetCommands(
@@ -109,6 +184,8 @@ func main() {
 	rootCmd.AddCommand(
 		client.PostCommands(
 			bankcmd.SendTxCmd(cdc),
+			bankcmd.IssueTxCmd(cdc),
+			bankcmd.RenounceMintingTxCmd(cdc),
 		)...)
 
 	// add proxy, version and key info
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	abci "github.com/tendermint/abci/types"
 	tmtypes "github.com/tendermint/tendermint/types"
@@ -15,6 +16,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/server"
 )
 
+const flagInvCheckPeriod = "inv-check-period"
+
 func main() {
 	cdc := app.MakeCodec()
 	ctx := server.NewDefaultContext()
@@ -28,6 +31,12 @@ func main() {
 	server.AddCommands(ctx, cdc, rootCmd, app.GaiaAppInit(),
 		server.ConstructAppCreator(newApp, "gaia"),
 		server.ConstructAppExporter(exportAppStateAndTMValidators, "gaia"))
+	rootCmd.AddCommand(
+		server.PruneCmd(ctx, server.ConstructAppPruner("gaia")),
+		server.SnapshotsCmd("gaia"),
+	)
+
+	rootCmd.PersistentFlags().Int64(flagInvCheckPeriod, 0, "Check the invariants of the state at the end of blocks at multiples of this height, never if zero")
 
 	// prepare and add flags
 	executor := cli.PrepareBaseCmd(rootCmd, "GA", app.DefaultNodeHome)
@@ -35,10 +44,20 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB) abci.Application {
-	return app.NewGaiaApp(logger, db)
+	gapp := app.NewGaiaApp(logger, db)
+	gapp.SetMinimumGasPrices(server.MinimumGasPrices())
+	gapp.SetPruning(server.PruningOptions())
+	gapp.SetInvariantCheckPeriod(viper.GetInt64(flagInvCheckPeriod))
+	gapp.SetSnapshots(server.SnapshotStore(), server.SnapshotOptions())
+	return gapp
 }
 
-func exportAppStateAndTMValidators(logger log.Logger, db dbm.DB) (json.RawMessage, []tmtypes.GenesisValidator, error) {
+func exportAppStateAndTMValidators(logger log.Logger, db dbm.DB, height int64, forZeroHeight bool) (json.RawMessage, []tmtypes.GenesisValidator, error) {
 	gapp := app.NewGaiaApp(logger, db)
-	return gapp.ExportAppStateAndValidators()
+	if height >= 0 {
+		if err := gapp.LoadHeight(height); err != nil {
+			return nil, nil, err
+		}
+	}
+	return gapp.ExportAppStateAndValidators(forZeroHeight)
 }
@@ -0,0 +1,99 @@
+package multisig
+
+import (
+	"fmt"
+)
+
+// CompactBitArray is a space efficient bit array, it records which of the
+// keys of a multisig have signed using one bit per key.
+type CompactBitArray struct {
+	ExtraBitsStored byte   `json:"extra_bits"` // number of bits used in the last byte, zero if it is full
+	Elems           []byte `json:"bits"`
+}
+
+// NewCompactBitArray returns a bit array of the given size with all bits unset,
+// nil if the size is not positive.
+func NewCompactBitArray(bits int) *CompactBitArray {
+	if bits <= 0 {
+		return nil
+	}
+	return &CompactBitArray{
+		ExtraBitsStored: byte(bits % 8),
+		Elems:           make([]byte, (bits+7)/8),
+	}
+}
+
+// ValidateBasic checks that the bits used in the last byte, as decoded, are
+// consistent with the bytes of the bit array
+func (bA *CompactBitArray) ValidateBasic() error {
+	if bA == nil {
+		return nil
+	}
+	if bA.ExtraBitsStored > 7 {
+		return fmt.Errorf("bit array uses %d bits of its last byte", bA.ExtraBitsStored)
+	}
+	if bA.ExtraBitsStored != 0 && len(bA.Elems) == 0 {
+		return fmt.Errorf("bit array uses %d bits of a missing byte", bA.ExtraBitsStored)
+	}
+	return nil
+}
+
+// Size returns the number of bits in the bit array, zero if it is malformed
+func (bA *CompactBitArray) Size() int {
+	if bA == nil || bA.ValidateBasic() != nil {
+		return 0
+	}
+	if bA.ExtraBitsStored == 0 {
+		return len(bA.Elems) * 8
+	}
+	return (len(bA.Elems)-1)*8 + int(bA.ExtraBitsStored)
+}
+
+// GetIndex returns whether the bit at index i is set,
+// false if i is out of range
+func (bA *CompactBitArray) GetIndex(i int) bool {
+	if i < 0 || i >= bA.Size() {
+		return false
+	}
+	return bA.Elems[i>>3]&(uint8(1)<<uint8(7-(i%8))) > 0
+}
+
+// SetIndex sets the bit at index i, returning false if i is out of range
+func (bA *CompactBitArray) SetIndex(i int, v bool) bool {
+	if i < 0 || i >= bA.Size() {
+		return false
+	}
+	if v {
+		bA.Elems[i>>3] |= uint8(1) << uint8(7-(i%8))
+	} else {
+		bA.Elems[i>>3] &= ^(uint8(1) << uint8(7-(i%8)))
+	}
+	return true
+}
+
+// NumTrueBitsBefore returns the number of bits set before index i
+func (bA *CompactBitArray) NumTrueBitsBefore(i int) int {
+	count := 0
+	for j := 0; j < i && j < bA.Size(); j++ {
+		if bA.GetIndex(j) {
+			count++
+		}
+	}
+	return count
+}
+
+// String returns the bits as a string of x and _, e.g. "x_x" for 101
+func (bA *CompactBitArray) String() string {
+	if bA == nil {
+		return "nil-BitArray"
+	}
+	bits := make([]byte, bA.Size())
+	for i := range bits {
+		if bA.GetIndex(i) {
+			bits[i] = 'x'
+		} else {
+			bits[i] = '_'
+		}
+	}
+	return fmt.Sprintf("BA{%d:%s}", bA.Size(), string(bits))
+}
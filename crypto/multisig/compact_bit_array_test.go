@@ -0,0 +1,57 @@
+package multisig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactBitArray(t *testing.T) {
+	require.Nil(t, NewCompactBitArray(0))
+
+	for _, size := range []int{1, 7, 8, 9, 16, 21} {
+		bA := NewCompactBitArray(size)
+		require.Equal(t, size, bA.Size())
+
+		// out of range indexes are ignored
+		require.False(t, bA.SetIndex(-1, true))
+		require.False(t, bA.SetIndex(size, true))
+		require.False(t, bA.GetIndex(size))
+
+		// set every other bit
+		for i := 0; i < size; i += 2 {
+			require.True(t, bA.SetIndex(i, true))
+		}
+		for i := 0; i < size; i++ {
+			require.Equal(t, i%2 == 0, bA.GetIndex(i))
+			require.Equal(t, (i+1)/2, bA.NumTrueBitsBefore(i))
+		}
+
+		// unset them again
+		for i := 0; i < size; i += 2 {
+			require.True(t, bA.SetIndex(i, false))
+		}
+		require.Equal(t, 0, bA.NumTrueBitsBefore(size))
+	}
+}
+
+func TestCompactBitArrayMalformed(t *testing.T) {
+	for _, bA := range []*CompactBitArray{
+		{ExtraBitsStored: 8, Elems: []byte{0xFF}},
+		{ExtraBitsStored: 255, Elems: []byte{0xFF, 0xFF}},
+		{ExtraBitsStored: 1, Elems: nil},
+	} {
+		require.NotNil(t, bA.ValidateBasic())
+		require.Equal(t, 0, bA.Size())
+		require.False(t, bA.GetIndex(8))
+		require.False(t, bA.SetIndex(8, true))
+	}
+	require.Nil(t, NewCompactBitArray(9).ValidateBasic())
+}
+
+func TestCompactBitArrayString(t *testing.T) {
+	bA := NewCompactBitArray(3)
+	bA.SetIndex(0, true)
+	bA.SetIndex(2, true)
+	require.Equal(t, "BA{3:x_x}", bA.String())
+}
@@ -0,0 +1,81 @@
+package multisig
+
+import (
+	"errors"
+
+	"github.com/tendermint/go-crypto"
+)
+
+var _ crypto.Signature = SignatureMultisig{}
+
+// SignatureMultisig is the signature of a PubKeyMultisigThreshold, the bit
+// array records which keys signed and Sigs holds their signatures in the
+// order of the keys.
+type SignatureMultisig struct {
+	BitArray *CompactBitArray   `json:"bit_array"`
+	Sigs     []crypto.Signature `json:"sigs"`
+}
+
+// NewSignatureMultisig returns an empty signature for a multisig of n keys
+func NewSignatureMultisig(n int) *SignatureMultisig {
+	return &SignatureMultisig{
+		BitArray: NewCompactBitArray(n),
+	}
+}
+
+// AddSignature adds the signature of the key at the given index,
+// replacing any previous signature of that key
+func (sig *SignatureMultisig) AddSignature(s crypto.Signature, index int) {
+	newSigIndex := sig.BitArray.NumTrueBitsBefore(index)
+	if sig.BitArray.GetIndex(index) {
+		sig.Sigs[newSigIndex] = s
+		return
+	}
+	sig.BitArray.SetIndex(index, true)
+	sig.Sigs = append(sig.Sigs, nil)
+	copy(sig.Sigs[newSigIndex+1:], sig.Sigs[newSigIndex:])
+	sig.Sigs[newSigIndex] = s
+}
+
+// AddSignatureFromPubKey adds the signature of the given key, which must be
+// one of the keys of the multisig
+func (sig *SignatureMultisig) AddSignatureFromPubKey(s crypto.Signature, pubkey crypto.PubKey, keys []crypto.PubKey) error {
+	for i, key := range keys {
+		if key.Equals(pubkey) {
+			sig.AddSignature(s, i)
+			return nil
+		}
+	}
+	return errors.New("provided key was not in the multisig")
+}
+
+// Implements crypto.Signature
+func (sig SignatureMultisig) Bytes() []byte {
+	bz, err := cdc.MarshalBinaryBare(sig)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// Implements crypto.Signature
+func (sig SignatureMultisig) IsZero() bool {
+	return len(sig.Sigs) == 0
+}
+
+// Implements crypto.Signature
+func (sig SignatureMultisig) Equals(other crypto.Signature) bool {
+	otherSig, ok := other.(SignatureMultisig)
+	if !ok || len(sig.Sigs) != len(otherSig.Sigs) {
+		return false
+	}
+	if sig.BitArray.String() != otherSig.BitArray.String() {
+		return false
+	}
+	for i := range sig.Sigs {
+		if !sig.Sigs[i].Equals(otherSig.Sigs[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+package multisig
+
+import (
+	"fmt"
+
+	"github.com/tendermint/go-crypto"
+)
+
+var _ crypto.PubKey = PubKeyMultisigThreshold{}
+
+// PubKeyMultisigThreshold is a public key which requires signatures from
+// at least K of its keys.
+type PubKeyMultisigThreshold struct {
+	K       uint            `json:"threshold"`
+	PubKeys []crypto.PubKey `json:"pubkeys"`
+}
+
+// NewPubKeyMultisigThreshold returns a public key requiring k of the given
+// keys to sign, panics if k is zero or larger than the number of keys.
+func NewPubKeyMultisigThreshold(k int, pubkeys []crypto.PubKey) crypto.PubKey {
+	if k <= 0 {
+		panic("threshold k of n multisignature: k <= 0")
+	}
+	if len(pubkeys) < k {
+		panic("threshold k of n multisignature: len(pubkeys) < k")
+	}
+	return PubKeyMultisigThreshold{uint(k), pubkeys}
+}
+
+// Implements crypto.PubKey
+func (pk PubKeyMultisigThreshold) Address() crypto.Address {
+	return crypto.Address(crypto.Ripemd160(pk.Bytes()))
+}
+
+// Implements crypto.PubKey
+func (pk PubKeyMultisigThreshold) Bytes() []byte {
+	bz, err := cdc.MarshalBinaryBare(pk)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// Implements crypto.PubKey, the signature must be a SignatureMultisig
+// holding valid signatures from at least K of the keys, ordered as the keys.
+func (pk PubKeyMultisigThreshold) VerifyBytes(msg []byte, sig crypto.Signature) bool {
+	multisig, ok := sig.(SignatureMultisig)
+	if !ok || multisig.BitArray == nil || multisig.BitArray.ValidateBasic() != nil {
+		return false
+	}
+	size := multisig.BitArray.Size()
+	if size != len(pk.PubKeys) {
+		return false
+	}
+	signed := multisig.BitArray.NumTrueBitsBefore(size)
+	if signed < int(pk.K) || signed != len(multisig.Sigs) {
+		return false
+	}
+	sigIndex := 0
+	for i := 0; i < size; i++ {
+		if multisig.BitArray.GetIndex(i) {
+			if !pk.PubKeys[i].VerifyBytes(msg, multisig.Sigs[sigIndex]) {
+				return false
+			}
+			sigIndex++
+		}
+	}
+	return true
+}
+
+// Implements crypto.PubKey
+func (pk PubKeyMultisigThreshold) Equals(other crypto.PubKey) bool {
+	otherKey, ok := other.(PubKeyMultisigThreshold)
+	if !ok {
+		return false
+	}
+	if pk.K != otherKey.K || len(pk.PubKeys) != len(otherKey.PubKeys) {
+		return false
+	}
+	for i := range pk.PubKeys {
+		if !pk.PubKeys[i].Equals(otherKey.PubKeys[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (pk PubKeyMultisigThreshold) String() string {
+	return fmt.Sprintf("PubKeyMultisigThreshold{%d of %d}", pk.K, len(pk.PubKeys))
+}
@@ -0,0 +1,127 @@
+package multisig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/go-crypto"
+)
+
+func generatePubKeysAndSignatures(n int, msg []byte) (pubkeys []crypto.PubKey, signatures []crypto.Signature) {
+	pubkeys = make([]crypto.PubKey, n)
+	signatures = make([]crypto.Signature, n)
+	for i := 0; i < n; i++ {
+		var privkey crypto.PrivKey
+		if i%2 == 0 {
+			privkey = crypto.GenPrivKeyEd25519()
+		} else {
+			privkey = crypto.GenPrivKeySecp256k1()
+		}
+		pubkeys[i] = privkey.PubKey()
+		signatures[i] = privkey.Sign(msg)
+	}
+	return
+}
+
+func TestThresholdMultisigValidCases(t *testing.T) {
+	msg := []byte{1, 2, 3, 4}
+	pubkeys, sigs := generatePubKeysAndSignatures(5, msg)
+	multisigKey := NewPubKeyMultisigThreshold(2, pubkeys)
+	multisignature := NewSignatureMultisig(5)
+
+	// no signatures
+	require.False(t, multisigKey.VerifyBytes(msg, *multisignature))
+
+	// below the threshold
+	multisignature.AddSignatureFromPubKey(sigs[3], pubkeys[3], pubkeys)
+	require.False(t, multisigKey.VerifyBytes(msg, *multisignature))
+
+	// reaching the threshold, added out of order
+	multisignature.AddSignatureFromPubKey(sigs[1], pubkeys[1], pubkeys)
+	require.True(t, multisigKey.VerifyBytes(msg, *multisignature))
+
+	// more signatures than the threshold
+	multisignature.AddSignatureFromPubKey(sigs[4], pubkeys[4], pubkeys)
+	require.True(t, multisigKey.VerifyBytes(msg, *multisignature))
+
+	// adding a signature twice replaces it
+	multisignature.AddSignatureFromPubKey(sigs[1], pubkeys[1], pubkeys)
+	require.Equal(t, 3, len(multisignature.Sigs))
+	require.True(t, multisigKey.VerifyBytes(msg, *multisignature))
+
+	// other messages are rejected
+	require.False(t, multisigKey.VerifyBytes([]byte{1, 2, 3}, *multisignature))
+}
+
+func TestThresholdMultisigInvalidCases(t *testing.T) {
+	msg := []byte{1, 2, 3, 4}
+	pubkeys, sigs := generatePubKeysAndSignatures(3, msg)
+	multisigKey := NewPubKeyMultisigThreshold(2, pubkeys)
+
+	// signature of the wrong key
+	multisignature := NewSignatureMultisig(3)
+	multisignature.AddSignature(sigs[0], 0)
+	multisignature.AddSignature(sigs[2], 1)
+	require.False(t, multisigKey.VerifyBytes(msg, *multisignature))
+
+	// bit array of the wrong size
+	multisignature = NewSignatureMultisig(4)
+	multisignature.AddSignature(sigs[0], 0)
+	multisignature.AddSignature(sigs[1], 1)
+	require.False(t, multisigKey.VerifyBytes(msg, *multisignature))
+
+	// more signatures than set bits
+	multisignature = NewSignatureMultisig(3)
+	multisignature.AddSignature(sigs[0], 0)
+	multisignature.AddSignature(sigs[1], 1)
+	multisignature.Sigs = append(multisignature.Sigs, sigs[2])
+	require.False(t, multisigKey.VerifyBytes(msg, *multisignature))
+
+	// not a multisignature
+	require.False(t, multisigKey.VerifyBytes(msg, sigs[0]))
+
+	// malformed bit arrays claiming the size of the multisig, as decoded
+	for _, bA := range []CompactBitArray{
+		{ExtraBitsStored: 11, Elems: []byte{}},
+		{ExtraBitsStored: 11, Elems: nil},
+		{ExtraBitsStored: 3, Elems: nil},
+	} {
+		multisignature = &SignatureMultisig{BitArray: &bA, Sigs: sigs[:2]}
+		var decodedSig crypto.Signature
+		require.Nil(t, cdc.UnmarshalBinaryBare(multisignature.Bytes(), &decodedSig))
+		require.False(t, multisigKey.VerifyBytes(msg, decodedSig))
+	}
+
+	// key outside of the multisig
+	otherKeys, otherSigs := generatePubKeysAndSignatures(1, msg)
+	multisignature = NewSignatureMultisig(3)
+	require.NotNil(t, multisignature.AddSignatureFromPubKey(otherSigs[0], otherKeys[0], pubkeys))
+
+	require.Panics(t, func() { NewPubKeyMultisigThreshold(0, pubkeys) })
+	require.Panics(t, func() { NewPubKeyMultisigThreshold(4, pubkeys) })
+}
+
+func TestMultisigAmino(t *testing.T) {
+	msg := []byte{1, 2, 3, 4}
+	pubkeys, sigs := generatePubKeysAndSignatures(3, msg)
+	multisigKey := NewPubKeyMultisigThreshold(2, pubkeys)
+	multisignature := NewSignatureMultisig(3)
+	multisignature.AddSignature(sigs[2], 2)
+	multisignature.AddSignature(sigs[0], 0)
+
+	var decodedKey crypto.PubKey
+	err := cdc.UnmarshalBinaryBare(multisigKey.Bytes(), &decodedKey)
+	require.Nil(t, err)
+	require.True(t, multisigKey.Equals(decodedKey))
+	require.Equal(t, multisigKey.Address(), decodedKey.Address())
+
+	var decodedSig crypto.Signature
+	err = cdc.UnmarshalBinaryBare(multisignature.Bytes(), &decodedSig)
+	require.Nil(t, err)
+	require.True(t, multisignature.Equals(decodedSig))
+	require.True(t, decodedKey.VerifyBytes(msg, decodedSig))
+
+	// keys differ with their threshold
+	require.False(t, multisigKey.Equals(NewPubKeyMultisigThreshold(3, pubkeys)))
+	require.NotEqual(t, multisigKey.Address(), NewPubKeyMultisigThreshold(3, pubkeys).Address())
+}
@@ -0,0 +1,20 @@
+package multisig
+
+import (
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/go-crypto"
+)
+
+// RegisterAmino registers the multisig public key and signature types,
+// the go-crypto types must be registered separately
+func RegisterAmino(cdc *amino.Codec) {
+	cdc.RegisterConcrete(PubKeyMultisigThreshold{}, "cosmos-sdk/PubKeyMultisigThreshold", nil)
+	cdc.RegisterConcrete(SignatureMultisig{}, "cosmos-sdk/SignatureMultisig", nil)
+}
+
+var cdc = amino.NewCodec()
+
+func init() {
+	crypto.RegisterAmino(cdc)
+	RegisterAmino(cdc)
+}
@@ -57,12 +57,16 @@ func main() {
 	rootCmd.AddCommand(
 		client.PostCommands(
 			bankcmd.SendTxCmd(cdc),
+			bankcmd.IssueTxCmd(cdc),
+			bankcmd.RenounceMintingTxCmd(cdc),
 			ibccmd.IBCTransferCmd(cdc),
 			ibccmd.IBCRelayCmd(cdc),
 			stakecmd.GetCmdCreateValidator(cdc),
 			stakecmd.GetCmdEditValidator(cdc),
 			stakecmd.GetCmdDelegate(cdc),
 			stakecmd.GetCmdUnbond(cdc),
+			stakecmd.GetCmdBeginRedelegate(cdc),
+			stakecmd.GetCmdCompleteRedelegate(cdc),
 		)...)
 
 	// add proxy, version and key info
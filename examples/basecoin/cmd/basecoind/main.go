@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -37,10 +38,22 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB) abci.Application {
-	return app.NewBasecoinApp(logger, db)
+	bapp := app.NewBasecoinApp(logger, db)
+	bapp.SetMinimumGasPrices(server.MinimumGasPrices())
+	bapp.SetPruning(server.PruningOptions())
+	bapp.SetSnapshots(server.SnapshotStore(), server.SnapshotOptions())
+	return bapp
 }
 
-func exportAppStateAndTMValidators(logger log.Logger, db dbm.DB) (json.RawMessage, []tmtypes.GenesisValidator, error) {
+func exportAppStateAndTMValidators(logger log.Logger, db dbm.DB, height int64, forZeroHeight bool) (json.RawMessage, []tmtypes.GenesisValidator, error) {
+	if forZeroHeight {
+		return nil, nil, errors.New("basecoin does not support exports for zero height")
+	}
 	bapp := app.NewBasecoinApp(logger, db)
+	if height >= 0 {
+		if err := bapp.LoadHeight(height); err != nil {
+			return nil, nil, err
+		}
+	}
 	return bapp.ExportAppStateAndValidators()
 }
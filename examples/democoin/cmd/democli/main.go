@@ -59,6 +59,8 @@ func main() {
 	rootCmd.AddCommand(
 		client.PostCommands(
 			bankcmd.SendTxCmd(cdc),
+			bankcmd.IssueTxCmd(cdc),
+			bankcmd.RenounceMintingTxCmd(cdc),
 		)...)
 	rootCmd.AddCommand(
 		client.PostCommands(
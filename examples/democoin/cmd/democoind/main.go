@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -44,11 +45,23 @@ func CoolAppGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (appState jso
 }
 
 func newApp(logger log.Logger, db dbm.DB) abci.Application {
-	return app.NewDemocoinApp(logger, db)
+	dapp := app.NewDemocoinApp(logger, db)
+	dapp.SetMinimumGasPrices(server.MinimumGasPrices())
+	dapp.SetPruning(server.PruningOptions())
+	dapp.SetSnapshots(server.SnapshotStore(), server.SnapshotOptions())
+	return dapp
 }
 
-func exportAppStateAndTMValidators(logger log.Logger, db dbm.DB) (json.RawMessage, []tmtypes.GenesisValidator, error) {
+func exportAppStateAndTMValidators(logger log.Logger, db dbm.DB, height int64, forZeroHeight bool) (json.RawMessage, []tmtypes.GenesisValidator, error) {
+	if forZeroHeight {
+		return nil, nil, errors.New("democoin does not support exports for zero height")
+	}
 	dapp := app.NewDemocoinApp(logger, db)
+	if height >= 0 {
+		if err := dapp.LoadHeight(height); err != nil {
+			return nil, nil, err
+		}
+	}
 	return dapp.ExportAppStateAndValidators()
 }
 
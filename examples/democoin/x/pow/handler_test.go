@@ -22,7 +22,7 @@ func TestPowHandler(t *testing.T) {
 	am := auth.NewAccountMapper(cdc, capKey, &auth.BaseAccount{})
 	ctx := sdk.NewContext(ms, abci.Header{}, false, nil, log.NewNopLogger())
 	config := NewConfig("pow", int64(1))
-	ck := bank.NewKeeper(am)
+	ck := bank.NewKeeper(cdc, capKey, am, bank.DefaultCodespace)
 	keeper := NewKeeper(capKey, config, ck, DefaultCodespace)
 
 	handler := keeper.Handler
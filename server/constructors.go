@@ -8,14 +8,24 @@ import (
 	tmtypes "github.com/tendermint/tendermint/types"
 	dbm "github.com/tendermint/tmlibs/db"
 	"github.com/tendermint/tmlibs/log"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // AppCreator lets us lazily initialize app, using home dir
 // and other flags (?) to start
 type AppCreator func(string, log.Logger) (abci.Application, error)
 
-// AppExporter dumps all app state to JSON-serializable structure and returns the current validator set
-type AppExporter func(home string, log log.Logger) (json.RawMessage, []tmtypes.GenesisValidator, error)
+// AppExporter dumps all app state at a height, the latest one if negative, to
+// JSON-serializable structure and returns the validator set at that height.
+// Exports for zero height rebase the heights kept in the state for a chain
+// restarting at height zero.
+type AppExporter func(home string, log log.Logger, height int64, forZeroHeight bool) (json.RawMessage, []tmtypes.GenesisValidator, error)
+
+// AppPruner deletes the versions of the app state which the pruning options
+// do not keep and returns how many versions were deleted.
+type AppPruner func(home string, log log.Logger, pruning sdk.PruningOptions) (int, error)
 
 // ConstructAppCreator returns an application generation function
 func ConstructAppCreator(appFn func(log.Logger, dbm.DB) abci.Application, name string) AppCreator {
@@ -31,13 +41,27 @@ func ConstructAppCreator(appFn func(log.Logger, dbm.DB) abci.Application, name s
 }
 
 // ConstructAppExporter returns an application export function
-func ConstructAppExporter(appFn func(log.Logger, dbm.DB) (json.RawMessage, []tmtypes.GenesisValidator, error), name string) AppExporter {
-	return func(rootDir string, logger log.Logger) (json.RawMessage, []tmtypes.GenesisValidator, error) {
+func ConstructAppExporter(appFn func(log.Logger, dbm.DB, int64, bool) (json.RawMessage, []tmtypes.GenesisValidator, error), name string) AppExporter {
+	return func(rootDir string, logger log.Logger, height int64, forZeroHeight bool) (json.RawMessage, []tmtypes.GenesisValidator, error) {
 		dataDir := filepath.Join(rootDir, "data")
 		db, err := dbm.NewGoLevelDB(name, dataDir)
 		if err != nil {
 			return nil, nil, err
 		}
-		return appFn(logger, db)
+		return appFn(logger, db, height, forZeroHeight)
+	}
+}
+
+// ConstructAppPruner returns a function pruning the multistore of an app
+// kept in the named database
+func ConstructAppPruner(name string) AppPruner {
+	return func(rootDir string, logger log.Logger, pruning sdk.PruningOptions) (int, error) {
+		dataDir := filepath.Join(rootDir, "data")
+		db, err := dbm.NewGoLevelDB(name, dataDir)
+		if err != nil {
+			return 0, err
+		}
+		defer db.Close()
+		return store.PruneCommitMultiStore(db, pruning)
 	}
 }
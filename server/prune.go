@@ -0,0 +1,34 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// PruneCmd deletes the versions of the app state which the pruning options
+// do not keep, while the node is stopped
+func PruneCmd(ctx *Context, appPruner AppPruner) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Prune the versions of the state kept in the data directory",
+		Long: `Delete the versions of the state which the pruning options do not keep,
+as if the node had always run with them. The node must be stopped.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validatePruning(); err != nil {
+				return err
+			}
+			home := viper.GetString("home")
+			pruned, err := appPruner(home, ctx.Logger, PruningOptions())
+			if err != nil {
+				return errors.Errorf("Error pruning state: %v\n", err)
+			}
+			fmt.Printf("Pruned %d versions of the state\n", pruned)
+			return nil
+		},
+	}
+	addPruningFlags(cmd)
+	return cmd
+}
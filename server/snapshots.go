@@ -0,0 +1,123 @@
+package server
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	dbm "github.com/tendermint/tmlibs/db"
+
+	"github.com/cosmos/cosmos-sdk/store"
+)
+
+const flagAppHash = "app-hash"
+
+// SnapshotsCmd manages the snapshots of the app state kept in the named
+// database, while the node is stopped
+func SnapshotsCmd(name string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshots",
+		Short: "Manage the snapshots of the state kept in the data directory",
+	}
+	cmd.AddCommand(
+		listSnapshotsCmd(),
+		createSnapshotCmd(name),
+		restoreSnapshotCmd(name),
+	)
+	return cmd
+}
+
+func listSnapshotsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the snapshots of the state",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifests, err := SnapshotStore().List()
+			if err != nil {
+				return err
+			}
+			for _, manifest := range manifests {
+				fmt.Printf("height %d\tapp hash %X\t%d chunks\n",
+					manifest.CommitID.Version, manifest.CommitID.Hash, len(manifest.ChunkHashes))
+			}
+			return nil
+		},
+	}
+}
+
+func createSnapshotCmd(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create",
+		Short: "Snapshot the latest version of the state",
+		Long:  `Snapshot the latest version of the state. The node must be stopped.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openAppDB(name)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			manifest, err := store.CreateSnapshot(db, SnapshotStore(), 0)
+			if err != nil {
+				return errors.Errorf("Error creating snapshot: %v\n", err)
+			}
+			fmt.Printf("Created snapshot at height %d with app hash %X\n",
+				manifest.CommitID.Version, manifest.CommitID.Hash)
+			return nil
+		},
+	}
+}
+
+func restoreSnapshotCmd(name string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore [height]",
+		Short: "Restore the state from the snapshot at a height",
+		Long: `Rebuild the state of the snapshot at a height into an empty data directory,
+verifying it against the app hash of the snapshot, or against --app-hash if
+set. The state of tendermint is not restored, its blocks up to the height must
+be synced separately. The node must be stopped.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			snapshots := SnapshotStore()
+			manifest, err := snapshots.Get(height)
+			if err != nil {
+				return err
+			}
+			if appHash := viper.GetString(flagAppHash); appHash != "" {
+				hash, err := hex.DecodeString(appHash)
+				if err != nil {
+					return err
+				}
+				if !bytes.Equal(hash, manifest.CommitID.Hash) {
+					return errors.Errorf("snapshot %d has app hash %X, not %X", height, manifest.CommitID.Hash, hash)
+				}
+			}
+			db, err := openAppDB(name)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			commitID, err := store.RestoreSnapshot(db, snapshots, height)
+			if err != nil {
+				return errors.Errorf("Error restoring snapshot: %v\n", err)
+			}
+			fmt.Printf("Restored the state at height %d with app hash %X\n", commitID.Version, commitID.Hash)
+			return nil
+		},
+	}
+	cmd.Flags().String(flagAppHash, "", "Trusted app hash of the snapshot, in hex")
+	return cmd
+}
+
+func openAppDB(name string) (dbm.DB, error) {
+	return dbm.NewGoLevelDB(name, filepath.Join(viper.GetString("home"), "data"))
+}
@@ -1,22 +1,33 @@
 package server
 
 import (
+	"fmt"
+	"path/filepath"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/tendermint/abci/server"
 
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
 	tcmd "github.com/tendermint/tendermint/cmd/tendermint/commands"
 	"github.com/tendermint/tendermint/node"
-	"github.com/tendermint/tendermint/proxy"
 	pvm "github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/proxy"
 	cmn "github.com/tendermint/tmlibs/common"
 )
 
 const (
-	flagWithTendermint = "with-tendermint"
-	flagAddress        = "address"
+	flagWithTendermint    = "with-tendermint"
+	flagAddress           = "address"
+	flagMinimumGasPrices  = "minimum-gas-prices"
+	flagPruningKeepRecent = "pruning-keep-recent"
+	flagPruningKeepEvery  = "pruning-keep-every"
+	flagSnapshotInterval  = "snapshot-interval"
+	flagSnapshotKeep      = "snapshot-keep-recent"
 )
 
 // StartCmd runs the service passed in, either
@@ -26,6 +37,15 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 		Use:   "start",
 		Short: "Run the full node",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := sdk.ParseCoins(viper.GetString(flagMinimumGasPrices)); err != nil {
+				return errors.Wrap(err, "invalid minimum gas prices")
+			}
+			if err := validatePruning(); err != nil {
+				return err
+			}
+			if err := validateSnapshots(); err != nil {
+				return err
+			}
 			if !viper.GetBool(flagWithTendermint) {
 				ctx.Logger.Info("Starting ABCI without Tendermint")
 				return startStandAlone(ctx, appCreator)
@@ -38,12 +58,76 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 	// basic flags for abci app
 	cmd.Flags().Bool(flagWithTendermint, true, "run abci app embedded in-process with tendermint")
 	cmd.Flags().String(flagAddress, "tcp://0.0.0.0:46658", "Listen address")
+	cmd.Flags().String(flagMinimumGasPrices, "", "Minimum gas prices txs must pay to enter the mempool, in any of the denominations (e.g. 1steak,2photino)")
+	addPruningFlags(cmd)
+	cmd.Flags().Int64(flagSnapshotInterval, 0, "Snapshot the state at multiples of this height, never if zero")
+	cmd.Flags().Int(flagSnapshotKeep, 0, "Number of recent snapshots to keep, all of them if zero")
 
 	// AddNodeFlags adds support for all tendermint-specific command line options
 	tcmd.AddNodeFlags(cmd)
 	return cmd
 }
 
+// MinimumGasPrices returns the minimum gas prices of the node, set in its
+// config file or with the --minimum-gas-prices flag
+func MinimumGasPrices() sdk.Coins {
+	prices, err := sdk.ParseCoins(viper.GetString(flagMinimumGasPrices))
+	if err != nil {
+		panic(fmt.Sprintf("invalid minimum gas prices: %v", err))
+	}
+	return prices
+}
+
+// PruningOptions returns the versions of the state kept by the node, set in
+// its config file or with the --pruning-keep-recent and --pruning-keep-every
+// flags
+func PruningOptions() sdk.PruningOptions {
+	if err := validatePruning(); err != nil {
+		panic(err)
+	}
+	return sdk.PruningOptions{
+		KeepRecent: viper.GetInt64(flagPruningKeepRecent),
+		KeepEvery:  viper.GetInt64(flagPruningKeepEvery),
+	}
+}
+
+func addPruningFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64(flagPruningKeepRecent, 0, "Number of recent versions of the state to keep, all of them if zero")
+	cmd.Flags().Int64(flagPruningKeepEvery, 0, "Also keep forever the versions of the state at multiples of this height, none if zero")
+}
+
+func validatePruning() error {
+	if viper.GetInt64(flagPruningKeepRecent) < 0 || viper.GetInt64(flagPruningKeepEvery) < 0 {
+		return errors.New("invalid pruning options, the versions to keep cannot be negative")
+	}
+	return nil
+}
+
+// SnapshotStore returns the store of the state snapshots of the node, kept in
+// its data directory
+func SnapshotStore() store.SnapshotStore {
+	return store.NewSnapshotStore(filepath.Join(viper.GetString("home"), "data", "snapshots"))
+}
+
+// SnapshotOptions returns how often the node snapshots its state, set in its
+// config file or with the --snapshot-interval and --snapshot-keep-recent flags
+func SnapshotOptions() store.SnapshotOptions {
+	if err := validateSnapshots(); err != nil {
+		panic(err)
+	}
+	return store.SnapshotOptions{
+		Interval:   viper.GetInt64(flagSnapshotInterval),
+		KeepRecent: viper.GetInt(flagSnapshotKeep),
+	}
+}
+
+func validateSnapshots() error {
+	if viper.GetInt64(flagSnapshotInterval) < 0 || viper.GetInt(flagSnapshotKeep) < 0 {
+		return errors.New("invalid snapshot options, the interval and snapshots to keep cannot be negative")
+	}
+	return nil
+}
+
 func startStandAlone(ctx *Context, appCreator AppCreator) error {
 	// Generate the app in the proper dir
 	addr := viper.GetString(flagAddress)
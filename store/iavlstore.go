@@ -1,6 +1,7 @@
 package store
 
 import (
+	"bytes"
 	"fmt"
 	"sync"
 
@@ -13,18 +14,17 @@ import (
 )
 
 const (
-	defaultIAVLCacheSize  = 10000
-	defaultIAVLNumHistory = 1<<53 - 1 // DEPRECATED
+	defaultIAVLCacheSize = 10000
 )
 
 // load the iavl store
-func LoadIAVLStore(db dbm.DB, id CommitID) (CommitStore, error) {
+func LoadIAVLStore(db dbm.DB, id CommitID, pruning sdk.PruningOptions) (CommitStore, error) {
 	tree := iavl.NewVersionedTree(db, defaultIAVLCacheSize)
 	_, err := tree.LoadVersion(id.Version)
 	if err != nil {
 		return nil, err
 	}
-	store := newIAVLStore(tree, defaultIAVLNumHistory)
+	store := newIAVLStore(tree, pruning)
 	return store, nil
 }
 
@@ -40,20 +40,24 @@ type iavlStore struct {
 	// The underlying tree.
 	tree *iavl.VersionedTree
 
-	// How many old versions we hold onto.
-	// A value of 0 means keep all history.
-	numHistory int64
+	// Which old versions we hold onto.
+	pruning sdk.PruningOptions
 }
 
 // CONTRACT: tree should be fully loaded.
-func newIAVLStore(tree *iavl.VersionedTree, numHistory int64) *iavlStore {
+func newIAVLStore(tree *iavl.VersionedTree, pruning sdk.PruningOptions) *iavlStore {
 	st := &iavlStore{
-		tree:       tree,
-		numHistory: numHistory,
+		tree:    tree,
+		pruning: pruning,
 	}
 	return st
 }
 
+// Set the versions to hold onto.
+func (st *iavlStore) SetPruning(pruning sdk.PruningOptions) {
+	st.pruning = pruning
+}
+
 // Implements Committer.
 func (st *iavlStore) Commit() CommitID {
 
@@ -65,9 +69,11 @@ func (st *iavlStore) Commit() CommitID {
 	}
 
 	// Release an old version of history
-	if st.numHistory > 0 && (st.numHistory < st.tree.Version64()) {
-		toRelease := version - st.numHistory
-		st.tree.DeleteVersion(toRelease)
+	if toRelease, ok := st.pruning.Released(version); ok && st.tree.VersionExists(toRelease) {
+		err = st.tree.DeleteVersion(toRelease)
+		if err != nil {
+			panic(err)
+		}
 	}
 
 	return CommitID{
@@ -166,16 +172,24 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 		} else {
 			_, res.Value = tree.GetVersioned(key, height)
 		}
-	case "/subspace":
+	case "/subspace": // Get all the pairs under a prefix
 		subspace := req.Data
 		res.Key = subspace
-		var KVs []KVPair
-		iterator := sdk.KVStorePrefixIterator(st, subspace)
-		for ; iterator.Valid(); iterator.Next() {
-			KVs = append(KVs, KVPair{iterator.Key(), iterator.Value()})
+		err := st.queryRange(&res, RangeQuery{subspace, sdk.PrefixEndBytes(subspace), 0}, height, req.Prove)
+		if err != nil {
+			return err.QueryResult()
+		}
+	case "/range": // Get a page of the pairs of a range
+		var query RangeQuery
+		errDecode := cdc.UnmarshalBinary(req.Data, &query)
+		if errDecode != nil {
+			return sdk.ErrTxDecode(errDecode.Error()).QueryResult()
+		}
+		res.Key = query.Start
+		err := st.queryRange(&res, query, height, req.Prove)
+		if err != nil {
+			return err.QueryResult()
 		}
-		iterator.Close()
-		res.Value = cdc.MustMarshalBinary(KVs)
 	default:
 		msg := fmt.Sprintf("Unexpected Query path: %v", req.Path)
 		return sdk.ErrUnknownRequest(msg).QueryResult()
@@ -183,6 +197,82 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 	return
 }
 
+// RangeQuery is the data of a /range query, for the pairs from the start key
+// up to the end key excluded, or up to the last key if the end is nil. At most
+// Limit pairs are returned unless it is zero, the next page then starts right
+// after the last key returned.
+type RangeQuery struct {
+	Start []byte
+	End   []byte
+	Limit int
+}
+
+// Bytes returns the encoding of the query.
+func (query RangeQuery) Bytes() []byte {
+	return cdc.MustMarshalBinary(query)
+}
+
+// rangeProof proves the pairs of a range query. The iavl range includes its
+// end key, the pair under it is excluded from the result and kept here.
+type rangeProof struct {
+	Proof *iavl.KeyRangeProof
+	End   *KVPair
+}
+
+// read the pairs of the range at the committed version into the response
+func (st *iavlStore) queryRange(res *abci.ResponseQuery, query RangeQuery, height int64, prove bool) sdk.Error {
+	if query.Limit < 0 {
+		return sdk.ErrUnknownRequest("range queries cannot have a negative limit")
+	}
+	if query.End != nil && bytes.Compare(query.Start, query.End) >= 0 {
+		res.Value = cdc.MustMarshalBinary([]KVPair(nil))
+		return nil
+	}
+
+	// the iavl range includes its end, an unbounded range ends at the last
+	// key of the version, or at the start key when past it
+	rangeEnd := query.End
+	if rangeEnd == nil {
+		last, _, _, err := st.tree.GetVersionedLastInRangeWithProof(nil, nil, height)
+		if err == iavl.ErrNilRoot {
+			res.Value = cdc.MustMarshalBinary([]KVPair(nil))
+			return nil
+		}
+		if err != nil {
+			return sdk.ErrUnknownRequest(err.Error())
+		}
+		rangeEnd = last
+		if bytes.Compare(query.Start, last) > 0 {
+			rangeEnd = query.Start
+		}
+	}
+
+	keys, values, proof, err := st.tree.GetVersionedRangeWithProof(query.Start, rangeEnd, query.Limit, height)
+	if err == iavl.ErrNilRoot {
+		// nothing was ever set in the store
+		res.Value = cdc.MustMarshalBinary([]KVPair(nil))
+		return nil
+	}
+	if err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+
+	var KVs []KVPair
+	var end *KVPair
+	for i, key := range keys {
+		if query.End != nil && bytes.Equal(key, query.End) {
+			end = &KVPair{key, values[i]}
+			continue
+		}
+		KVs = append(KVs, KVPair{key, values[i]})
+	}
+	res.Value = cdc.MustMarshalBinary(KVs)
+	if prove {
+		res.Proof = cdc.MustMarshalBinary(rangeProof{proof, end})
+	}
+	return nil
+}
+
 //----------------------------------------
 
 // Implements Iterator.
@@ -14,8 +14,8 @@ import (
 )
 
 var (
-	cacheSize        = 100
-	numHistory int64 = 5
+	cacheSize = 100
+	pruning   = sdk.PruningOptions{KeepRecent: 5}
 )
 
 var (
@@ -45,7 +45,7 @@ func newTree(t *testing.T, db dbm.DB) (*iavl.VersionedTree, CommitID) {
 func TestIAVLStoreGetSetHasDelete(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree, _ := newTree(t, db)
-	iavlStore := newIAVLStore(tree, numHistory)
+	iavlStore := newIAVLStore(tree, pruning)
 
 	key := "hello"
 
@@ -70,7 +70,7 @@ func TestIAVLStoreGetSetHasDelete(t *testing.T) {
 func TestIAVLIterator(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree, _ := newTree(t, db)
-	iavlStore := newIAVLStore(tree, numHistory)
+	iavlStore := newIAVLStore(tree, pruning)
 	iter := iavlStore.Iterator([]byte("aloha"), []byte("hellz"))
 	expected := []string{"aloha", "hello"}
 	var i int
@@ -143,7 +143,7 @@ func TestIAVLIterator(t *testing.T) {
 func TestIAVLSubspaceIterator(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree, _ := newTree(t, db)
-	iavlStore := newIAVLStore(tree, numHistory)
+	iavlStore := newIAVLStore(tree, pruning)
 
 	iavlStore.Set([]byte("test1"), []byte("test1"))
 	iavlStore.Set([]byte("test2"), []byte("test2"))
@@ -202,7 +202,7 @@ func TestIAVLSubspaceIterator(t *testing.T) {
 func TestIAVLReverseSubspaceIterator(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree, _ := newTree(t, db)
-	iavlStore := newIAVLStore(tree, numHistory)
+	iavlStore := newIAVLStore(tree, pruning)
 
 	iavlStore.Set([]byte("test1"), []byte("test1"))
 	iavlStore.Set([]byte("test2"), []byte("test2"))
@@ -261,7 +261,7 @@ func TestIAVLReverseSubspaceIterator(t *testing.T) {
 func TestIAVLStoreQuery(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree := iavl.NewVersionedTree(db, cacheSize)
-	iavlStore := newIAVLStore(tree, numHistory)
+	iavlStore := newIAVLStore(tree, pruning)
 
 	k1, v1 := []byte("key1"), []byte("val1")
 	k2, v2 := []byte("key2"), []byte("val2")
@@ -315,6 +315,10 @@ func TestIAVLStoreQuery(t *testing.T) {
 	// and for the subspace
 	qres = iavlStore.Query(querySub)
 	assert.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	assert.Equal(t, valExpSubEmpty, qres.Value)
+	querySub.Height = cid.Version
+	qres = iavlStore.Query(querySub)
+	assert.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	assert.Equal(t, valExpSub1, qres.Value)
 
 	// modify
@@ -335,7 +339,11 @@ func TestIAVLStoreQuery(t *testing.T) {
 	qres = iavlStore.Query(query2)
 	assert.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	assert.Equal(t, v2, qres.Value)
-	// and for the subspace
+	// and for the subspace, which still reads the old version
+	qres = iavlStore.Query(querySub)
+	assert.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	assert.Equal(t, valExpSub1, qres.Value)
+	querySub.Height = cid.Version
 	qres = iavlStore.Query(querySub)
 	assert.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	assert.Equal(t, valExpSub2, qres.Value)
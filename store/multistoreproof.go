@@ -0,0 +1,133 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tendermint/iavl"
+)
+
+// MultiStoreProof proves a value of a substore of the rootMultiStore against
+// the hash of its commitInfo, the app hash. It holds the proof of the value
+// in the substore and the storeInfos of all the substores at the version of
+// the value, of which the substore root is one.
+type MultiStoreProof struct {
+	StoreName  string
+	StoreProof []byte // proof of the key or range in the substore
+	StoreInfos []storeInfo
+}
+
+// ReadMultiStoreProof decodes the proof of a query response of the
+// rootMultiStore.
+func ReadMultiStoreProof(bz []byte) (proof MultiStoreProof, err error) {
+	err = cdc.UnmarshalBinary(bz, &proof)
+	return
+}
+
+// Bytes returns the encoding of the proof.
+func (proof MultiStoreProof) Bytes() []byte {
+	return cdc.MustMarshalBinary(proof)
+}
+
+// Verify checks the proof of the value under the key in the named substore
+// against the app hash. A nil value checks the absence of the key.
+func (proof MultiStoreProof) Verify(appHash []byte, storeName string, key, value []byte) (err error) {
+	// iavl panics on the empty paths of malformed proofs
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("malformed proof: %v", r)
+		}
+	}()
+
+	storeRoot, err := proof.storeRoot(appHash, storeName)
+	if err != nil {
+		return err
+	}
+
+	// the value is committed to by the substore root
+	keyProof, err := iavl.ReadKeyProof(proof.StoreProof)
+	if err != nil {
+		return err
+	}
+	return keyProof.Verify(key, value, storeRoot)
+}
+
+// VerifyRange checks the proof of the pairs returned by a range query of
+// the named substore against the app hash: they must be all the pairs of the
+// range, or its first limit pairs. A range without end must have no pair
+// after the last one.
+func (proof MultiStoreProof) VerifyRange(appHash []byte, storeName string, query RangeQuery, KVs []KVPair) (err error) {
+	// iavl panics on the empty paths of malformed proofs
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("malformed proof: %v", r)
+		}
+	}()
+
+	storeRoot, err := proof.storeRoot(appHash, storeName)
+	if err != nil {
+		return err
+	}
+
+	// the pairs are committed to by the substore root
+	var rangeProof rangeProof
+	err = cdc.UnmarshalBinary(proof.StoreProof, &rangeProof)
+	if err != nil {
+		return err
+	}
+	if rangeProof.Proof == nil {
+		return fmt.Errorf("no range proof")
+	}
+	var keys, values [][]byte
+	for _, kv := range KVs {
+		keys = append(keys, kv.Key)
+		values = append(values, kv.Value)
+	}
+	if end := rangeProof.End; end != nil {
+		if query.End == nil || !bytes.Equal(end.Key, query.End) {
+			return fmt.Errorf("excluded pair is under %X, not the end key %X", end.Key, query.End)
+		}
+		keys = append(keys, end.Key)
+		values = append(values, end.Value)
+	}
+	if query.End == nil {
+		return verifyUnboundedRange(rangeProof.Proof, query, keys, values, storeRoot)
+	}
+	return rangeProof.Proof.Verify(query.Start, query.End, query.Limit, keys, values, storeRoot)
+}
+
+// the iavl proof of a range without end may have no key on its right, the
+// range then ends right after the last key, or at the start key if empty
+func verifyUnboundedRange(proof *iavl.KeyRangeProof, query RangeQuery, keys, values [][]byte, root []byte) error {
+	if proof.Right != nil {
+		return fmt.Errorf("range proof has keys after the last pair")
+	}
+	end := query.Start
+	if len(keys) > 0 {
+		last := keys[len(keys)-1]
+		end = append(append([]byte{}, last...), 0)
+	}
+	return proof.Verify(query.Start, end, query.Limit, keys, values, root)
+}
+
+// the root of the named substore, committed to by the app hash
+func (proof MultiStoreProof) storeRoot(appHash []byte, storeName string) ([]byte, error) {
+	if proof.StoreName != storeName {
+		return nil, fmt.Errorf("proof is for store %s, not %s", proof.StoreName, storeName)
+	}
+
+	var storeRoot []byte
+	for _, si := range proof.StoreInfos {
+		if si.Name == storeName {
+			storeRoot = si.Core.CommitID.Hash
+		}
+	}
+	if storeRoot == nil {
+		return nil, fmt.Errorf("no root hash for store %s", storeName)
+	}
+	hash := commitInfo{StoreInfos: proof.StoreInfos}.Hash()
+	if !bytes.Equal(hash, appHash) {
+		return nil, fmt.Errorf("store infos hash to %X, not the app hash %X", hash, appHash)
+	}
+	return storeRoot, nil
+}
@@ -7,6 +7,7 @@ import (
 	"golang.org/x/crypto/ripemd160"
 
 	abci "github.com/tendermint/abci/types"
+	"github.com/tendermint/iavl"
 	dbm "github.com/tendermint/tmlibs/db"
 	"github.com/tendermint/tmlibs/merkle"
 
@@ -25,6 +26,7 @@ const (
 type rootMultiStore struct {
 	db           dbm.DB
 	lastCommitID CommitID
+	pruning      sdk.PruningOptions
 	storesParams map[StoreKey]storeParams
 	stores       map[StoreKey]CommitStore
 	keysByName   map[string]StoreKey
@@ -74,6 +76,16 @@ func (rs *rootMultiStore) GetCommitKVStore(key StoreKey) CommitKVStore {
 	return rs.stores[key].(CommitKVStore)
 }
 
+// Implements CommitMultiStore.
+func (rs *rootMultiStore) SetPruning(pruning sdk.PruningOptions) {
+	rs.pruning = pruning
+	for _, store := range rs.stores {
+		if store, ok := store.(*iavlStore); ok {
+			store.SetPruning(pruning)
+		}
+	}
+}
+
 // Implements CommitMultiStore.
 func (rs *rootMultiStore) LoadLatestVersion() error {
 	ver := getLatestVersion(rs.db)
@@ -149,6 +161,9 @@ func (rs *rootMultiStore) Commit() CommitID {
 	batch := rs.db.NewBatch()
 	setCommitInfo(batch, version, commitInfo)
 	setLatestVersion(batch, version)
+	if toRelease, ok := rs.pruning.Released(version); ok {
+		deleteCommitInfo(batch, toRelease)
+	}
 	batch.Write()
 
 	// Prepare for next version.
@@ -206,7 +221,8 @@ func (rs *rootMultiStore) getStoreByName(name string) Store {
 // Query calls substore.Query with the same `req` where `req.Path` is
 // modified to remove the substore prefix.
 // Ie. `req.Path` here is `/<substore>/<path>`, and trimmed to `/<path>` for the substore.
-// TODO: add proof for `multistore -> substore`.
+// The proof of the substore is wrapped in a MultiStoreProof, proving its
+// root against the commitInfo of the queried version.
 func (rs *rootMultiStore) Query(req abci.RequestQuery) abci.ResponseQuery {
 	// Query just routes this to a substore.
 	path := req.Path
@@ -229,6 +245,20 @@ func (rs *rootMultiStore) Query(req abci.RequestQuery) abci.ResponseQuery {
 	// trim the path and make the query
 	req.Path = subpath
 	res := queryable.Query(req)
+	if !req.Prove || res.Code != 0 || res.Proof == nil {
+		return res
+	}
+
+	// link the substore root to the commitInfo hash
+	cInfo, errInfo := getCommitInfo(rs.db, res.Height)
+	if errInfo != nil {
+		return sdk.ErrInternal(errInfo.Error()).QueryResult()
+	}
+	res.Proof = MultiStoreProof{
+		StoreName:  storeName,
+		StoreProof: res.Proof,
+		StoreInfos: cInfo.StoreInfos,
+	}.Bytes()
 	return res
 }
 
@@ -255,7 +285,7 @@ func (rs *rootMultiStore) loadCommitStoreFromParams(id CommitID, params storePar
 	if params.db != nil {
 		db = dbm.NewPrefixDB(params.db, []byte("s/_/"))
 	} else {
-		db = dbm.NewPrefixDB(rs.db, []byte("s/k:"+params.key.Name()+"/"))
+		db = substoreDB(rs.db, params.key.Name())
 	}
 	switch params.typ {
 	case sdk.StoreTypeMulti:
@@ -263,7 +293,7 @@ func (rs *rootMultiStore) loadCommitStoreFromParams(id CommitID, params storePar
 		// TODO: id?
 		// return NewCommitMultiStore(db, id)
 	case sdk.StoreTypeIAVL:
-		store, err = LoadIAVLStore(db, id)
+		store, err = LoadIAVLStore(db, id, rs.pruning)
 		return
 	case sdk.StoreTypeDB:
 		panic("dbm.DB is not a CommitStore")
@@ -272,6 +302,11 @@ func (rs *rootMultiStore) loadCommitStoreFromParams(id CommitID, params storePar
 	}
 }
 
+// the db of a substore mounted without its own db
+func substoreDB(db dbm.DB, name string) dbm.DB {
+	return dbm.NewPrefixDB(db, []byte("s/k:"+name+"/"))
+}
+
 func (rs *rootMultiStore) nameToKey(name string) StoreKey {
 	for key := range rs.storesParams {
 		if key.Name() == name {
@@ -420,3 +455,54 @@ func setCommitInfo(batch dbm.Batch, version int64, cInfo commitInfo) {
 	cInfoKey := fmt.Sprintf(commitInfoKeyFmt, version)
 	batch.Set([]byte(cInfoKey), cInfoBytes)
 }
+
+// Delete the commitInfo of a pruned version.
+func deleteCommitInfo(batch dbm.Batch, version int64) {
+	cInfoKey := fmt.Sprintf(commitInfoKeyFmt, version)
+	batch.Delete([]byte(cInfoKey))
+}
+
+// PruneCommitMultiStore deletes the versions of the rootMultiStore committed
+// to the db which the pruning options do not keep given its latest version,
+// with their commitInfo. It prunes the IAVL substores mounted without their
+// own db and returns how many versions were deleted. The multistore must not
+// be loaded meanwhile, this prunes the state of a node which ran with other
+// pruning options.
+func PruneCommitMultiStore(db dbm.DB, pruning sdk.PruningOptions) (pruned int, err error) {
+	latest := getLatestVersion(db)
+	if latest == 0 {
+		return 0, nil
+	}
+	cInfo, err := getCommitInfo(db, latest)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, storeInfo := range cInfo.StoreInfos {
+		tree := iavl.NewVersionedTree(substoreDB(db, storeInfo.Name), defaultIAVLCacheSize)
+		_, err = tree.LoadVersion(storeInfo.Core.CommitID.Version)
+		if err != nil {
+			return 0, fmt.Errorf("Failed to load store %s: %v", storeInfo.Name, err)
+		}
+		for version := int64(1); version < latest; version++ {
+			if pruning.Keep(version, latest) || !tree.VersionExists(version) {
+				continue
+			}
+			err = tree.DeleteVersion(version)
+			if err != nil {
+				return 0, fmt.Errorf("Failed to prune store %s: %v", storeInfo.Name, err)
+			}
+		}
+	}
+
+	batch := db.NewBatch()
+	for version := int64(1); version < latest; version++ {
+		if pruning.Keep(version, latest) || !db.Has([]byte(fmt.Sprintf(commitInfoKeyFmt, version))) {
+			continue
+		}
+		deleteCommitInfo(batch, version)
+		pruned++
+	}
+	batch.Write()
+	return pruned, nil
+}
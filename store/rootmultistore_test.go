@@ -159,6 +159,235 @@ func TestMultiStoreQuery(t *testing.T) {
 	assert.Equal(t, v2, qres.Value)
 }
 
+func TestMultiStoreQueryProof(t *testing.T) {
+	db := dbm.NewMemDB()
+	multi := newMultiStoreWithMounts(db)
+	err := multi.LoadLatestVersion()
+	assert.Nil(t, err)
+
+	k, v := []byte("wind"), []byte("blows")
+	k2, v2 := []byte("water"), []byte("flows")
+	store1 := multi.getStoreByName("store1").(KVStore)
+	store2 := multi.getStoreByName("store2").(KVStore)
+	store1.Set(k, v)
+	store2.Set(k2, v2)
+	// iavl cannot prove keys of trees holding a single key
+	store1.Set([]byte("fire"), []byte("burns"))
+	store2.Set([]byte("earth"), []byte("turns"))
+	cid := multi.Commit()
+
+	// the value is proven against the app hash
+	query := abci.RequestQuery{Path: "/store1/key", Data: k, Height: cid.Version, Prove: true}
+	qres := multi.Query(query)
+	assert.Equal(t, v, qres.Value)
+	proof, err := ReadMultiStoreProof(qres.Proof)
+	assert.Nil(t, err)
+	assert.Nil(t, proof.Verify(cid.Hash, "store1", k, v))
+	assert.NotNil(t, proof.Verify(cid.Hash, "store1", k, v2))
+	assert.NotNil(t, proof.Verify(cid.Hash, "store2", k, v))
+	assert.NotNil(t, proof.Verify([]byte("apphash"), "store1", k, v))
+
+	// so is the absence of a key
+	query.Path = "/store2/key"
+	qres = multi.Query(query)
+	assert.Nil(t, qres.Value)
+	proof, err = ReadMultiStoreProof(qres.Proof)
+	assert.Nil(t, err)
+	assert.Nil(t, proof.Verify(cid.Hash, "store2", k, nil))
+	assert.NotNil(t, proof.Verify(cid.Hash, "store2", k, v))
+
+	// proofs of older versions are checked against their app hash
+	store1.Set(k, v2)
+	cid2 := multi.Commit()
+	query.Path = "/store1/key"
+	qres = multi.Query(query)
+	assert.Equal(t, v, qres.Value)
+	proof, err = ReadMultiStoreProof(qres.Proof)
+	assert.Nil(t, err)
+	assert.Nil(t, proof.Verify(cid.Hash, "store1", k, v))
+	assert.NotNil(t, proof.Verify(cid2.Hash, "store1", k, v))
+}
+
+func TestMultiStoreRangeQueryProof(t *testing.T) {
+	db := dbm.NewMemDB()
+	multi := newMultiStoreWithMounts(db)
+	err := multi.LoadLatestVersion()
+	assert.Nil(t, err)
+
+	store1 := multi.getStoreByName("store1").(KVStore)
+	KVs := []KVPair{
+		{[]byte("a/1"), []byte("one")},
+		{[]byte("a/2"), []byte("two")},
+		{[]byte("a/3"), []byte("three")},
+	}
+	for _, kv := range KVs {
+		store1.Set(kv.Key, kv.Value)
+	}
+	// the end key of the subspace is excluded from it
+	store1.Set([]byte("b"), []byte("four"))
+	store1.Set([]byte("c"), []byte("five"))
+	cid := multi.Commit()
+	store1.Set([]byte("a/4"), []byte("uncommitted"))
+
+	// all the committed pairs of the subspace are proven
+	query := abci.RequestQuery{Path: "/store1/subspace", Data: []byte("a"), Height: cid.Version, Prove: true}
+	qres := multi.Query(query)
+	assert.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	assert.Equal(t, cdc.MustMarshalBinary(KVs), qres.Value)
+	proof, err := ReadMultiStoreProof(qres.Proof)
+	assert.Nil(t, err)
+	rangeQuery := RangeQuery{[]byte("a"), []byte("b"), 0}
+	assert.Nil(t, proof.VerifyRange(cid.Hash, "store1", rangeQuery, KVs))
+	assert.NotNil(t, proof.VerifyRange(cid.Hash, "store1", rangeQuery, KVs[:2]))
+	assert.NotNil(t, proof.VerifyRange(cid.Hash, "store1", rangeQuery, []KVPair{KVs[0], KVs[2]}))
+	assert.NotNil(t, proof.VerifyRange(cid.Hash, "store1", rangeQuery, []KVPair{KVs[0], KVs[1], {KVs[2].Key, []byte("six")}}))
+	assert.NotNil(t, proof.VerifyRange([]byte("apphash"), "store1", rangeQuery, KVs))
+
+	// ranges are paginated by limit
+	rangeQuery.Limit = 2
+	query = abci.RequestQuery{Path: "/store1/range", Data: rangeQuery.Bytes(), Height: cid.Version, Prove: true}
+	qres = multi.Query(query)
+	assert.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	assert.Equal(t, cdc.MustMarshalBinary(KVs[:2]), qres.Value)
+	proof, err = ReadMultiStoreProof(qres.Proof)
+	assert.Nil(t, err)
+	assert.Nil(t, proof.VerifyRange(cid.Hash, "store1", rangeQuery, KVs[:2]))
+	assert.NotNil(t, proof.VerifyRange(cid.Hash, "store1", rangeQuery, KVs[:1]))
+
+	// the next page starts right after the last key
+	rangeQuery.Start = append(KVs[1].Key, 0)
+	query.Data = rangeQuery.Bytes()
+	qres = multi.Query(query)
+	assert.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	assert.Equal(t, cdc.MustMarshalBinary(KVs[2:]), qres.Value)
+	proof, err = ReadMultiStoreProof(qres.Proof)
+	assert.Nil(t, err)
+	assert.Nil(t, proof.VerifyRange(cid.Hash, "store1", rangeQuery, KVs[2:]))
+	assert.NotNil(t, proof.VerifyRange(cid.Hash, "store1", rangeQuery, nil))
+
+	// ranges without end run to the last key
+	all := append(KVs, KVPair{[]byte("b"), []byte("four")}, KVPair{[]byte("c"), []byte("five")})
+	rangeQuery = RangeQuery{[]byte("a/2"), nil, 0}
+	query.Data = rangeQuery.Bytes()
+	qres = multi.Query(query)
+	assert.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	assert.Equal(t, cdc.MustMarshalBinary(all[1:]), qres.Value)
+	proof, err = ReadMultiStoreProof(qres.Proof)
+	assert.Nil(t, err)
+	assert.Nil(t, proof.VerifyRange(cid.Hash, "store1", rangeQuery, all[1:]))
+	assert.NotNil(t, proof.VerifyRange(cid.Hash, "store1", rangeQuery, all[1:4]))
+	assert.NotNil(t, proof.VerifyRange(cid.Hash, "store1", rangeQuery, all[2:]))
+
+	// a range proof with a key on its right does not prove them
+	rangeQuery.End = []byte("c")
+	query.Data = rangeQuery.Bytes()
+	qres = multi.Query(query)
+	proof, err = ReadMultiStoreProof(qres.Proof)
+	assert.Nil(t, err)
+	rangeQuery.End = nil
+	assert.NotNil(t, proof.VerifyRange(cid.Hash, "store1", rangeQuery, all[1:4]))
+
+	// and are paginated by limit
+	rangeQuery.Limit = 2
+	query.Data = rangeQuery.Bytes()
+	qres = multi.Query(query)
+	assert.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	assert.Equal(t, cdc.MustMarshalBinary(all[1:3]), qres.Value)
+	proof, err = ReadMultiStoreProof(qres.Proof)
+	assert.Nil(t, err)
+	assert.Nil(t, proof.VerifyRange(cid.Hash, "store1", rangeQuery, all[1:3]))
+
+	// past the last key they are empty
+	rangeQuery = RangeQuery{[]byte("d"), nil, 0}
+	query.Data = rangeQuery.Bytes()
+	qres = multi.Query(query)
+	assert.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	assert.Equal(t, cdc.MustMarshalBinary([]KVPair(nil)), qres.Value)
+	proof, err = ReadMultiStoreProof(qres.Proof)
+	assert.Nil(t, err)
+	assert.Nil(t, proof.VerifyRange(cid.Hash, "store1", rangeQuery, nil))
+	assert.NotNil(t, proof.VerifyRange(cid.Hash, "store1", RangeQuery{[]byte("b/"), nil, 0}, nil))
+
+	// subspaces without end key run to the last key
+	max := KVPair{[]byte{0xFF, 0xFF}, []byte("six")}
+	store1.Set(max.Key, max.Value)
+	cid = multi.Commit()
+	query = abci.RequestQuery{Path: "/store1/subspace", Data: []byte{0xFF}, Height: cid.Version, Prove: true}
+	qres = multi.Query(query)
+	assert.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	assert.Equal(t, cdc.MustMarshalBinary([]KVPair{max}), qres.Value)
+	proof, err = ReadMultiStoreProof(qres.Proof)
+	assert.Nil(t, err)
+	assert.Nil(t, proof.VerifyRange(cid.Hash, "store1", RangeQuery{[]byte{0xFF}, nil, 0}, []KVPair{max}))
+	assert.NotNil(t, proof.VerifyRange(cid.Hash, "store1", RangeQuery{[]byte{0xFF}, nil, 0}, nil))
+}
+
+func TestMultiStorePruning(t *testing.T) {
+	db := dbm.NewMemDB()
+	multi := newMultiStoreWithMounts(db)
+	multi.SetPruning(sdk.PruningOptions{KeepRecent: 2, KeepEvery: 3})
+	err := multi.LoadLatestVersion()
+	assert.Nil(t, err)
+
+	store1 := multi.getStoreByName("store1").(*iavlStore)
+	for i := 0; i < 7; i++ {
+		store1.Set([]byte("key"), []byte{byte(i)})
+		multi.Commit()
+	}
+
+	// the recent versions and every third version are kept
+	for version, kept := range map[int64]bool{1: false, 2: false, 3: true, 4: false, 5: false, 6: true, 7: true} {
+		_, err = getCommitInfo(db, version)
+		assert.Equal(t, kept, err == nil, "commitInfo of version %d", version)
+		assert.Equal(t, kept, store1.tree.VersionExists(version), "store version %d", version)
+	}
+
+	// the kept versions can be loaded
+	multi = newMultiStoreWithMounts(db)
+	err = multi.LoadVersion(3)
+	assert.Nil(t, err)
+}
+
+func TestPruneCommitMultiStore(t *testing.T) {
+	db := dbm.NewMemDB()
+	// only the substores kept in the db of the multistore are pruned offline
+	newMultiStore := func() *rootMultiStore {
+		multi := NewCommitMultiStore(db)
+		multi.MountStoreWithDB(sdk.NewKVStoreKey("store1"), sdk.StoreTypeIAVL, nil)
+		multi.MountStoreWithDB(sdk.NewKVStoreKey("store2"), sdk.StoreTypeIAVL, nil)
+		return multi
+	}
+	multi := newMultiStore()
+	err := multi.LoadLatestVersion()
+	assert.Nil(t, err)
+
+	store1 := multi.getStoreByName("store1").(KVStore)
+	for i := 0; i < 5; i++ {
+		store1.Set([]byte("key"), []byte{byte(i)})
+		multi.Commit()
+	}
+	cid := multi.LastCommitID()
+
+	// the versions kept by a node without pruning are pruned offline
+	pruned, err := PruneCommitMultiStore(db, sdk.PruningOptions{KeepRecent: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, pruned)
+	pruned, err = PruneCommitMultiStore(db, sdk.PruningOptions{KeepRecent: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, pruned)
+
+	multi = newMultiStore()
+	err = multi.LoadLatestVersion()
+	assert.Nil(t, err)
+	assert.Equal(t, cid, multi.LastCommitID())
+	for version := int64(1); version <= 5; version++ {
+		_, err = getCommitInfo(db, version)
+		assert.Equal(t, version > 3, err == nil, "commitInfo of version %d", version)
+		store := multi.getStoreByName("store1").(*iavlStore)
+		assert.Equal(t, version > 3, store.tree.VersionExists(version), "store version %d", version)
+	}
+}
+
 //-----------------------------------------------------------------------
 // utils
 
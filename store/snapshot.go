@@ -0,0 +1,461 @@
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/iavl"
+	dbm "github.com/tendermint/tmlibs/db"
+)
+
+const (
+	snapshotChunkSize    = 1 << 22 // bytes of items after which a chunk is closed
+	snapshotVerifyLimit  = 1000    // keys verified per range proof on restore
+	snapshotManifestFile = "manifest.json"
+
+	// keys of the roots and nodes in the node db of an iavl tree
+	iavlRootKeyFmt = "r/%d"
+	iavlNodeKeyFmt = "n/%x"
+	iavlNodePrefix = "n/"
+)
+
+// SnapshotManifest describes a snapshot of the rootMultiStore at a version.
+// The chunks hold the nodes of the IAVL trees of its substores at the
+// version and are checked against their hashes, the store infos hash to the
+// app hash of the commit ID.
+type SnapshotManifest struct {
+	CommitID    CommitID
+	StoreInfos  []storeInfo
+	ChunkHashes [][]byte // sha256 of every chunk, in order
+}
+
+// SnapshotOptions sets how often snapshots are taken, at every multiple of
+// Interval unless it is zero, and how many of the most recent are kept, all
+// of them if KeepRecent is zero.
+type SnapshotOptions struct {
+	Interval   int64
+	KeepRecent int
+}
+
+// an entry of the node db of a substore, the root or a node of its tree at
+// the version of the snapshot
+type snapshotItem struct {
+	Store string
+	Key   []byte
+	Value []byte
+}
+
+//----------------------------------------
+// SnapshotStore
+
+// SnapshotStore keeps snapshots on disk, each in a directory named after its
+// version holding its manifest and chunks.
+type SnapshotStore struct {
+	dir string
+}
+
+// NewSnapshotStore returns a store of the snapshots kept in dir.
+func NewSnapshotStore(dir string) SnapshotStore {
+	return SnapshotStore{dir}
+}
+
+// List returns the manifests of the snapshots, by ascending version.
+func (ss SnapshotStore) List() ([]SnapshotManifest, error) {
+	files, err := ioutil.ReadDir(ss.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var manifests []SnapshotManifest
+	for _, file := range files {
+		// skip the snapshots being written
+		version, err := strconv.ParseInt(file.Name(), 10, 64)
+		if err != nil || !file.IsDir() {
+			continue
+		}
+		manifest, err := ss.Get(version)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, manifest)
+	}
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].CommitID.Version < manifests[j].CommitID.Version
+	})
+	return manifests, nil
+}
+
+// Get returns the manifest of the snapshot at the version.
+func (ss SnapshotStore) Get(version int64) (manifest SnapshotManifest, err error) {
+	bz, err := ioutil.ReadFile(filepath.Join(ss.versionDir(version), snapshotManifestFile))
+	if err != nil {
+		return manifest, fmt.Errorf("Failed to read snapshot %d: %v", version, err)
+	}
+	err = cdc.UnmarshalJSON(bz, &manifest)
+	if err != nil {
+		return manifest, fmt.Errorf("Failed to decode snapshot %d: %v", version, err)
+	}
+	return manifest, nil
+}
+
+// LoadChunk returns a chunk of the snapshot, checked against its hash in the
+// manifest.
+func (ss SnapshotStore) LoadChunk(manifest SnapshotManifest, index int) ([]byte, error) {
+	if index < 0 || index >= len(manifest.ChunkHashes) {
+		return nil, fmt.Errorf("snapshot %d has no chunk %d", manifest.CommitID.Version, index)
+	}
+	chunk, err := ioutil.ReadFile(filepath.Join(ss.versionDir(manifest.CommitID.Version), strconv.Itoa(index)))
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(chunk)
+	if !bytes.Equal(hash[:], manifest.ChunkHashes[index]) {
+		return nil, fmt.Errorf("chunk %d of snapshot %d hashes to %X, not %X",
+			index, manifest.CommitID.Version, hash, manifest.ChunkHashes[index])
+	}
+	return chunk, nil
+}
+
+// Delete removes the snapshot at the version.
+func (ss SnapshotStore) Delete(version int64) error {
+	return os.RemoveAll(ss.versionDir(version))
+}
+
+// Prune removes the snapshots but the most recent ones.
+func (ss SnapshotStore) Prune(keepRecent int) error {
+	manifests, err := ss.List()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(manifests)-keepRecent; i++ {
+		err = ss.Delete(manifests[i].CommitID.Version)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ss SnapshotStore) versionDir(version int64) string {
+	return filepath.Join(ss.dir, strconv.FormatInt(version, 10))
+}
+
+// snapshotWriter writes the items of a snapshot in chunks as they are added,
+// in a temporary directory moved in place with the manifest once complete.
+type snapshotWriter struct {
+	dir      string
+	tmpDir   string
+	manifest SnapshotManifest
+	items    []snapshotItem
+	size     int
+}
+
+func (ss SnapshotStore) newWriter(manifest SnapshotManifest) (*snapshotWriter, error) {
+	dir := ss.versionDir(manifest.CommitID.Version)
+	w := &snapshotWriter{dir: dir, tmpDir: dir + ".tmp", manifest: manifest}
+	err := os.RemoveAll(w.tmpDir)
+	if err != nil {
+		return nil, err
+	}
+	err = os.MkdirAll(w.tmpDir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// add an item, writing the chunk once it is full
+func (w *snapshotWriter) add(item snapshotItem) error {
+	w.items = append(w.items, item)
+	w.size += len(item.Key) + len(item.Value)
+	if w.size >= snapshotChunkSize {
+		return w.flush()
+	}
+	return nil
+}
+
+func (w *snapshotWriter) flush() error {
+	if len(w.items) == 0 {
+		return nil
+	}
+	chunk := cdc.MustMarshalBinary(w.items)
+	index := len(w.manifest.ChunkHashes)
+	err := ioutil.WriteFile(filepath.Join(w.tmpDir, strconv.Itoa(index)), chunk, 0644)
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(chunk)
+	w.manifest.ChunkHashes = append(w.manifest.ChunkHashes, hash[:])
+	w.items, w.size = nil, 0
+	return nil
+}
+
+// write the last chunk and the manifest, and move the snapshot in place
+func (w *snapshotWriter) close() (SnapshotManifest, error) {
+	err := w.flush()
+	if err != nil {
+		return w.manifest, err
+	}
+	bz, err := cdc.MarshalJSON(w.manifest)
+	if err != nil {
+		return w.manifest, err
+	}
+	err = ioutil.WriteFile(filepath.Join(w.tmpDir, snapshotManifestFile), bz, 0644)
+	if err != nil {
+		return w.manifest, err
+	}
+	err = os.RemoveAll(w.dir)
+	if err != nil {
+		return w.manifest, err
+	}
+	return w.manifest, os.Rename(w.tmpDir, w.dir)
+}
+
+// remove the incomplete snapshot
+func (w *snapshotWriter) abort() {
+	os.RemoveAll(w.tmpDir) // nolint: errcheck
+}
+
+//----------------------------------------
+// Create and restore
+
+// CreateSnapshot saves a snapshot of the version of the rootMultiStore
+// committed to the db, or of the latest version if zero, with the IAVL trees
+// of its substores mounted without their own db. Only the nodes of the trees
+// at the version are saved, read from the db so that newer versions can be
+// committed meanwhile, but the version must not be pruned before the
+// snapshot is complete.
+func CreateSnapshot(db dbm.DB, ss SnapshotStore, version int64) (manifest SnapshotManifest, err error) {
+	if version == 0 {
+		version = getLatestVersion(db)
+	}
+	if version == 0 {
+		return manifest, fmt.Errorf("no version to snapshot")
+	}
+	cInfo, err := getCommitInfo(db, version)
+	if err != nil {
+		return manifest, err
+	}
+	storeInfos := make([]storeInfo, len(cInfo.StoreInfos))
+	copy(storeInfos, cInfo.StoreInfos)
+	sort.Slice(storeInfos, func(i, j int) bool { return storeInfos[i].Name < storeInfos[j].Name })
+
+	w, err := ss.newWriter(SnapshotManifest{CommitID: cInfo.CommitID(), StoreInfos: storeInfos})
+	if err != nil {
+		return manifest, fmt.Errorf("Failed to save snapshot %d: %v", version, err)
+	}
+	for _, storeInfo := range storeInfos {
+		name := storeInfo.Name
+		err = exportIAVLVersion(substoreDB(db, name), version, func(key, value []byte) error {
+			return w.add(snapshotItem{name, key, value})
+		})
+		if err != nil {
+			w.abort()
+			return manifest, fmt.Errorf("Failed to export store %s: %v", name, err)
+		}
+	}
+	manifest, err = w.close()
+	if err != nil {
+		w.abort()
+		return manifest, fmt.Errorf("Failed to save snapshot %d: %v", version, err)
+	}
+	return manifest, nil
+}
+
+// export the root of the tree at the version and its nodes, parents first,
+// from the node db
+func exportIAVLVersion(db dbm.DB, version int64, fn func(key, value []byte) error) error {
+	rootKey := []byte(fmt.Sprintf(iavlRootKeyFmt, version))
+	root := db.Get(rootKey)
+	if root == nil {
+		return fmt.Errorf("no root for version %d", version)
+	}
+	err := fn(rootKey, root)
+	if err != nil || len(root) == 0 {
+		return err
+	}
+
+	hashes := [][]byte{root}
+	for len(hashes) > 0 {
+		hash := hashes[len(hashes)-1]
+		hashes = hashes[:len(hashes)-1]
+		nodeKey := []byte(fmt.Sprintf(iavlNodeKeyFmt, hash))
+		node := db.Get(nodeKey)
+		if node == nil {
+			return fmt.Errorf("missing node %X", hash)
+		}
+		err = fn(nodeKey, node)
+		if err != nil {
+			return err
+		}
+		left, right, err := iavlNodeChildren(node)
+		if err != nil {
+			return fmt.Errorf("Failed to decode node %X: %v", hash, err)
+		}
+		if left != nil {
+			hashes = append(hashes, right, left)
+		}
+	}
+	return nil
+}
+
+// the hashes of the children of an encoded iavl node, none for a leaf
+func iavlNodeChildren(bz []byte) (left, right []byte, err error) {
+	height, n, err := amino.DecodeInt8(bz)
+	if err != nil {
+		return
+	}
+	bz = bz[n:]
+	// size and version
+	for i := 0; i < 2; i++ {
+		_, n, err = amino.DecodeInt64(bz)
+		if err != nil {
+			return
+		}
+		bz = bz[n:]
+	}
+	_, n, err = amino.DecodeByteSlice(bz) // key
+	if err != nil || height == 0 {
+		return
+	}
+	bz = bz[n:]
+	left, n, err = amino.DecodeByteSlice(bz)
+	if err != nil {
+		return
+	}
+	right, _, err = amino.DecodeByteSlice(bz[n:])
+	if err == nil && (len(left) == 0 || len(right) == 0) {
+		err = fmt.Errorf("inner node without child hash")
+	}
+	return
+}
+
+// RestoreSnapshot rebuilds the rootMultiStore committed to the empty db
+// from the snapshot at the version. The IAVL trees of the substores are
+// rebuilt from their nodes and every key is verified up to the root hash of
+// its store info, the store infos hash to the app hash of the snapshot, which
+// is returned with its version. The db is left partially written if the
+// restore fails.
+func RestoreSnapshot(db dbm.DB, ss SnapshotStore, version int64) (commitID CommitID, err error) {
+	if getLatestVersion(db) != 0 {
+		return commitID, fmt.Errorf("cannot restore a snapshot into a non empty multistore")
+	}
+	manifest, err := ss.Get(version)
+	if err != nil {
+		return commitID, err
+	}
+	cInfo := commitInfo{Version: manifest.CommitID.Version, StoreInfos: manifest.StoreInfos}
+	if cInfo.Version != version || !bytes.Equal(cInfo.Hash(), manifest.CommitID.Hash) {
+		return commitID, fmt.Errorf("store infos of snapshot %d do not hash to its commit ID", version)
+	}
+	// the nodes restored in every store
+	nodes := make(map[string]int64)
+	for _, storeInfo := range manifest.StoreInfos {
+		nodes[storeInfo.Name] = 0
+	}
+
+	rootKey := fmt.Sprintf(iavlRootKeyFmt, version)
+	for i := range manifest.ChunkHashes {
+		chunk, err := ss.LoadChunk(manifest, i)
+		if err != nil {
+			return commitID, err
+		}
+		var items []snapshotItem
+		err = cdc.UnmarshalBinary(chunk, &items)
+		if err != nil {
+			return commitID, fmt.Errorf("Failed to decode chunk %d: %v", i, err)
+		}
+		batch := db.NewBatch()
+		for _, item := range items {
+			count, ok := nodes[item.Store]
+			if !ok {
+				return commitID, fmt.Errorf("chunk %d has entries of unknown store %s", i, item.Store)
+			}
+			if strings.HasPrefix(string(item.Key), iavlNodePrefix) {
+				nodes[item.Store] = count + 1
+			} else if string(item.Key) != rootKey {
+				return commitID, fmt.Errorf("chunk %d has an entry %X which is neither a node nor the root", i, item.Key)
+			}
+			batch.Set(append([]byte("s/k:"+item.Store+"/"), item.Key...), item.Value)
+		}
+		batch.Write()
+	}
+
+	for _, storeInfo := range manifest.StoreInfos {
+		err = restoreIAVLVersion(substoreDB(db, storeInfo.Name), storeInfo.Core.CommitID, nodes[storeInfo.Name])
+		if err != nil {
+			return commitID, fmt.Errorf("Failed to restore store %s: %v", storeInfo.Name, err)
+		}
+	}
+
+	batch := db.NewBatch()
+	setCommitInfo(batch, version, cInfo)
+	setLatestVersion(batch, version)
+	batch.Write()
+	return manifest.CommitID, nil
+}
+
+// load the restored tree, which holds the version only, and verify it: it
+// has the root hash of the commit ID, every key is proven against it, and it
+// has no other node than the ones of its keys and their parents
+func restoreIAVLVersion(db dbm.DB, id CommitID, nodes int64) (err error) {
+	// iavl panics on missing nodes and on the empty paths of malformed proofs
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("malformed tree: %v", r)
+		}
+	}()
+
+	tree := iavl.NewVersionedTree(db, defaultIAVLCacheSize)
+	latest, err := tree.Load()
+	if err != nil {
+		return err
+	}
+	if latest != id.Version {
+		return fmt.Errorf("latest version is %d, not %d", latest, id.Version)
+	}
+
+	// the working tree is a copy of the loaded version
+	t := tree.Tree()
+	if !bytes.Equal(t.Hash(), id.Hash) {
+		return fmt.Errorf("root hash is %X, not %X", t.Hash(), id.Hash)
+	}
+	size := t.Size64()
+	if size == 0 {
+		if nodes != 0 {
+			return fmt.Errorf("empty tree has %d nodes", nodes)
+		}
+		return nil
+	}
+	if nodes != 2*size-1 {
+		return fmt.Errorf("tree of %d keys has %d nodes, not %d", size, nodes, 2*size-1)
+	}
+	start, _ := t.GetByIndex64(0)
+	end, _ := t.GetByIndex64(size - 1)
+	for {
+		keys, values, proof, err := t.GetRangeWithProof(start, end, snapshotVerifyLimit)
+		if err != nil {
+			return err
+		}
+		err = proof.Verify(start, end, snapshotVerifyLimit, keys, values, id.Hash)
+		if err != nil || len(keys) == 0 {
+			return fmt.Errorf("Failed to verify keys from %X: %v", start, err)
+		}
+		last := keys[len(keys)-1]
+		if len(keys) < snapshotVerifyLimit || bytes.Equal(last, end) {
+			return nil
+		}
+		// the next range starts right after the last key
+		start = append(cp(last), 0)
+	}
+}
@@ -0,0 +1,187 @@
+package store
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tmlibs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func newSnapshotMultiStore(db dbm.DB) *rootMultiStore {
+	multi := NewCommitMultiStore(db)
+	multi.MountStoreWithDB(sdk.NewKVStoreKey("store1"), sdk.StoreTypeIAVL, nil)
+	multi.MountStoreWithDB(sdk.NewKVStoreKey("store2"), sdk.StoreTypeIAVL, nil)
+	multi.MountStoreWithDB(sdk.NewKVStoreKey("store3"), sdk.StoreTypeIAVL, nil)
+	return multi
+}
+
+func writeSnapshotManifest(t *testing.T, dir string, manifest SnapshotManifest) {
+	bz, err := cdc.MarshalJSON(manifest)
+	require.Nil(t, err)
+	manifestFile := filepath.Join(dir, fmt.Sprint(manifest.CommitID.Version), snapshotManifestFile)
+	require.Nil(t, ioutil.WriteFile(manifestFile, bz, 0644))
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	ss := NewSnapshotStore(dir)
+
+	db := dbm.NewMemDB()
+	multi := newSnapshotMultiStore(db)
+	require.Nil(t, multi.LoadLatestVersion())
+	_, err = CreateSnapshot(db, ss, 0)
+	require.NotNil(t, err)
+
+	// store3 is left empty, store2 holds a single key
+	store1 := multi.getStoreByName("store1").(KVStore)
+	store2 := multi.getStoreByName("store2").(KVStore)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 2000; j++ {
+			store1.Set([]byte(fmt.Sprintf("key%d", j)), []byte(fmt.Sprintf("value%d-%d", i, j)))
+		}
+		store2.Set([]byte("key"), []byte{byte(i)})
+		multi.Commit()
+	}
+	cid := multi.LastCommitID()
+	manifest, err := CreateSnapshot(db, ss, 0)
+	require.Nil(t, err)
+	assert.Equal(t, cid, manifest.CommitID)
+	assert.NotEmpty(t, manifest.ChunkHashes)
+
+	// only the root and the nodes of the version are saved
+	entries := make(map[string]int)
+	for i := range manifest.ChunkHashes {
+		chunk, err := ss.LoadChunk(manifest, i)
+		require.Nil(t, err)
+		var items []snapshotItem
+		require.Nil(t, cdc.UnmarshalBinary(chunk, &items))
+		for _, item := range items {
+			entries[item.Store]++
+		}
+	}
+	assert.Equal(t, map[string]int{"store1": 1 + 2*2000 - 1, "store2": 1 + 1, "store3": 1}, entries)
+
+	manifests, err := ss.List()
+	require.Nil(t, err)
+	assert.Equal(t, []SnapshotManifest{manifest}, manifests)
+
+	// the restored multistore has the app hash of the snapshot
+	db2 := dbm.NewMemDB()
+	restored, err := RestoreSnapshot(db2, ss, cid.Version)
+	require.Nil(t, err)
+	assert.Equal(t, cid, restored)
+	multi2 := newSnapshotMultiStore(db2)
+	require.Nil(t, multi2.LoadLatestVersion())
+	assert.Equal(t, cid, multi2.LastCommitID())
+	assert.Equal(t, []byte("value2-1999"), multi2.getStoreByName("store1").(KVStore).Get([]byte("key1999")))
+	assert.Equal(t, []byte{2}, multi2.getStoreByName("store2").(KVStore).Get([]byte("key")))
+	// only the version of the snapshot is kept
+	assert.False(t, multi2.getStoreByName("store1").(*iavlStore).tree.VersionExists(cid.Version-1))
+
+	// and commits the same versions as the original one
+	multi.getStoreByName("store3").(KVStore).Set([]byte("key"), []byte("value"))
+	multi2.getStoreByName("store3").(KVStore).Set([]byte("key"), []byte("value"))
+	assert.Equal(t, multi.Commit(), multi2.Commit())
+
+	// snapshots are only restored into empty multistores
+	_, err = RestoreSnapshot(db2, ss, cid.Version)
+	assert.NotNil(t, err)
+	_, err = RestoreSnapshot(dbm.NewMemDB(), ss, cid.Version+1)
+	assert.NotNil(t, err)
+
+	// corrupted chunks are rejected
+	chunkFile := filepath.Join(dir, fmt.Sprint(cid.Version), "0")
+	chunk, err := ioutil.ReadFile(chunkFile)
+	require.Nil(t, err)
+	var items []snapshotItem
+	require.Nil(t, cdc.UnmarshalBinary(chunk, &items))
+	for i, item := range items {
+		// alter a value of the snapshot version, ending its leaf node
+		if item.Store == "store1" && strings.HasPrefix(string(item.Key), "n/") && strings.HasSuffix(string(item.Value), "value2-1000") {
+			items[i].Value[len(item.Value)-1]++
+			break
+		}
+	}
+	forged := cdc.MustMarshalBinary(items)
+	require.Nil(t, ioutil.WriteFile(chunkFile, forged, 0644))
+	_, err = RestoreSnapshot(dbm.NewMemDB(), ss, cid.Version)
+	assert.NotNil(t, err)
+
+	// even when their hash is updated in the manifest
+	hash := sha256.Sum256(forged)
+	manifest.ChunkHashes[0] = hash[:]
+	writeSnapshotManifest(t, dir, manifest)
+	_, err = RestoreSnapshot(dbm.NewMemDB(), ss, cid.Version)
+	assert.NotNil(t, err)
+
+	// older versions are snapshotted while newer ones are committed
+	manifest, err = CreateSnapshot(db, ss, cid.Version-1)
+	require.Nil(t, err)
+	assert.Equal(t, cid.Version-1, manifest.CommitID.Version)
+	db3 := dbm.NewMemDB()
+	restored, err = RestoreSnapshot(db3, ss, cid.Version-1)
+	require.Nil(t, err)
+	assert.Equal(t, manifest.CommitID, restored)
+	multi3 := newSnapshotMultiStore(db3)
+	require.Nil(t, multi3.LoadLatestVersion())
+	assert.Equal(t, []byte("value1-1999"), multi3.getStoreByName("store1").(KVStore).Get([]byte("key1999")))
+
+	// the most recent snapshots are kept
+	require.Nil(t, ss.Prune(0))
+	manifests, err = ss.List()
+	require.Nil(t, err)
+	assert.Empty(t, manifests)
+}
+
+func TestSnapshotRestoreExtraEntries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	ss := NewSnapshotStore(dir)
+
+	db := dbm.NewMemDB()
+	multi := newSnapshotMultiStore(db)
+	require.Nil(t, multi.LoadLatestVersion())
+	store1 := multi.getStoreByName("store1").(KVStore)
+	store1.Set([]byte("key1"), []byte("value1"))
+	store1.Set([]byte("key2"), []byte("value2"))
+	multi.Commit()
+	store1.Set([]byte("key1"), []byte("value3"))
+	cid := multi.Commit()
+	manifest, err := CreateSnapshot(db, ss, 0)
+	require.Nil(t, err)
+
+	// a chunk holding the entries of another version is rejected
+	chunk, err := ss.LoadChunk(manifest, 0)
+	require.Nil(t, err)
+	var items []snapshotItem
+	require.Nil(t, cdc.UnmarshalBinary(chunk, &items))
+	rootKey := []byte(fmt.Sprintf(iavlRootKeyFmt, cid.Version-1))
+	oldRoot := substoreDB(db, "store1").Get(rootKey)
+	oldNode := substoreDB(db, "store1").Get([]byte(fmt.Sprintf(iavlNodeKeyFmt, oldRoot)))
+	extras := [][]snapshotItem{
+		{{"store1", rootKey, oldRoot}},
+		{{"store1", []byte(fmt.Sprintf(iavlNodeKeyFmt, oldRoot)), oldNode}},
+	}
+	for _, extra := range extras {
+		forged := cdc.MustMarshalBinary(append(items, extra...))
+		hash := sha256.Sum256(forged)
+		manifest.ChunkHashes = [][]byte{hash[:]}
+		versionDir := filepath.Join(dir, fmt.Sprint(cid.Version))
+		require.Nil(t, ioutil.WriteFile(filepath.Join(versionDir, "0"), forged, 0644))
+		writeSnapshotManifest(t, dir, manifest)
+		_, err = RestoreSnapshot(dbm.NewMemDB(), ss, cid.Version)
+		assert.NotNil(t, err)
+	}
+}
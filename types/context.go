@@ -46,6 +46,8 @@ func NewContext(ms MultiStore, header abci.Header, isCheckTx bool, txBytes []byt
 	c = c.WithLogger(logger)
 	c = c.WithSigningValidators(nil)
 	c = c.WithGasMeter(NewInfiniteGasMeter())
+	c = c.WithMinimumGasPrices(nil)
+	c = c.WithBlockGasMeter(NewInfiniteGasMeter())
 	return c
 }
 
@@ -132,6 +134,8 @@ const (
 	contextKeyLogger
 	contextKeySigningValidators
 	contextKeyGasMeter
+	contextKeyMinimumGasPrices
+	contextKeyBlockGasMeter
 )
 
 // NOTE: Do not expose MultiStore.
@@ -166,6 +170,12 @@ func (c Context) SigningValidators() []abci.SigningValidator {
 func (c Context) GasMeter() GasMeter {
 	return c.Value(contextKeyGasMeter).(GasMeter)
 }
+func (c Context) MinimumGasPrices() Coins {
+	return c.Value(contextKeyMinimumGasPrices).(Coins)
+}
+func (c Context) BlockGasMeter() GasMeter {
+	return c.Value(contextKeyBlockGasMeter).(GasMeter)
+}
 func (c Context) WithMultiStore(ms MultiStore) Context {
 	return c.withValue(contextKeyMultiStore, ms)
 }
@@ -194,6 +204,12 @@ func (c Context) WithSigningValidators(SigningValidators []abci.SigningValidator
 func (c Context) WithGasMeter(meter GasMeter) Context {
 	return c.withValue(contextKeyGasMeter, meter)
 }
+func (c Context) WithMinimumGasPrices(prices Coins) Context {
+	return c.withValue(contextKeyMinimumGasPrices, prices)
+}
+func (c Context) WithBlockGasMeter(meter GasMeter) Context {
+	return c.withValue(contextKeyBlockGasMeter, meter)
+}
 
 // Cache the multistore and return a new cached context. The cached context is
 // written to the context when writeCache is called.
@@ -53,6 +53,9 @@ const (
 	CodeInsufficientCoins CodeType = 10
 	CodeInvalidCoins      CodeType = 11
 	CodeOutOfGas          CodeType = 12
+	CodeInsufficientFee   CodeType = 13
+	CodeMemoTooLarge      CodeType = 14
+	CodeTxTimeout         CodeType = 15
 
 	// CodespaceRoot is a codespace for error codes in this file only.
 	// Notice that 0 is an "unset" codespace, which can be overridden with
@@ -91,6 +94,12 @@ func CodeToDefaultMsg(code CodeType) string {
 		return "Invalid coins"
 	case CodeOutOfGas:
 		return "Out of gas"
+	case CodeInsufficientFee:
+		return "Insufficient fee"
+	case CodeMemoTooLarge:
+		return "Memo too large"
+	case CodeTxTimeout:
+		return "Tx timed out"
 	default:
 		return fmt.Sprintf("Unknown code %d", code)
 	}
@@ -137,6 +146,15 @@ func ErrInvalidCoins(msg string) Error {
 func ErrOutOfGas(msg string) Error {
 	return newErrorWithRootCodespace(CodeOutOfGas, msg)
 }
+func ErrInsufficientFee(msg string) Error {
+	return newErrorWithRootCodespace(CodeInsufficientFee, msg)
+}
+func ErrMemoTooLarge(msg string) Error {
+	return newErrorWithRootCodespace(CodeMemoTooLarge, msg)
+}
+func ErrTxTimeout(msg string) Error {
+	return newErrorWithRootCodespace(CodeTxTimeout, msg)
+}
 
 //----------------------------------------
 // Error & sdkError
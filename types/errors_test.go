@@ -16,6 +16,9 @@ var codeTypes = []CodeType{
 	CodeUnknownRequest,
 	CodeUnknownAddress,
 	CodeInvalidPubKey,
+	CodeInsufficientFee,
+	CodeMemoTooLarge,
+	CodeTxTimeout,
 }
 
 type errFn func(msg string) Error
@@ -29,6 +32,9 @@ var errFns = []errFn{
 	ErrUnknownRequest,
 	ErrUnknownAddress,
 	ErrInvalidPubKey,
+	ErrInsufficientFee,
+	ErrMemoTooLarge,
+	ErrTxTimeout,
 }
 
 func TestCodeType(t *testing.T) {
@@ -1,6 +1,8 @@
 package types
 
-import ()
+import (
+	"math"
+)
 
 // Gas measured by the SDK
 type Gas = int64
@@ -14,6 +16,7 @@ type ErrorOutOfGas struct {
 type GasMeter interface {
 	GasConsumed() Gas
 	ConsumeGas(amount Gas, descriptor string)
+	IsOutOfGas() bool
 }
 
 type basicGasMeter struct {
@@ -39,6 +42,10 @@ func (g *basicGasMeter) ConsumeGas(amount Gas, descriptor string) {
 	}
 }
 
+func (g *basicGasMeter) IsOutOfGas() bool {
+	return g.consumed >= g.limit
+}
+
 type infiniteGasMeter struct {
 	consumed Gas
 }
@@ -56,3 +63,27 @@ func (g *infiniteGasMeter) GasConsumed() Gas {
 func (g *infiniteGasMeter) ConsumeGas(amount Gas, descriptor string) {
 	g.consumed += amount
 }
+
+func (g *infiniteGasMeter) IsOutOfGas() bool {
+	return false
+}
+
+// FeeForGas returns the fee paying for the given gas at the given gas
+// prices, in each of their denominations, false if the gas is negative or
+// its fee overflows in any of them
+func FeeForGas(prices Coins, gas Gas) (Coins, bool) {
+	if gas < 0 {
+		return nil, false
+	}
+	var fee Coins
+	for _, price := range prices {
+		if price.Amount <= 0 || gas == 0 {
+			continue
+		}
+		if price.Amount > math.MaxInt64/gas {
+			return nil, false
+		}
+		fee = append(fee, Coin{price.Denom, price.Amount * gas})
+	}
+	return fee, true
+}
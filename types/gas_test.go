@@ -0,0 +1,32 @@
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeForGas(t *testing.T) {
+	prices := Coins{{"atom", 2}, {"photon", 1}}
+
+	fee, ok := FeeForGas(prices, 10)
+	require.True(t, ok)
+	require.Equal(t, Coins{{"atom", 20}, {"photon", 10}}, fee)
+
+	// no fee is due without gas prices or gas
+	fee, ok = FeeForGas(nil, 10)
+	require.True(t, ok)
+	require.Empty(t, fee)
+	fee, ok = FeeForGas(prices, 0)
+	require.True(t, ok)
+	require.Empty(t, fee)
+
+	// fees overflowing in any denomination are rejected
+	_, ok = FeeForGas(prices, 1<<62)
+	require.False(t, ok)
+	_, ok = FeeForGas(Coins{{"atom", 1}}, math.MaxInt64)
+	require.True(t, ok)
+	_, ok = FeeForGas(prices, -1)
+	require.False(t, ok)
+}
@@ -5,3 +5,45 @@ type Handler func(ctx Context, msg Msg) Result
 
 // If newCtx.IsZero(), ctx is used instead.
 type AnteHandler func(ctx Context, tx Tx) (newCtx Context, result Result, abort bool)
+
+// AnteDecorator runs one step of an ante handler, it calls next to run the
+// rest of the chain or aborts the tx without calling it.
+// If newCtx.IsZero(), ctx is used instead.
+type AnteDecorator func(ctx Context, tx Tx, next AnteHandler) (newCtx Context, result Result, abort bool)
+
+// ChainAnteDecorators returns an AnteHandler running the decorators in
+// order, each decorator passing the context of the tx to the next.
+func ChainAnteDecorators(decorators ...AnteDecorator) AnteHandler {
+	if len(decorators) == 0 {
+		return func(ctx Context, tx Tx) (Context, Result, bool) {
+			return ctx, Result{}, false
+		}
+	}
+	next := ChainAnteDecorators(decorators[1:]...)
+	decorator := decorators[0]
+	return func(ctx Context, tx Tx) (newCtx Context, result Result, abort bool) {
+		newCtx, result, abort = decorator(ctx, tx, next)
+		if newCtx.IsZero() {
+			newCtx = ctx
+		}
+		return newCtx, result, abort
+	}
+}
+
+// NewMsgAnteDecorator returns an AnteDecorator running the check on every Msg
+// of the tx routed to msgType before the rest of the chain, the tx is aborted
+// with the result of the first failing check.
+func NewMsgAnteDecorator(msgType string, check func(ctx Context, tx Tx, msg Msg) Result) AnteDecorator {
+	return func(ctx Context, tx Tx, next AnteHandler) (Context, Result, bool) {
+		for _, msg := range tx.GetMsgs() {
+			if msg.Type() != msgType {
+				continue
+			}
+			res := check(ctx, tx, msg)
+			if !res.IsOK() {
+				return ctx, res, true
+			}
+		}
+		return next(ctx, tx)
+	}
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/abci/types"
+	"github.com/tendermint/tmlibs/log"
+)
+
+type testTx []Msg
+
+func (tx testTx) GetMsgs() []Msg { return tx }
+
+type testAnteKey int
+
+// decorator appending its name to the context, or aborting the tx
+func recordingDecorator(name string, abort bool) AnteDecorator {
+	return func(ctx Context, tx Tx, next AnteHandler) (Context, Result, bool) {
+		names, _ := ctx.Value(testAnteKey(0)).([]string)
+		ctx = ctx.WithValue(testAnteKey(0), append(names, name))
+		if abort {
+			return ctx, ErrUnauthorized(name).Result(), true
+		}
+		return next(ctx, tx)
+	}
+}
+
+func TestChainAnteDecorators(t *testing.T) {
+	ctx := NewContext(nil, abci.Header{}, false, nil, log.NewNopLogger())
+	tx := testTx{NewTestMsg()}
+
+	// an empty chain accepts the tx
+	newCtx, res, abort := ChainAnteDecorators()(ctx, tx)
+	require.False(t, abort)
+	require.True(t, res.IsOK())
+	require.False(t, newCtx.IsZero())
+
+	// decorators run in order and pass their context on
+	anteHandler := ChainAnteDecorators(recordingDecorator("a", false), recordingDecorator("b", false))
+	newCtx, res, abort = anteHandler(ctx, tx)
+	require.False(t, abort)
+	require.Equal(t, []string{"a", "b"}, newCtx.Value(testAnteKey(0)))
+
+	// an aborting decorator stops the chain
+	anteHandler = ChainAnteDecorators(recordingDecorator("a", true), recordingDecorator("b", false))
+	newCtx, res, abort = anteHandler(ctx, tx)
+	require.True(t, abort)
+	require.Equal(t, ToABCICode(CodespaceRoot, CodeUnauthorized), res.Code)
+	require.Equal(t, []string{"a"}, newCtx.Value(testAnteKey(0)))
+
+	// a zero context is replaced by the context of the decorator
+	zeroDecorator := func(ctx Context, tx Tx, next AnteHandler) (Context, Result, bool) {
+		return Context{}, Result{}, false
+	}
+	newCtx, _, _ = ChainAnteDecorators(zeroDecorator)(ctx, tx)
+	require.False(t, newCtx.IsZero())
+}
+
+func TestMsgAnteDecorator(t *testing.T) {
+	ctx := NewContext(nil, abci.Header{}, false, nil, log.NewNopLogger())
+	addr := Address([]byte("blocked"))
+	checked := 0
+	check := func(ctx Context, tx Tx, msg Msg) Result {
+		checked++
+		for _, signer := range msg.GetSigners() {
+			if signer.String() == addr.String() {
+				return ErrUnauthorized("blocked signer").Result()
+			}
+		}
+		return Result{}
+	}
+	anteHandler := ChainAnteDecorators(NewMsgAnteDecorator("TestMsg", check))
+
+	// every msg of the type is checked
+	_, res, abort := anteHandler(ctx, testTx{NewTestMsg(), NewTestMsg(Address([]byte("other")))})
+	require.False(t, abort)
+	require.True(t, res.IsOK())
+	require.Equal(t, 2, checked)
+
+	_, res, abort = anteHandler(ctx, testTx{NewTestMsg(), NewTestMsg(addr)})
+	require.True(t, abort)
+	require.Equal(t, ToABCICode(CodespaceRoot, CodeUnauthorized), res.Code)
+
+	// msgs of other types are not
+	checked = 0
+	anteHandler = ChainAnteDecorators(NewMsgAnteDecorator("bank", check))
+	_, _, abort = anteHandler(ctx, testTx{NewTestMsg(addr)})
+	require.False(t, abort)
+	require.Equal(t, 0, checked)
+}
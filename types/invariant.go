@@ -0,0 +1,5 @@
+package types
+
+// An Invariant is a property of the application state which must always hold,
+// it returns an error describing the violation if it does not
+type Invariant func(ctx Context) error
@@ -38,6 +38,8 @@ type Validator interface {
 	GetPubKey() crypto.PubKey // validation pubkey
 	GetPower() Rat            // validation power
 	GetBondHeight() int64     // height in which the validator became active
+	GetDelegatorShares() Rat  // total outstanding delegator shares
+	GetCommission() Rat       // fraction of fees charged to the delegators by the validator
 }
 
 // validator which fulfills abci validator interface for use in Tendermint
@@ -58,11 +60,11 @@ type ValidatorSet interface {
 	IterateValidatorsBonded(Context,
 		func(index int64, validator Validator) (stop bool))
 
-	Validator(Context, Address) Validator     // get a particular validator by owner address
-	TotalPower(Context) Rat                   // total power of the validator set
-	Slash(Context, crypto.PubKey, int64, Rat) // slash the validator and delegators of the validator, specifying offence height & slash fraction
-	Revoke(Context, crypto.PubKey)            // revoke a validator
-	Unrevoke(Context, crypto.PubKey)          // unrevoke a validator
+	Validator(Context, Address) Validator                 // get a particular validator by owner address
+	TotalPower(Context) Rat                               // total power of the validator set
+	Slash(Context, crypto.PubKey, int64, int64, Rat) Tags // slash the validator and delegators of the validator, specifying offence height, offence power & slash fraction
+	Revoke(Context, crypto.PubKey)                        // revoke a validator
+	Unrevoke(Context, crypto.PubKey)                      // unrevoke a validator
 }
 
 //_______________________________________________________________________________
@@ -76,9 +78,17 @@ type Delegation interface {
 
 // properties for the set of all delegations for a particular
 type DelegationSet interface {
+	Delegation(Context, Address, Address) Delegation // get a particular delegation by delegator and validator owner address
 
 	// iterate through all delegations from one delegator by validator-address,
 	//   execute func for each validator
-	IterateDelegators(Context, delegator Address,
+	IterateDelegators(ctx Context, delegator Address,
 		fn func(index int64, delegation Delegation) (stop bool))
 }
+
+// event hooks for staking delegations, allowing other modules to act on
+// changes to the stake of a delegator
+type StakingHooks interface {
+	BeforeDelegationSharesModified(ctx Context, delegator Address, validator Address) // called before the shares of a delegation are created, changed or removed
+	OnDelegationRemoved(ctx Context, delegator Address, validator Address)            // called once a delegation has been removed
+}
@@ -82,6 +82,39 @@ type CommitMultiStore interface {
 	// the next commit after loading must be idempotent (return the
 	// same commit id).  Otherwise the behavior is undefined.
 	LoadVersion(ver int64) error
+
+	// Set the versions kept by the stores as new versions are
+	// committed, may be called before or after loading a version.
+	SetPruning(pruning PruningOptions)
+}
+
+// PruningOptions sets which committed versions of the stores are kept, the
+// other versions are deleted once they are no longer recent.
+type PruningOptions struct {
+	KeepRecent int64 // number of recent versions kept, every version is kept if zero
+	KeepEvery  int64 // versions which are multiples of it are kept forever, none if zero
+}
+
+// PruneNothing keeps every version, as archive nodes do.
+var PruneNothing = PruningOptions{}
+
+// Keep returns whether the version is kept once the latest version is
+// committed.
+func (pruning PruningOptions) Keep(version, latest int64) bool {
+	if pruning.KeepRecent <= 0 || version > latest-pruning.KeepRecent {
+		return true
+	}
+	return pruning.KeepEvery > 0 && version%pruning.KeepEvery == 0
+}
+
+// Released returns the version which is no longer kept once the latest
+// version is committed, if any.
+func (pruning PruningOptions) Released(latest int64) (version int64, ok bool) {
+	version = latest - pruning.KeepRecent
+	if version <= 0 || pruning.Keep(version, latest) {
+		return 0, false
+	}
+	return version, true
 }
 
 //---------subsp-------------------------------
@@ -27,3 +27,39 @@ func TestPrefixEndBytes(t *testing.T) {
 		assert.Equal(test.expected, end)
 	}
 }
+
+func TestPruningOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	var testCases = []struct {
+		pruning PruningOptions
+		kept    []int64
+	}{
+		{PruneNothing, []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+		{PruningOptions{KeepRecent: 3}, []int64{8, 9, 10}},
+		{PruningOptions{KeepRecent: 2, KeepEvery: 4}, []int64{4, 8, 9, 10}},
+		{PruningOptions{KeepEvery: 4}, []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+	}
+
+	for _, test := range testCases {
+		var kept []int64
+		for version := int64(1); version <= 10; version++ {
+			if test.pruning.Keep(version, 10) {
+				kept = append(kept, version)
+			}
+		}
+		assert.Equal(test.kept, kept)
+
+		// every version which is not kept is released once
+		var released []int64
+		for latest := int64(1); latest <= 10; latest++ {
+			if version, ok := test.pruning.Released(latest); ok {
+				released = append(released, version)
+			}
+		}
+		for _, version := range kept {
+			assert.NotContains(released, version)
+		}
+		assert.Equal(10, len(kept)+len(released))
+	}
+}
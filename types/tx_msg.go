@@ -29,8 +29,8 @@ type Msg interface {
 // Transactions objects must fulfill the Tx
 type Tx interface {
 
-	// Gets the Msg.
-	GetMsg() Msg
+	// Gets the Msgs, which are run in order and either all succeed or all fail.
+	GetMsgs() []Msg
 }
 
 //__________________________________________________________
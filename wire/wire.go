@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 
+	"github.com/cosmos/cosmos-sdk/crypto/multisig"
 	"github.com/tendermint/go-amino"
 	"github.com/tendermint/go-crypto"
 )
@@ -16,9 +17,10 @@ func NewCodec() *Codec {
 	return cdc
 }
 
-// Register the go-crypto to the codec
+// Register the go-crypto and multisig types to the codec
 func RegisterCrypto(cdc *Codec) {
 	crypto.RegisterAmino(cdc)
+	multisig.RegisterAmino(cdc)
 }
 
 // attempt to make some pretty json
@@ -4,23 +4,86 @@ import (
 	"bytes"
 	"fmt"
 
+	"github.com/cosmos/cosmos-sdk/crypto/multisig"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/spf13/viper"
+	"github.com/tendermint/go-crypto"
 )
 
 const (
 	deductFeesCost sdk.Gas = 10
-	verifyCost             = 100
 )
 
+// GasConfig defines the gas charged by the ante handler for the size of a
+// tx and its memo and the verification of its signatures
+type GasConfig struct {
+	TxSizeCostPerByte      sdk.Gas `json:"tx_size_cost_per_byte"`
+	MemoCostPerByte        sdk.Gas `json:"memo_cost_per_byte"`
+	SigVerifyCostEd25519   sdk.Gas `json:"sig_verify_cost_ed25519"`
+	SigVerifyCostSecp256k1 sdk.Gas `json:"sig_verify_cost_secp256k1"`
+}
+
+// DefaultGasConfig returns the gas costs used by NewAnteHandler
+func DefaultGasConfig() GasConfig {
+	return GasConfig{
+		TxSizeCostPerByte:      10,
+		MemoCostPerByte:        1,
+		SigVerifyCostEd25519:   590,
+		SigVerifyCostSecp256k1: 1000,
+	}
+}
+
+// FeeGrantKeeper lets the ante handler charge the fees of a tx to the
+// allowance a granter gave to its fee payer
+type FeeGrantKeeper interface {
+	UseGrantedFees(ctx sdk.Context, granter, grantee sdk.Address, fee sdk.Coins) sdk.Error
+}
+
 // NewAnteHandler returns an AnteHandler that checks
 // and increments sequence numbers, checks signatures & account numbers,
 // and deducts fees from the first signer.
 func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
+	return NewAnteHandlerWithGasConfig(am, fck, DefaultGasConfig())
+}
 
+// NewAnteHandlerWithGasConfig returns the AnteHandler of NewAnteHandler,
+// charging the given gas costs for the size and signatures of txs.
+func NewAnteHandlerWithGasConfig(am AccountMapper, fck FeeCollectionKeeper, gasConfig GasConfig) sdk.AnteHandler {
+	return NewAnteHandlerWithFeeGrants(am, fck, nil, gasConfig)
+}
+
+// NewAnteHandlerWithFeeGrants returns the AnteHandler of
+// NewAnteHandlerWithGasConfig, the fees of txs naming a fee granter are
+// paid by the granter within the allowances of the FeeGrantKeeper.
+// Such txs are rejected when the keeper is nil.
+func NewAnteHandlerWithFeeGrants(am AccountMapper, fck FeeCollectionKeeper, fgk FeeGrantKeeper, gasConfig GasConfig) sdk.AnteHandler {
+	return sdk.ChainAnteDecorators(NewAnteDecorators(am, fck, fgk, gasConfig)...)
+}
+
+// NewAnteDecorators returns the decorators chained by
+// NewAnteHandlerWithFeeGrants, apps append their own decorators to run
+// them once the signatures of a tx are verified and its fee is deducted.
+// Every decorator charges its gas before calling the next one.
+func NewAnteDecorators(am AccountMapper, fck FeeCollectionKeeper, fgk FeeGrantKeeper, gasConfig GasConfig) []sdk.AnteDecorator {
+	return []sdk.AnteDecorator{
+		NewSetUpContextDecorator(),
+		NewValidateBasicDecorator(),
+		NewValidateMemoDecorator(),
+		NewMinimumFeeDecorator(),
+		NewSigVerificationDecorator(am, gasConfig),
+		NewIncrementSequenceDecorator(),
+		NewDeductFeeDecorator(am, fck, fgk),
+		NewConsumeTxSizeGasDecorator(gasConfig),
+	}
+}
+
+// NewSetUpContextDecorator returns a decorator requiring txs to be StdTxs and
+// metering the rest of the chain with the gas limit of the tx. Running out of
+// gas aborts the tx with ErrOutOfGas.
+func NewSetUpContextDecorator() sdk.AnteDecorator {
 	return func(
-		ctx sdk.Context, tx sdk.Tx,
-	) (_ sdk.Context, _ sdk.Result, abort bool) {
+		ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler,
+	) (newCtx sdk.Context, res sdk.Result, abort bool) {
 
 		// This AnteHandler requires Txs to be StdTxs
 		stdTx, ok := tx.(StdTx)
@@ -28,25 +91,114 @@ func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
 			return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
 		}
 
-		// Assert that there are signatures.
+		// The gas limit of the tx applies to the ante handler too, running
+		// out of gas aborts the tx once its fee has been deducted.
+		ctx = ctx.WithGasMeter(sdk.NewGasMeter(stdTx.Fee.Gas))
+		defer func() {
+			if r := recover(); r != nil {
+				outOfGas, ok := r.(sdk.ErrorOutOfGas)
+				if !ok {
+					panic(r)
+				}
+				log := fmt.Sprintf("Out of gas in location: %v", outOfGas.Descriptor)
+				res = sdk.ErrOutOfGas(log).Result()
+				res.GasWanted = stdTx.Fee.Gas
+				res.GasUsed = ctx.GasMeter().GasConsumed()
+				newCtx, abort = ctx, true
+			}
+		}()
+
+		return next(ctx, tx)
+	}
+}
+
+// NewValidateBasicDecorator returns a decorator checking that a StdTx has one
+// signature for each of its signers, an address signing several msgs only
+// signs once.
+func NewValidateBasicDecorator() sdk.AnteDecorator {
+	return func(ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
+		stdTx, ok := tx.(StdTx)
+		if !ok {
+			return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+		}
+
 		var sigs = stdTx.GetSignatures()
 		if len(sigs) == 0 {
 			return ctx,
 				sdk.ErrUnauthorized("no signers").Result(),
 				true
 		}
+		if len(sigs) != len(stdTx.GetSigners()) {
+			return ctx,
+				sdk.ErrUnauthorized("wrong number of signers").Result(),
+				true
+		}
 
-		msg := tx.GetMsg()
+		return next(ctx, tx)
+	}
+}
+
+// NewValidateMemoDecorator returns a decorator rejecting txs with a memo too
+// large or included after their timeout height.
+func NewValidateMemoDecorator() sdk.AnteDecorator {
+	return func(ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
+		stdTx, ok := tx.(StdTx)
+		if !ok {
+			return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+		}
 
-		// Assert that number of signatures is correct.
-		var signerAddrs = msg.GetSigners()
-		if len(sigs) != len(signerAddrs) {
+		memo := stdTx.Memo
+		if len(memo) > MaxMemoCharacters {
 			return ctx,
-				sdk.ErrUnauthorized("wrong number of signers").Result(),
+				sdk.ErrMemoTooLarge(fmt.Sprintf("maximum number of characters is %d but received %d characters",
+					MaxMemoCharacters, len(memo))).Result(),
 				true
 		}
+		if stdTx.TimeoutHeight != 0 && ctx.BlockHeight() > stdTx.TimeoutHeight {
+			return ctx,
+				sdk.ErrTxTimeout(fmt.Sprintf("tx timed out at height %d, current height is %d",
+					stdTx.TimeoutHeight, ctx.BlockHeight())).Result(),
+				true
+		}
+
+		return next(ctx, tx)
+	}
+}
+
+// NewMinimumFeeDecorator returns a decorator letting only the txs paying the
+// minimum gas prices of the node enter its mempool, this is not enforced when
+// delivering txs.
+func NewMinimumFeeDecorator() sdk.AnteDecorator {
+	return func(ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
+		stdTx, ok := tx.(StdTx)
+		if !ok {
+			return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+		}
+
+		if ctx.IsCheckTx() {
+			res := ensureSufficientFees(stdTx.Fee, ctx.MinimumGasPrices())
+			if !res.IsOK() {
+				return ctx, res, true
+			}
+		}
+
+		return next(ctx, tx)
+	}
+}
+
+// NewSigVerificationDecorator returns a decorator checking the signatures,
+// account numbers and sequences of the signers of a StdTx and caching their
+// accounts in the context. Each signature is charged before it is verified.
+func NewSigVerificationDecorator(am AccountMapper, gasConfig GasConfig) sdk.AnteDecorator {
+	return func(ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
+		stdTx, ok := tx.(StdTx)
+		if !ok {
+			return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+		}
 
 		// Get the sign bytes (requires all account & sequence numbers and the fee)
+		var sigs = stdTx.GetSignatures()
+		var signerAddrs = stdTx.GetSigners()
 		sequences := make([]int64, len(signerAddrs))
 		for i := 0; i < len(signerAddrs); i++ {
 			sequences[i] = sigs[i].Sequence
@@ -55,64 +207,111 @@ func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
 		for i := 0; i < len(signerAddrs); i++ {
 			accNums[i] = sigs[i].AccountNumber
 		}
-		fee := stdTx.Fee
 		chainID := ctx.ChainID()
 		// XXX: major hack; need to get ChainID
 		// into the app right away (#565)
 		if chainID == "" {
 			chainID = viper.GetString("chain-id")
 		}
-		signBytes := StdSignBytes(ctx.ChainID(), accNums, sequences, fee, msg)
+		signBytes := StdSignBytes(ctx.ChainID(), accNums, sequences, stdTx.Fee, stdTx.GetMsgs(), stdTx.Memo, stdTx.TimeoutHeight)
 
 		// Check sig and nonce and collect signer accounts.
 		var signerAccs = make([]Account, len(signerAddrs))
 		for i := 0; i < len(sigs); i++ {
-			signerAddr, sig := signerAddrs[i], sigs[i]
-
-			// check signature, return account with incremented nonce
 			signerAcc, res := processSig(
 				ctx, am,
-				signerAddr, sig, signBytes,
+				signerAddrs[i], sigs[i], signBytes, gasConfig,
 			)
 			if !res.IsOK() {
 				return ctx, res, true
 			}
+			signerAccs[i] = signerAcc
+		}
 
-			// first sig pays the fees
-			if i == 0 {
-				// TODO: min fee
-				if !fee.Amount.IsZero() {
-					ctx.GasMeter().ConsumeGas(deductFeesCost, "deductFees")
-					signerAcc, res = deductFees(signerAcc, fee)
-					if !res.IsOK() {
-						return ctx, res, true
-					}
-					fck.addCollectedFees(ctx, fee.Amount)
-				}
+		// cache the signer accounts in the context
+		return next(WithSigners(ctx, signerAccs), tx)
+	}
+}
+
+// NewDeductFeeDecorator returns a decorator deducting the fee of a StdTx from
+// its first signer, whose account is cached in the context, or from the fee
+// granter within the allowances of the FeeGrantKeeper. The signer accounts,
+// whose sequences the NewIncrementSequenceDecorator must have incremented,
+// are saved along with the fee before their gas is charged, so that a tx
+// running out of gas afterwards still pays its fee and cannot be replayed.
+func NewDeductFeeDecorator(am AccountMapper, fck FeeCollectionKeeper, fgk FeeGrantKeeper) sdk.AnteDecorator {
+	return func(ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
+		stdTx, ok := tx.(StdTx)
+		if !ok {
+			return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+		}
+		signerAccs := GetSigners(ctx)
+		if len(signerAccs) == 0 {
+			return ctx, sdk.ErrInternal("signers must be verified before deducting fees").Result(), true
+		}
+
+		// first sig pays the fees, unless they are granted to it
+		writeCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		fee := stdTx.Fee
+		if !fee.Amount.IsZero() {
+			payerAcc := signerAccs[0]
+			var res sdk.Result
+			if fee.Granter == nil || bytes.Equal(fee.Granter, payerAcc.GetAddress()) {
+				payerAcc, res = deductFees(payerAcc, fee, ctx.BlockHeader().Time)
+			} else {
+				res = deductGrantedFees(writeCtx, am, fgk, signerAccs, fee)
+			}
+			if !res.IsOK() {
+				return ctx, res, true
 			}
+			fck.addCollectedFees(writeCtx, fee.Amount)
+			signerAccs[0] = payerAcc
+			writeCtx.GasMeter().ConsumeGas(deductFeesCost, "deductFees")
+		}
+		for _, signerAcc := range signerAccs {
+			am.SetAccount(writeCtx, signerAcc)
+		}
+		ctx.GasMeter().ConsumeGas(writeCtx.GasMeter().GasConsumed(), "ante fee and signers")
 
-			// Save the account.
-			am.SetAccount(ctx, signerAcc)
-			signerAccs[i] = signerAcc
+		return next(WithSigners(ctx, signerAccs), tx)
+	}
+}
+
+// NewIncrementSequenceDecorator returns a decorator incrementing the
+// sequences of the signer accounts cached in the context, they are saved by
+// the NewDeductFeeDecorator which must follow.
+func NewIncrementSequenceDecorator() sdk.AnteDecorator {
+	return func(ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
+		for _, signerAcc := range GetSigners(ctx) {
+			signerAcc.SetSequence(signerAcc.GetSequence() + 1)
 		}
 
-		// cache the signer accounts in the context
-		ctx = WithSigners(ctx, signerAccs)
+		return next(ctx, tx)
+	}
+}
 
-		// set the gas meter
-		ctx = ctx.WithGasMeter(sdk.NewGasMeter(stdTx.Fee.Gas))
+// NewConsumeTxSizeGasDecorator returns a decorator charging the size of a
+// StdTx and of its memo.
+func NewConsumeTxSizeGasDecorator(gasConfig GasConfig) sdk.AnteDecorator {
+	return func(ctx sdk.Context, tx sdk.Tx, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
+		stdTx, ok := tx.(StdTx)
+		if !ok {
+			return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+		}
 
-		// TODO: tx tags (?)
+		ctx.GasMeter().ConsumeGas(gasConfig.TxSizeCostPerByte*sdk.Gas(len(ctx.TxBytes())), "txSize")
+		ctx.GasMeter().ConsumeGas(gasConfig.MemoCostPerByte*sdk.Gas(len(stdTx.Memo)), "memo")
 
-		return ctx, sdk.Result{}, false // continue...
+		return next(ctx, tx)
 	}
 }
 
-// verify the signature and increment the sequence.
+// verify the signature and the sequence, charging the signature before it is
+// verified.
 // if the account doesn't have a pubkey, set it.
 func processSig(
 	ctx sdk.Context, am AccountMapper,
-	addr sdk.Address, sig StdSignature, signBytes []byte) (
+	addr sdk.Address, sig StdSignature, signBytes []byte, gasConfig GasConfig) (
 	acc Account, res sdk.Result) {
 
 	// Get the account.
@@ -128,13 +327,12 @@ func processSig(
 			fmt.Sprintf("Invalid account number. Got %d, expected %d", sig.AccountNumber, accnum)).Result()
 	}
 
-	// Check and increment sequence number.
+	// Check sequence number.
 	seq := acc.GetSequence()
 	if seq != sig.Sequence {
 		return nil, sdk.ErrInvalidSequence(
 			fmt.Sprintf("Invalid sequence. Got %d, expected %d", sig.Sequence, seq)).Result()
 	}
-	acc.SetSequence(seq + 1)
 
 	// If pubkey is not known for account,
 	// set it from the StdSignature.
@@ -155,7 +353,10 @@ func processSig(
 	}
 
 	// Check sig.
-	ctx.GasMeter().ConsumeGas(verifyCost, "ante verify")
+	res = consumeSignatureGas(ctx.GasMeter(), pubKey, sig.Signature, gasConfig)
+	if !res.IsOK() {
+		return nil, res
+	}
 	if !pubKey.VerifyBytes(signBytes, sig.Signature) {
 		return nil, sdk.ErrUnauthorized("signature verification failed").Result()
 	}
@@ -163,21 +364,117 @@ func processSig(
 	return
 }
 
+// Consume the gas to verify a signature of the given key, depending on the
+// type of the key. A multisig signature costs the verification of each of
+// the signatures it holds, it must have a bit for each key and a signature
+// for each bit set.
+func consumeSignatureGas(meter sdk.GasMeter, pubKey crypto.PubKey, sig crypto.Signature, gasConfig GasConfig) sdk.Result {
+	switch pubKey := pubKey.(type) {
+	case crypto.PubKeyEd25519:
+		meter.ConsumeGas(gasConfig.SigVerifyCostEd25519, "ante verify: ed25519")
+		return sdk.Result{}
+	case crypto.PubKeySecp256k1:
+		meter.ConsumeGas(gasConfig.SigVerifyCostSecp256k1, "ante verify: secp256k1")
+		return sdk.Result{}
+	case multisig.PubKeyMultisigThreshold:
+		multisignature, ok := sig.(multisig.SignatureMultisig)
+		if !ok {
+			return sdk.ErrUnauthorized("multisig key requires a multisig signature").Result()
+		}
+		bitArray := multisignature.BitArray
+		if bitArray.ValidateBasic() != nil || bitArray.Size() != len(pubKey.PubKeys) ||
+			bitArray.NumTrueBitsBefore(bitArray.Size()) != len(multisignature.Sigs) {
+			return sdk.ErrUnauthorized("malformed multisig signature").Result()
+		}
+		sigIndex := 0
+		for i, subKey := range pubKey.PubKeys {
+			if !multisignature.BitArray.GetIndex(i) {
+				continue
+			}
+			res := consumeSignatureGas(meter, subKey, multisignature.Sigs[sigIndex], gasConfig)
+			if !res.IsOK() {
+				return res
+			}
+			sigIndex++
+		}
+		return sdk.Result{}
+	default:
+		return sdk.ErrInvalidPubKey(fmt.Sprintf("unrecognized public key type %T", pubKey)).Result()
+	}
+}
+
+// Check that the fee pays for the gas limit of the tx at one of the gas
+// prices, any fee is sufficient when no gas prices are set.
+func ensureSufficientFees(fee StdFee, prices sdk.Coins) sdk.Result {
+	required, ok := sdk.FeeForGas(prices, fee.Gas)
+	if !ok {
+		errMsg := fmt.Sprintf("no fee can pay for %d gas at the gas prices %s", fee.Gas, prices)
+		return sdk.ErrInsufficientFee(errMsg).Result()
+	}
+	if len(required) == 0 {
+		return sdk.Result{}
+	}
+	for _, coin := range required {
+		if fee.Amount.AmountOf(coin.Denom) >= coin.Amount {
+			return sdk.Result{}
+		}
+	}
+	errMsg := fmt.Sprintf("fee %s is below the minimum fee for %d gas, one of %s", fee.Amount, fee.Gas, required)
+	return sdk.ErrInsufficientFee(errMsg).Result()
+}
+
 // Deduct the fee from the account.
 // We could use the CoinKeeper (in addition to the AccountMapper,
 // because the CoinKeeper doesn't give us accounts), but it seems easier to do this.
-func deductFees(acc Account, fee StdFee) (Account, sdk.Result) {
+// Coins which are still vesting cannot pay fees.
+func deductFees(acc Account, fee StdFee, blockTime int64) (Account, sdk.Result) {
 	coins := acc.GetCoins()
 	feeAmount := fee.Amount
 
-	newCoins := coins.Minus(feeAmount)
-	if !newCoins.IsNotNegative() {
-		errMsg := fmt.Sprintf("%s < %s", coins, feeAmount)
+	spendable := SpendableCoins(acc, blockTime)
+	if !spendable.Minus(feeAmount).IsNotNegative() {
+		errMsg := fmt.Sprintf("%s < %s", spendable, feeAmount)
 		return nil, sdk.ErrInsufficientFunds(errMsg).Result()
 	}
-	acc.SetCoins(newCoins)
+	acc.SetCoins(coins.Minus(feeAmount))
 	return acc, sdk.Result{}
 }
 
-// BurnFeeHandler burns all fees (decreasing total supply)
-func BurnFeeHandler(_ sdk.Context, _ sdk.Tx, _ sdk.Coins) {}
+// Deduct the fee from the account of its granter and from the allowance
+// the granter gave to the fee payer, the first signer. A granter signing the
+// tx is debited in the signer accounts, which are saved afterwards.
+func deductGrantedFees(ctx sdk.Context, am AccountMapper, fgk FeeGrantKeeper, signerAccs []Account, fee StdFee) sdk.Result {
+	if fgk == nil {
+		return sdk.ErrUnauthorized("fee grants are not supported").Result()
+	}
+	granterIdx := -1
+	for i, signerAcc := range signerAccs {
+		if bytes.Equal(signerAcc.GetAddress(), fee.Granter) {
+			granterIdx = i
+			break
+		}
+	}
+	var granterAcc Account
+	if granterIdx >= 0 {
+		granterAcc = signerAccs[granterIdx]
+	} else {
+		granterAcc = am.GetAccount(ctx, fee.Granter)
+	}
+	if granterAcc == nil {
+		return sdk.ErrUnknownAddress(fee.Granter.String()).Result()
+	}
+	granterAcc, res := deductFees(granterAcc, fee, ctx.BlockHeader().Time)
+	if !res.IsOK() {
+		return res
+	}
+	err := fgk.UseGrantedFees(ctx, fee.Granter, signerAccs[0].GetAddress(), fee.Amount)
+	if err != nil {
+		return err.Result()
+	}
+	if granterIdx >= 0 {
+		signerAccs[granterIdx] = granterAcc
+	} else {
+		am.SetAccount(ctx, granterAcc)
+	}
+	return sdk.Result{}
+}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,6 +10,7 @@ import (
 	crypto "github.com/tendermint/go-crypto"
 	"github.com/tendermint/tmlibs/log"
 
+	"github.com/cosmos/cosmos-sdk/crypto/multisig"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	wire "github.com/cosmos/cosmos-sdk/wire"
 )
@@ -18,7 +20,7 @@ func newTestMsg(addrs ...sdk.Address) *sdk.TestMsg {
 }
 
 func newStdFee() StdFee {
-	return NewStdFee(100,
+	return NewStdFee(5000,
 		sdk.Coin{"atom", 150},
 	)
 }
@@ -52,17 +54,24 @@ func checkInvalidTx(t *testing.T, anteHandler sdk.AnteHandler, ctx sdk.Context,
 	assert.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, code), result.Code)
 }
 
-func newTestTx(ctx sdk.Context, msg sdk.Msg, privs []crypto.PrivKey, accNums []int64, seqs []int64, fee StdFee) sdk.Tx {
-	signBytes := StdSignBytes(ctx.ChainID(), accNums, seqs, fee, msg)
-	return newTestTxWithSignBytes(msg, privs, accNums, seqs, fee, signBytes)
+func newTestTx(ctx sdk.Context, msgs []sdk.Msg, privs []crypto.PrivKey, accNums []int64, seqs []int64, fee StdFee) sdk.Tx {
+	signBytes := StdSignBytes(ctx.ChainID(), accNums, seqs, fee, msgs, "", 0)
+	return newTestTxWithSignBytes(msgs, privs, accNums, seqs, fee, signBytes)
 }
 
-func newTestTxWithSignBytes(msg sdk.Msg, privs []crypto.PrivKey, accNums []int64, seqs []int64, fee StdFee, signBytes []byte) sdk.Tx {
+func newTestTxWithMemo(ctx sdk.Context, msgs []sdk.Msg, privs []crypto.PrivKey, accNums []int64, seqs []int64, fee StdFee, memo string, timeoutHeight int64) sdk.Tx {
+	signBytes := StdSignBytes(ctx.ChainID(), accNums, seqs, fee, msgs, memo, timeoutHeight)
+	tx := newTestTxWithSignBytes(msgs, privs, accNums, seqs, fee, signBytes).(StdTx)
+	tx.Memo, tx.TimeoutHeight = memo, timeoutHeight
+	return tx
+}
+
+func newTestTxWithSignBytes(msgs []sdk.Msg, privs []crypto.PrivKey, accNums []int64, seqs []int64, fee StdFee, signBytes []byte) sdk.Tx {
 	sigs := make([]StdSignature, len(privs))
 	for i, priv := range privs {
 		sigs[i] = StdSignature{PubKey: priv.PubKey(), Signature: priv.Sign(signBytes), AccountNumber: accNums[i], Sequence: seqs[i]}
 	}
-	tx := NewStdTx(msg, fee, sigs)
+	tx := NewStdTx(msgs, fee, sigs, "", 0)
 	return tx
 }
 
@@ -88,17 +97,17 @@ func TestAnteHandlerSigErrors(t *testing.T) {
 
 	// test no signatures
 	privs, accNums, seqs := []crypto.PrivKey{}, []int64{}, []int64{}
-	tx = newTestTx(ctx, msg, privs, accNums, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accNums, seqs, fee)
 	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeUnauthorized)
 
 	// test num sigs dont match GetSigners
 	privs, accNums, seqs = []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
-	tx = newTestTx(ctx, msg, privs, accNums, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accNums, seqs, fee)
 	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeUnauthorized)
 
 	// test an unrecognized account
 	privs, accNums, seqs = []crypto.PrivKey{priv1, priv2}, []int64{0, 1}, []int64{0, 0}
-	tx = newTestTx(ctx, msg, privs, accNums, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accNums, seqs, fee)
 	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeUnknownAddress)
 
 	// save the first account, but second is still unrecognized
@@ -138,28 +147,28 @@ func TestAnteHandlerAccountNumbers(t *testing.T) {
 
 	// test good tx from one signer
 	privs, accnums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
-	tx = newTestTx(ctx, msg, privs, accnums, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, seqs, fee)
 	checkValidTx(t, anteHandler, ctx, tx)
 
 	// new tx from wrong account number
 	seqs = []int64{1}
-	tx = newTestTx(ctx, msg, privs, []int64{1}, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, []int64{1}, seqs, fee)
 	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeInvalidSequence)
 
 	// from correct account number
 	seqs = []int64{1}
-	tx = newTestTx(ctx, msg, privs, []int64{0}, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, []int64{0}, seqs, fee)
 	checkValidTx(t, anteHandler, ctx, tx)
 
 	// new tx with another signer and incorrect account numbers
 	msg = newTestMsg(addr1, addr2)
 	privs, accnums, seqs = []crypto.PrivKey{priv1, priv2}, []int64{1, 0}, []int64{2, 0}
-	tx = newTestTx(ctx, msg, privs, accnums, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, seqs, fee)
 	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeInvalidSequence)
 
 	// correct account numbers
 	privs, accnums, seqs = []crypto.PrivKey{priv1, priv2}, []int64{0, 1}, []int64{2, 0}
-	tx = newTestTx(ctx, msg, privs, accnums, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, seqs, fee)
 	checkValidTx(t, anteHandler, ctx, tx)
 }
 
@@ -193,7 +202,7 @@ func TestAnteHandlerSequences(t *testing.T) {
 
 	// test good tx from one signer
 	privs, accnums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
-	tx = newTestTx(ctx, msg, privs, accnums, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, seqs, fee)
 	checkValidTx(t, anteHandler, ctx, tx)
 
 	// test sending it again fails (replay protection)
@@ -201,13 +210,13 @@ func TestAnteHandlerSequences(t *testing.T) {
 
 	// fix sequence, should pass
 	seqs = []int64{1}
-	tx = newTestTx(ctx, msg, privs, accnums, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, seqs, fee)
 	checkValidTx(t, anteHandler, ctx, tx)
 
 	// new tx with another signer and correct sequences
 	msg = newTestMsg(addr1, addr2)
 	privs, accnums, seqs = []crypto.PrivKey{priv1, priv2}, []int64{0, 1}, []int64{2, 0}
-	tx = newTestTx(ctx, msg, privs, accnums, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, seqs, fee)
 	checkValidTx(t, anteHandler, ctx, tx)
 
 	// replay fails
@@ -216,17 +225,17 @@ func TestAnteHandlerSequences(t *testing.T) {
 	// tx from just second signer with incorrect sequence fails
 	msg = newTestMsg(addr2)
 	privs, accnums, seqs = []crypto.PrivKey{priv2}, []int64{1}, []int64{0}
-	tx = newTestTx(ctx, msg, privs, accnums, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, seqs, fee)
 	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeInvalidSequence)
 
 	// fix the sequence and it passes
-	tx = newTestTx(ctx, msg, []crypto.PrivKey{priv2}, []int64{1}, []int64{1}, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv2}, []int64{1}, []int64{1}, fee)
 	checkValidTx(t, anteHandler, ctx, tx)
 
 	// another tx from both of them that passes
 	msg = newTestMsg(addr1, addr2)
 	privs, accnums, seqs = []crypto.PrivKey{priv1, priv2}, []int64{0, 1}, []int64{3, 2}
-	tx = newTestTx(ctx, msg, privs, accnums, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, seqs, fee)
 	checkValidTx(t, anteHandler, ctx, tx)
 }
 
@@ -252,12 +261,12 @@ func TestAnteHandlerFees(t *testing.T) {
 	var tx sdk.Tx
 	msg := newTestMsg(addr1)
 	privs, accnums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
-	fee := NewStdFee(100,
+	fee := NewStdFee(5000,
 		sdk.Coin{"atom", 150},
 	)
 
 	// signer does not have enough funds to pay the fee
-	tx = newTestTx(ctx, msg, privs, accnums, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, seqs, fee)
 	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeInsufficientFunds)
 
 	acc1.SetCoins(sdk.Coins{{"atom", 149}})
@@ -273,6 +282,157 @@ func TestAnteHandlerFees(t *testing.T) {
 	assert.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(sdk.Coins{{"atom", 150}}))
 }
 
+// fee grant keeper holding the allowances of granters in memory
+type testFeeGrantKeeper map[string]sdk.Coins
+
+func (fgk testFeeGrantKeeper) UseGrantedFees(ctx sdk.Context, granter, grantee sdk.Address, fee sdk.Coins) sdk.Error {
+	key := string(granter) + string(grantee)
+	remaining := fgk[key].Minus(fee)
+	if !remaining.IsNotNegative() {
+		return sdk.ErrUnauthorized("fee allowance exceeded")
+	}
+	fgk[key] = remaining
+	return nil
+}
+
+// Test that the fees of a tx naming a granter are paid by the granter.
+func TestAnteHandlerFeeGrants(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	feeGrants := testFeeGrantKeeper{}
+	anteHandler := NewAnteHandlerWithFeeGrants(mapper, feeCollector, feeGrants, DefaultGasConfig())
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, nil, log.NewNopLogger())
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+	_, addr2 := privAndAddr()
+
+	// set the accounts, only the granter holds coins
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	mapper.SetAccount(ctx, acc1)
+	acc2 := mapper.NewAccountWithAddress(ctx, addr2)
+	acc2.SetCoins(sdk.Coins{{"atom", 200}})
+	mapper.SetAccount(ctx, acc2)
+
+	// msg and signatures
+	var tx sdk.Tx
+	msg := newTestMsg(addr1)
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
+	fee := newStdFee().WithGranter(addr2)
+
+	// the granter has not granted any fees
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, seqs, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeUnauthorized)
+
+	// the fees are paid by the granter within its allowance
+	feeGrants[string(addr2)+string(addr1)] = sdk.Coins{{"atom", 300}}
+	checkValidTx(t, anteHandler, ctx, tx)
+	assert.Equal(t, sdk.Coins{{"atom", 150}}, feeGrants[string(addr2)+string(addr1)])
+	assert.Equal(t, int64(50), mapper.GetAccount(ctx, addr2).GetCoins().AmountOf("atom"))
+	assert.True(t, mapper.GetAccount(ctx, addr1).GetCoins().IsZero())
+	assert.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(sdk.Coins{{"atom", 150}}))
+
+	// the granter cannot pay more than it holds, its allowance is unchanged
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, []int64{1}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeInsufficientFunds)
+	assert.Equal(t, sdk.Coins{{"atom", 150}}, feeGrants[string(addr2)+string(addr1)])
+
+	// the granter must be supported by the ante handler
+	anteHandler = NewAnteHandler(mapper, feeCollector)
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeUnauthorized)
+}
+
+// Test that a granter co-signing the tx pays its fees once.
+func TestAnteHandlerFeeGrantsCosigned(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	feeGrants := testFeeGrantKeeper{}
+	anteHandler := NewAnteHandlerWithFeeGrants(mapper, feeCollector, feeGrants, DefaultGasConfig())
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, nil, log.NewNopLogger())
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+	priv2, addr2 := privAndAddr()
+
+	// set the accounts, only the granter holds coins
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	mapper.SetAccount(ctx, acc1)
+	acc2 := mapper.NewAccountWithAddress(ctx, addr2)
+	acc2.SetCoins(sdk.Coins{{"atom", 200}})
+	mapper.SetAccount(ctx, acc2)
+	feeGrants[string(addr2)+string(addr1)] = sdk.Coins{{"atom", 300}}
+
+	// the granter is the second signer
+	msg := newTestMsg(addr1, addr2)
+	privs, accnums, seqs := []crypto.PrivKey{priv1, priv2}, []int64{0, 1}, []int64{0, 0}
+	fee := newStdFee().WithGranter(addr2)
+	tx := newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, seqs, fee)
+	checkValidTx(t, anteHandler, ctx, tx)
+
+	// its fee is debited and its sequence incremented
+	granterAcc := mapper.GetAccount(ctx, addr2)
+	assert.Equal(t, sdk.Coins{{"atom", 50}}, granterAcc.GetCoins())
+	assert.Equal(t, int64(1), granterAcc.GetSequence())
+	assert.Equal(t, int64(1), mapper.GetAccount(ctx, addr1).GetSequence())
+	assert.Equal(t, sdk.Coins{{"atom", 150}}, feeGrants[string(addr2)+string(addr1)])
+	assert.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(sdk.Coins{{"atom", 150}}))
+}
+
+// Test that txs must pay the minimum gas prices to pass CheckTx only.
+func TestAnteHandlerMinimumGasPrices(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	anteHandler := NewAnteHandler(mapper, feeCollector)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, true, nil, log.NewNopLogger())
+	ctx = ctx.WithMinimumGasPrices(sdk.Coins{{"atom", 2}, {"photon", 1}})
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+
+	// set the accounts
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(sdk.Coins{{"atom", 100000}, {"photon", 100000}})
+	mapper.SetAccount(ctx, acc1)
+
+	msg := newTestMsg(addr1)
+	privs, accnums := []crypto.PrivKey{priv1}, []int64{0}
+
+	// fee below the price in every denomination
+	tx := newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, []int64{0}, NewStdFee(10000, sdk.Coin{"atom", 19999}))
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeInsufficientFee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, []int64{0}, NewStdFee(10000))
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeInsufficientFee)
+
+	// gas limits whose fee overflows are rejected, not free
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, []int64{0}, NewStdFee(1<<62))
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeInsufficientFee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, []int64{0}, NewStdFee(-1))
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeInsufficientFee)
+
+	// paying the price in any of the denominations is enough
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, []int64{0}, NewStdFee(10000, sdk.Coin{"atom", 20000}))
+	checkValidTx(t, anteHandler, ctx, tx)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, []int64{1}, NewStdFee(10000, sdk.Coin{"photon", 10000}))
+	checkValidTx(t, anteHandler, ctx, tx)
+
+	// the prices are not enforced when delivering txs
+	ctx = ctx.WithIsCheckTx(false)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, []int64{2}, NewStdFee(10000))
+	checkValidTx(t, anteHandler, ctx, tx)
+}
+
 func TestAnteHandlerBadSignBytes(t *testing.T) {
 	// setup
 	ms, capKey, capKey2 := setupMultiStore()
@@ -305,7 +465,7 @@ func TestAnteHandlerBadSignBytes(t *testing.T) {
 
 	// test good tx and signBytes
 	privs, accnums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
-	tx = newTestTx(ctx, msg, privs, accnums, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, seqs, fee)
 	checkValidTx(t, anteHandler, ctx, tx)
 
 	chainID := ctx.ChainID()
@@ -332,21 +492,21 @@ func TestAnteHandlerBadSignBytes(t *testing.T) {
 	privs, seqs = []crypto.PrivKey{priv1}, []int64{1}
 	for _, cs := range cases {
 		tx := newTestTxWithSignBytes(
-			msg, privs, accnums, seqs, fee,
-			StdSignBytes(cs.chainID, cs.accnums, cs.seqs, cs.fee, cs.msg),
+			[]sdk.Msg{msg}, privs, accnums, seqs, fee,
+			StdSignBytes(cs.chainID, cs.accnums, cs.seqs, cs.fee, []sdk.Msg{cs.msg}, "", 0),
 		)
 		checkInvalidTx(t, anteHandler, ctx, tx, cs.code)
 	}
 
 	// test wrong signer if public key exist
 	privs, accnums, seqs = []crypto.PrivKey{priv2}, []int64{0}, []int64{1}
-	tx = newTestTx(ctx, msg, privs, accnums, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, seqs, fee)
 	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeUnauthorized)
 
 	// test wrong signer if public doesn't exist
 	msg = newTestMsg(addr2)
 	privs, accnums, seqs = []crypto.PrivKey{priv1}, []int64{1}, []int64{0}
-	tx = newTestTx(ctx, msg, privs, accnums, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, seqs, fee)
 	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeInvalidPubKey)
 
 }
@@ -379,7 +539,7 @@ func TestAnteHandlerSetPubKey(t *testing.T) {
 	msg := newTestMsg(addr1)
 	privs, accnums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
 	fee := newStdFee()
-	tx = newTestTx(ctx, msg, privs, accnums, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, seqs, fee)
 	checkValidTx(t, anteHandler, ctx, tx)
 
 	acc1 = mapper.GetAccount(ctx, addr1)
@@ -387,7 +547,7 @@ func TestAnteHandlerSetPubKey(t *testing.T) {
 
 	// test public key not found
 	msg = newTestMsg(addr2)
-	tx = newTestTx(ctx, msg, privs, []int64{1}, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, []int64{1}, seqs, fee)
 	sigs := tx.(StdTx).GetSignatures()
 	sigs[0].PubKey = nil
 	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeInvalidPubKey)
@@ -396,9 +556,332 @@ func TestAnteHandlerSetPubKey(t *testing.T) {
 	assert.Nil(t, acc2.GetPubKey())
 
 	// test invalid signature and public key
-	tx = newTestTx(ctx, msg, privs, []int64{1}, seqs, fee)
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, []int64{1}, seqs, fee)
 	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeInvalidPubKey)
 
 	acc2 = mapper.GetAccount(ctx, addr2)
 	assert.Nil(t, acc2.GetPubKey())
 }
+
+// Test that a tx with many msgs is signed once by each distinct signer.
+func TestAnteHandlerMultiMsg(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	anteHandler := NewAnteHandler(mapper, feeCollector)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, nil, log.NewNopLogger())
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+	priv2, addr2 := privAndAddr()
+	priv3, addr3 := privAndAddr()
+
+	// set the accounts
+	for _, addr := range []sdk.Address{addr1, addr2, addr3} {
+		acc := mapper.NewAccountWithAddress(ctx, addr)
+		acc.SetCoins(newCoins())
+		mapper.SetAccount(ctx, acc)
+	}
+
+	// msgs sharing the first signer
+	var tx sdk.Tx
+	msgs := []sdk.Msg{newTestMsg(addr1, addr2), newTestMsg(addr3, addr1)}
+	fee := newStdFee()
+
+	// signing once per msg signer is rejected
+	privs, accnums, seqs := []crypto.PrivKey{priv1, priv2, priv3, priv1}, []int64{0, 1, 2, 0}, []int64{0, 0, 0, 0}
+	tx = newTestTx(ctx, msgs, privs, accnums, seqs, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeUnauthorized)
+
+	// signing once per distinct signer passes
+	privs, accnums, seqs = []crypto.PrivKey{priv1, priv2, priv3}, []int64{0, 1, 2}, []int64{0, 0, 0}
+	tx = newTestTx(ctx, msgs, privs, accnums, seqs, fee)
+	checkValidTx(t, anteHandler, ctx, tx)
+
+	// each signer's sequence was bumped once
+	for _, addr := range []sdk.Address{addr1, addr2, addr3} {
+		require.Equal(t, int64(1), mapper.GetAccount(ctx, addr).GetSequence())
+	}
+}
+
+// Test that an account controlled by a multisig key needs its threshold of signatures.
+func TestAnteHandlerMultisig(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	anteHandler := NewAnteHandler(mapper, feeCollector)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, nil, log.NewNopLogger())
+
+	// 2 of 3 multisig key and its account
+	priv1, _ := privAndAddr()
+	priv2, _ := privAndAddr()
+	priv3, _ := privAndAddr()
+	pubkeys := []crypto.PubKey{priv1.PubKey(), priv2.PubKey(), priv3.PubKey()}
+	multisigKey := multisig.NewPubKeyMultisigThreshold(2, pubkeys)
+	addr := multisigKey.Address()
+
+	acc := mapper.NewAccountWithAddress(ctx, addr)
+	acc.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc)
+
+	msg := newTestMsg(addr)
+	fee := newStdFee()
+	signBytes := StdSignBytes(ctx.ChainID(), []int64{0}, []int64{0}, fee, []sdk.Msg{msg}, "", 0)
+	newMultisigTx := func(privs ...crypto.PrivKey) sdk.Tx {
+		sig := multisig.NewSignatureMultisig(len(pubkeys))
+		for _, priv := range privs {
+			err := sig.AddSignatureFromPubKey(priv.Sign(signBytes), priv.PubKey(), pubkeys)
+			require.Nil(t, err)
+		}
+		stdSig := StdSignature{PubKey: multisigKey, Signature: *sig, AccountNumber: 0, Sequence: 0}
+		return NewStdTx([]sdk.Msg{msg}, fee, []StdSignature{stdSig}, "", 0)
+	}
+
+	// below the threshold
+	checkInvalidTx(t, anteHandler, ctx, newMultisigTx(priv1), sdk.CodeUnauthorized)
+
+	// more bits set than signatures are rejected before charging them
+	malformed := newMultisigTx(priv1, priv3).(StdTx)
+	sig := malformed.Signatures[0].Signature.(multisig.SignatureMultisig)
+	sig.Sigs = sig.Sigs[:1]
+	malformed.Signatures[0].Signature = sig
+	checkInvalidTx(t, anteHandler, ctx, malformed, sdk.CodeUnauthorized)
+
+	// signatures are accepted in any order
+	checkValidTx(t, anteHandler, ctx, newMultisigTx(priv3, priv1))
+
+	acc = mapper.GetAccount(ctx, addr)
+	require.True(t, multisigKey.Equals(acc.GetPubKey()))
+	require.Equal(t, int64(1), acc.GetSequence())
+}
+
+// Test the memo and timeout height of txs.
+func TestAnteHandlerMemoAndTimeoutHeight(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	anteHandler := NewAnteHandler(mapper, feeCollector)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 10}, false, nil, log.NewNopLogger())
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+
+	// set the accounts
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc1)
+
+	var tx sdk.Tx
+	msg := newTestMsg(addr1)
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
+	fee := newStdFee()
+
+	// the memo is signed
+	tx = newTestTxWithMemo(ctx, []sdk.Msg{msg}, privs, accnums, seqs, fee, "deposit", 0)
+	stdTx := tx.(StdTx)
+	stdTx.Memo = "another deposit"
+	checkInvalidTx(t, anteHandler, ctx, stdTx, sdk.CodeUnauthorized)
+
+	// the memo length is limited
+	tx = newTestTxWithMemo(ctx, []sdk.Msg{msg}, privs, accnums, seqs, fee, strings.Repeat("m", MaxMemoCharacters+1), 0)
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeMemoTooLarge)
+
+	// txs time out after their timeout height
+	tx = newTestTxWithMemo(ctx, []sdk.Msg{msg}, privs, accnums, seqs, fee, "deposit", 9)
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeTxTimeout)
+	tx = newTestTxWithMemo(ctx, []sdk.Msg{msg}, privs, accnums, seqs, fee, "deposit", 10)
+	checkValidTx(t, anteHandler, ctx, tx)
+}
+
+// Test decorators appended to the auth decorators.
+func TestAnteDecorators(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, nil, log.NewNopLogger())
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+	priv2, addr2 := privAndAddr()
+
+	// set the accounts
+	for _, addr := range []sdk.Address{addr1, addr2} {
+		acc := mapper.NewAccountWithAddress(ctx, addr)
+		acc.SetCoins(newCoins())
+		mapper.SetAccount(ctx, acc)
+	}
+
+	// msgs signed by a blocked address are rejected, once signers are verified
+	blocked := sdk.NewMsgAnteDecorator("TestMsg", func(ctx sdk.Context, tx sdk.Tx, msg sdk.Msg) sdk.Result {
+		require.Equal(t, addr1, GetSigners(ctx)[0].GetAddress())
+		for _, signer := range msg.GetSigners() {
+			if signer.String() == addr2.String() {
+				return sdk.ErrUnauthorized("blocked address").Result()
+			}
+		}
+		return sdk.Result{}
+	})
+	decorators := append(NewAnteDecorators(mapper, feeCollector, nil, DefaultGasConfig()), blocked)
+	anteHandler := sdk.ChainAnteDecorators(decorators...)
+
+	msgs := []sdk.Msg{newTestMsg(addr1), newTestMsg(addr2)}
+	privs, accnums, seqs := []crypto.PrivKey{priv1, priv2}, []int64{0, 1}, []int64{0, 0}
+	tx := newTestTx(ctx, msgs, privs, accnums, seqs, newStdFee())
+
+	// the gas of the auth decorators is charged however the chain ends
+	authCtx, res, abort := NewAnteHandler(mapper, feeCollector)(ctx.WithMultiStore(ms.CacheMultiStore()), tx)
+	require.False(t, abort, res.Log)
+	blockedCtx, res, abort := anteHandler(ctx.WithMultiStore(ms.CacheMultiStore()), tx)
+	require.True(t, abort)
+	require.Equal(t, authCtx.GasMeter().GasConsumed(), blockedCtx.GasMeter().GasConsumed())
+
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.CodeUnauthorized)
+
+	// the fee was paid before the tx was rejected
+	require.Equal(t, int64(1), mapper.GetAccount(ctx, addr1).GetSequence())
+	require.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(sdk.Coins{{"atom", 150}}))
+
+	privs, accnums, seqs = []crypto.PrivKey{priv1}, []int64{0}, []int64{1}
+	tx = newTestTx(ctx, []sdk.Msg{newTestMsg(addr1)}, privs, accnums, seqs, newStdFee())
+	checkValidTx(t, anteHandler, ctx, tx)
+}
+
+func TestConsumeSignatureGas(t *testing.T) {
+	gasConfig := DefaultGasConfig()
+	priv1 := crypto.GenPrivKeyEd25519()
+	priv2 := crypto.GenPrivKeySecp256k1()
+	priv3 := crypto.GenPrivKeySecp256k1()
+	msg := []byte("msg")
+
+	// the cost depends on the type of the key
+	meter := sdk.NewInfiniteGasMeter()
+	res := consumeSignatureGas(meter, priv1.PubKey(), priv1.Sign(msg), gasConfig)
+	require.True(t, res.IsOK())
+	require.Equal(t, gasConfig.SigVerifyCostEd25519, meter.GasConsumed())
+
+	meter = sdk.NewInfiniteGasMeter()
+	res = consumeSignatureGas(meter, priv2.PubKey(), priv2.Sign(msg), gasConfig)
+	require.True(t, res.IsOK())
+	require.Equal(t, gasConfig.SigVerifyCostSecp256k1, meter.GasConsumed())
+
+	// each signature of a multisig is charged
+	pubkeys := []crypto.PubKey{priv1.PubKey(), priv2.PubKey(), priv3.PubKey()}
+	multisigKey := multisig.NewPubKeyMultisigThreshold(2, pubkeys)
+	sig := multisig.NewSignatureMultisig(len(pubkeys))
+	sig.AddSignatureFromPubKey(priv1.Sign(msg), priv1.PubKey(), pubkeys)
+	sig.AddSignatureFromPubKey(priv3.Sign(msg), priv3.PubKey(), pubkeys)
+
+	meter = sdk.NewInfiniteGasMeter()
+	res = consumeSignatureGas(meter, multisigKey, *sig, gasConfig)
+	require.True(t, res.IsOK())
+	require.Equal(t, gasConfig.SigVerifyCostEd25519+gasConfig.SigVerifyCostSecp256k1, meter.GasConsumed())
+
+	// malformed multisig signatures are rejected without charging them
+	malformed := []multisig.SignatureMultisig{
+		// more bits set than signatures
+		{BitArray: sig.BitArray, Sigs: sig.Sigs[:1]},
+		// fewer bits than keys
+		{BitArray: multisig.NewCompactBitArray(2), Sigs: nil},
+		// no bit array
+		{BitArray: nil, Sigs: nil},
+		// invalid bit array
+		{BitArray: &multisig.CompactBitArray{ExtraBitsStored: 9, Elems: []byte{0xFF}}, Sigs: sig.Sigs},
+	}
+	for i, msig := range malformed {
+		meter = sdk.NewInfiniteGasMeter()
+		res = consumeSignatureGas(meter, multisigKey, msig, gasConfig)
+		require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnauthorized), res.Code, "case %d", i)
+		require.Equal(t, sdk.Gas(0), meter.GasConsumed(), "case %d", i)
+	}
+}
+
+// Test that the ante handler runs within the gas limit of the tx.
+func TestAnteHandlerGas(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	gasConfig := GasConfig{
+		TxSizeCostPerByte:      2,
+		MemoCostPerByte:        3,
+		SigVerifyCostEd25519:   100,
+		SigVerifyCostSecp256k1: 200,
+	}
+	anteHandler := NewAnteHandlerWithGasConfig(mapper, feeCollector, gasConfig)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, nil, log.NewNopLogger())
+
+	// gas consumed by the ante handler, leaving the state untouched
+	gasConsumed := func(ctx sdk.Context, anteHandler sdk.AnteHandler, tx sdk.Tx) sdk.Gas {
+		newCtx, res, abort := anteHandler(ctx.WithMultiStore(ms.CacheMultiStore()), tx)
+		require.False(t, abort)
+		require.True(t, res.IsOK())
+		return newCtx.GasMeter().GasConsumed()
+	}
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+	priv2, addr2 := privAndAddr()
+
+	// set the accounts
+	for _, addr := range []sdk.Address{addr1, addr2} {
+		acc := mapper.NewAccountWithAddress(ctx, addr)
+		acc.SetCoins(newCoins())
+		mapper.SetAccount(ctx, acc)
+	}
+
+	msg := newTestMsg(addr1, addr2)
+	privs, accnums := []crypto.PrivKey{priv1, priv2}, []int64{0, 1}
+	fee := NewStdFee(100000, sdk.Coin{"atom", 150})
+	tx := newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, []int64{0, 0}, fee)
+
+	// the size of the tx is charged per byte
+	gas := gasConsumed(ctx.WithTxBytes(make([]byte, 50)), anteHandler, tx)
+	require.Equal(t, gas+2*50, gasConsumed(ctx.WithTxBytes(make([]byte, 100)), anteHandler, tx))
+
+	// the memo is charged per byte
+	memoTx := newTestTxWithMemo(ctx, []sdk.Msg{msg}, privs, accnums, []int64{0, 0}, fee, "memo", 0)
+	require.Equal(t, gas+3*4, gasConsumed(ctx.WithTxBytes(make([]byte, 50)), anteHandler, memoTx))
+
+	// each signature is charged
+	gasConfig.SigVerifyCostEd25519 = 300
+	expensiveAnteHandler := NewAnteHandlerWithGasConfig(mapper, feeCollector, gasConfig)
+	require.Equal(t, gas+2*200, gasConsumed(ctx.WithTxBytes(make([]byte, 50)), expensiveAnteHandler, tx))
+
+	// signatures are charged before they are verified, the invalid
+	// signature beyond the gas limit is never verified
+	fee = NewStdFee(150, sdk.Coin{"atom", 150})
+	stdTx := newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, []int64{0, 0}, fee).(StdTx)
+	stdTx.Signatures[1].Signature = priv2.Sign([]byte("other"))
+	_, res, abort := anteHandler(ctx, stdTx)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfGas), res.Code)
+	require.Equal(t, int64(0), mapper.GetAccount(ctx, addr1).GetSequence())
+	require.True(t, feeCollector.GetCollectedFees(ctx).IsZero())
+
+	// running out of gas aborts the tx but the fee is still paid
+	fee = NewStdFee(300, sdk.Coin{"atom", 150})
+	tx = newTestTx(ctx, []sdk.Msg{msg}, privs, accnums, []int64{0, 0}, fee)
+	_, res, abort = anteHandler(ctx, tx)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfGas), res.Code)
+	require.Equal(t, int64(300), res.GasWanted)
+
+	acc1 := mapper.GetAccount(ctx, addr1)
+	require.Equal(t, newCoins().Minus(sdk.Coins{{"atom", 150}}), acc1.GetCoins())
+	require.Equal(t, int64(1), acc1.GetSequence())
+	require.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(sdk.Coins{{"atom", 150}}))
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - the auth state kept besides the accounts themselves
+type GenesisState struct {
+	CollectedFees     sdk.Coins        `json:"collected_fees"`
+	NextAccountNumber int64            `json:"next_account_number"` // zero if the genesis accounts are yet to be numbered
+	RemovedAccounts   []RemovedAccount `json:"removed_accounts"`
+	EmptyAccounts     []EmptyAccount   `json:"empty_accounts"`
+}
+
+// RemovedAccount - address and account number of a removed account
+type RemovedAccount struct {
+	Address       sdk.Address `json:"address"`
+	AccountNumber int64       `json:"account_number"`
+}
+
+// EmptyAccount - an account without coins and the height it was last set
+// at, from which its dormancy is counted
+type EmptyAccount struct {
+	Address sdk.Address `json:"address"`
+	Height  int64       `json:"height"`
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// InitGenesis - store the auth state, the accounts must have been set
+// beforehand. The account number counter is only set if the genesis
+// accounts were numbered, that is for an exported state.
+func InitGenesis(ctx sdk.Context, am AccountMapper, fck FeeCollectionKeeper, data GenesisState) {
+	fck.setCollectedFees(ctx, data.CollectedFees)
+	if data.NextAccountNumber != 0 {
+		am.setNextAccountNumber(ctx, data.NextAccountNumber)
+	}
+	store := ctx.KVStore(am.key)
+	for _, removed := range data.RemovedAccounts {
+		store.Set(removedAccountKey(removed.Address, removed.AccountNumber), []byte{})
+	}
+	if !am.emptyAccountQueue {
+		return
+	}
+	for _, empty := range data.EmptyAccounts {
+		dequeueEmptyAccount(store, empty.Address)
+		queueEmptyAccount(store, empty.Address, empty.Height)
+	}
+}
+
+// WriteGenesis - output the auth state
+func WriteGenesis(ctx sdk.Context, am AccountMapper, fck FeeCollectionKeeper) GenesisState {
+	var removed []RemovedAccount
+	am.iterateRemovedAccounts(ctx, func(addr sdk.Address, accNumber int64) (stop bool) {
+		removed = append(removed, RemovedAccount{addr, accNumber})
+		return false
+	})
+	var empty []EmptyAccount
+	am.iterateEmptyAccounts(ctx, func(addr sdk.Address, height int64) (stop bool) {
+		empty = append(empty, EmptyAccount{addr, height})
+		return false
+	})
+	return GenesisState{
+		CollectedFees:     fck.GetCollectedFees(ctx),
+		NextAccountNumber: am.peekNextAccountNumber(ctx),
+		RemovedAccounts:   removed,
+		EmptyAccounts:     empty,
+	}
+}
+
+// ForZeroHeight - rebase the state exported at a height for a chain
+// restarting at height zero, the dormancy of empty accounts starts over
+func (data GenesisState) ForZeroHeight(height int64) GenesisState {
+	empty := make([]EmptyAccount, len(data.EmptyAccounts))
+	for i, account := range data.EmptyAccounts {
+		empty[i] = EmptyAccount{account.Address, 0}
+	}
+	data.EmptyAccounts = empty
+	return data
+}
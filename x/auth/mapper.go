@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"encoding/binary"
 	"fmt"
 	"reflect"
 
@@ -9,7 +10,12 @@ import (
 	crypto "github.com/tendermint/go-crypto"
 )
 
-var globalAccountNumberKey = []byte("globalAccountNumber")
+var (
+	globalAccountNumberKey     = []byte("globalAccountNumber")
+	removedAccountKeyPrefix    = []byte("removedAccount:")    // prefix for the account numbers of removed accounts
+	emptyAccountQueueKeyPrefix = []byte("emptyAccountQueue:") // prefix for the empty accounts, by the height they were last set at
+	emptySinceKeyPrefix        = []byte("emptySince:")        // prefix for the height empty accounts were last set at
+)
 
 // This AccountMapper encodes/decodes accounts using the
 // go-amino (binary) encoding/decoding library.
@@ -23,6 +29,9 @@ type AccountMapper struct {
 
 	// The wire codec for binary encoding/decoding of accounts.
 	cdc *wire.Codec
+
+	// Whether the accounts without coins are queued for the AccountReaper.
+	emptyAccountQueue bool
 }
 
 // NewAccountMapper returns a new sdk.AccountMapper that
@@ -36,6 +45,14 @@ func NewAccountMapper(cdc *wire.Codec, key sdk.StoreKey, proto Account) AccountM
 	}
 }
 
+// WithEmptyAccountQueue returns a mapper queueing the accounts without coins
+// by the height they were last set at, as required by the AccountReaper. It
+// must be set before the mapper is handed to other keepers.
+func (am AccountMapper) WithEmptyAccountQueue() AccountMapper {
+	am.emptyAccountQueue = true
+	return am
+}
+
 // Implaements sdk.AccountMapper.
 func (am AccountMapper) NewAccountWithAddress(ctx sdk.Context, addr sdk.Address) Account {
 	acc := am.clonePrototype()
@@ -72,25 +89,135 @@ func (am AccountMapper) SetAccount(ctx sdk.Context, acc Account) {
 	store := ctx.KVStore(am.key)
 	bz := am.encodeAccount(acc)
 	store.Set(AddressStoreKey(addr), bz)
+
+	// accounts without coins are queued by the height they were last set at,
+	// any change to the account restarts its dormancy
+	if am.emptyAccountQueue {
+		dequeueEmptyAccount(store, addr)
+		if acc.GetCoins().IsZero() {
+			queueEmptyAccount(store, addr, ctx.BlockHeight())
+		}
+	}
+}
+
+// RemoveAccount deletes an account from the store. The account number of the
+// account is kept so that it is never handed out again, an account created
+// later at the same address gets a new account number and the txs signed
+// for the removed account cannot be replayed.
+func (am AccountMapper) RemoveAccount(ctx sdk.Context, acc Account) {
+	addr := acc.GetAddress()
+	store := ctx.KVStore(am.key)
+	store.Delete(AddressStoreKey(addr))
+	if am.emptyAccountQueue {
+		dequeueEmptyAccount(store, addr)
+	}
+	store.Set(removedAccountKey(addr, acc.GetAccountNumber()), []byte{})
+}
+
+// Returns the account numbers of the accounts removed from the address
+func (am AccountMapper) GetRemovedAccountNumbers(ctx sdk.Context, addr sdk.Address) (accNumbers []int64) {
+	store := ctx.KVStore(am.key)
+	prefix := append(append([]byte{}, removedAccountKeyPrefix...), addr.Bytes()...)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	for ; iter.Valid(); iter.Next() {
+		accNumber := int64(binary.BigEndian.Uint64(iter.Key()[len(prefix):]))
+		accNumbers = append(accNumbers, accNumber)
+	}
+	iter.Close()
+	return accNumbers
+}
+
+// iterate over the addresses and account numbers of all removed accounts
+func (am AccountMapper) iterateRemovedAccounts(ctx sdk.Context, process func(addr sdk.Address, accNumber int64) (stop bool)) {
+	store := ctx.KVStore(am.key)
+	iter := sdk.KVStorePrefixIterator(store, removedAccountKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()[len(removedAccountKeyPrefix):]
+		addr := sdk.Address(key[:len(key)-8])
+		accNumber := int64(binary.BigEndian.Uint64(key[len(key)-8:]))
+		if process(addr, accNumber) {
+			return
+		}
+	}
+}
+
+// iterate over the empty accounts and the heights they were last set at
+func (am AccountMapper) iterateEmptyAccounts(ctx sdk.Context, process func(addr sdk.Address, height int64) (stop bool)) {
+	store := ctx.KVStore(am.key)
+	iter := sdk.KVStorePrefixIterator(store, emptySinceKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		addr := sdk.Address(iter.Key()[len(emptySinceKeyPrefix):])
+		height := int64(binary.BigEndian.Uint64(iter.Value()))
+		if process(addr, height) {
+			return
+		}
+	}
 }
 
 // Implements sdk.AccountMapper.
 func (am AccountMapper) IterateAccounts(ctx sdk.Context, process func(Account) (stop bool)) {
 	store := ctx.KVStore(am.key)
 	iter := sdk.KVStorePrefixIterator(store, []byte("account:"))
-	for {
-		if !iter.Valid() {
-			return
-		}
-		val := iter.Value()
-		acc := am.decodeAccount(val)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		acc := am.decodeAccount(iter.Value())
 		if process(acc) {
 			return
 		}
-		iter.Next()
 	}
 }
 
+// AccountNumberInvariant checks that every account number, including those
+// of removed accounts, is unique and below the global account number counter
+func AccountNumberInvariant(am AccountMapper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		next := am.peekNextAccountNumber(ctx)
+		seen := make(map[int64]bool)
+		check := func(addr sdk.Address, accNumber int64) error {
+			if accNumber < 0 || accNumber >= next {
+				return fmt.Errorf("account %s has account number %d, the next account number is %d", addr, accNumber, next)
+			}
+			if seen[accNumber] {
+				return fmt.Errorf("account number %d of account %s is not unique", accNumber, addr)
+			}
+			seen[accNumber] = true
+			return nil
+		}
+
+		var err error
+		am.IterateAccounts(ctx, func(acc Account) (stop bool) {
+			err = check(acc.GetAddress(), acc.GetAccountNumber())
+			return err != nil
+		})
+		if err != nil {
+			return err
+		}
+		am.iterateRemovedAccounts(ctx, func(addr sdk.Address, accNumber int64) (stop bool) {
+			err = check(addr, accNumber)
+			return err != nil
+		})
+		return err
+	}
+}
+
+// get the addresses of the accounts which have been empty since at least the
+// provided height and remove them from the queue of empty accounts
+func (am AccountMapper) popEmptyAccountQueue(ctx sdk.Context, height int64) (addrs []sdk.Address) {
+	store := ctx.KVStore(am.key)
+	iter := store.Iterator(emptyAccountQueueKeyPrefix, emptyAccountQueueKey(height+1, nil))
+	for ; iter.Valid(); iter.Next() {
+		addrs = append(addrs, sdk.Address(iter.Value()))
+	}
+	iter.Close()
+
+	for _, addr := range addrs {
+		dequeueEmptyAccount(store, addr)
+	}
+	return addrs
+}
+
 // Returns the PubKey of the account at address
 func (am AccountMapper) GetPubKey(ctx sdk.Context, addr sdk.Address) (crypto.PubKey, sdk.Error) {
 	acc := am.GetAccount(ctx, addr)
@@ -132,27 +259,73 @@ func (am AccountMapper) setSequence(ctx sdk.Context, addr sdk.Address, newSequen
 
 // Returns and increments the global account number counter
 func (am AccountMapper) GetNextAccountNumber(ctx sdk.Context) int64 {
+	accNumber := am.peekNextAccountNumber(ctx)
+	am.setNextAccountNumber(ctx, accNumber+1)
+	return accNumber
+}
+
+// Sets the global account number counter
+func (am AccountMapper) setNextAccountNumber(ctx sdk.Context, accNumber int64) {
+	store := ctx.KVStore(am.key)
+	store.Set(globalAccountNumberKey, am.cdc.MustMarshalBinary(accNumber))
+}
+
+// Returns the global account number counter without incrementing it
+func (am AccountMapper) peekNextAccountNumber(ctx sdk.Context) int64 {
 	var accNumber int64
 	store := ctx.KVStore(am.key)
 	bz := store.Get(globalAccountNumberKey)
 	if bz == nil {
-		accNumber = 0
-	} else {
-		err := am.cdc.UnmarshalBinary(bz, &accNumber)
-		if err != nil {
-			panic(err)
-		}
+		return 0
+	}
+	err := am.cdc.UnmarshalBinary(bz, &accNumber)
+	if err != nil {
+		panic(err)
 	}
-
-	bz = am.cdc.MustMarshalBinary(accNumber + 1)
-	store.Set(globalAccountNumberKey, bz)
-
 	return accNumber
 }
 
 //----------------------------------------
 // misc.
 
+// Key for the account number of an account removed from the address
+func removedAccountKey(addr sdk.Address, accNumber int64) []byte {
+	return append(append(append([]byte{}, removedAccountKeyPrefix...), addr.Bytes()...), bigEndianBytes(accNumber)...)
+}
+
+// Key for an account in the queue of empty accounts
+func emptyAccountQueueKey(height int64, addr sdk.Address) []byte {
+	return append(append(append([]byte{}, emptyAccountQueueKeyPrefix...), bigEndianBytes(height)...), addr.Bytes()...)
+}
+
+// Key for the height an empty account was last set at
+func emptySinceKey(addr sdk.Address) []byte {
+	return append(append([]byte{}, emptySinceKeyPrefix...), addr.Bytes()...)
+}
+
+// queue an empty account by the height it was last set at
+func queueEmptyAccount(store sdk.KVStore, addr sdk.Address, height int64) {
+	store.Set(emptyAccountQueueKey(height, addr), addr)
+	store.Set(emptySinceKey(addr), bigEndianBytes(height))
+}
+
+// remove an account from the queue of empty accounts if it is queued
+func dequeueEmptyAccount(store sdk.KVStore, addr sdk.Address) {
+	bz := store.Get(emptySinceKey(addr))
+	if bz == nil {
+		return
+	}
+	height := int64(binary.BigEndian.Uint64(bz))
+	store.Delete(emptyAccountQueueKey(height, addr))
+	store.Delete(emptySinceKey(addr))
+}
+
+func bigEndianBytes(i int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(i))
+	return bz
+}
+
 // Creates a new struct (or pointer to struct) from am.proto.
 func (am AccountMapper) clonePrototype() Account {
 	protoRt := reflect.TypeOf(am.proto)
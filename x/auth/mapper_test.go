@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/abci/types"
 	dbm "github.com/tendermint/tmlibs/db"
@@ -60,3 +61,48 @@ func TestAccountMapperGetSet(t *testing.T) {
 	assert.NotNil(t, acc)
 	assert.Equal(t, newSequence, acc.GetSequence())
 }
+
+func TestAccountMapperRemoveAccount(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterBaseAccount(cdc)
+
+	// make context and mapper
+	ctx := sdk.NewContext(ms, abci.Header{}, false, nil, log.NewNopLogger())
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	invariant := AccountNumberInvariant(mapper)
+
+	addr1 := sdk.Address([]byte("some-address1"))
+	addr2 := sdk.Address([]byte("some-address2"))
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc2 := mapper.NewAccountWithAddress(ctx, addr2)
+	mapper.SetAccount(ctx, acc1)
+	mapper.SetAccount(ctx, acc2)
+	require.Nil(t, invariant(ctx))
+
+	// the account is removed but its account number is kept
+	mapper.RemoveAccount(ctx, acc1)
+	assert.Nil(t, mapper.GetAccount(ctx, addr1))
+	assert.Equal(t, []int64{0}, mapper.GetRemovedAccountNumbers(ctx, addr1))
+	assert.Nil(t, mapper.GetRemovedAccountNumbers(ctx, addr2))
+	require.Nil(t, invariant(ctx))
+
+	// the iterator only visits the remaining accounts
+	var addrs []sdk.Address
+	mapper.IterateAccounts(ctx, func(acc Account) (stop bool) {
+		addrs = append(addrs, acc.GetAddress())
+		return false
+	})
+	assert.Equal(t, []sdk.Address{addr2}, addrs)
+
+	// an account created again gets a new account number
+	acc1 = mapper.NewAccountWithAddress(ctx, addr1)
+	assert.Equal(t, int64(2), acc1.GetAccountNumber())
+	mapper.SetAccount(ctx, acc1)
+	require.Nil(t, invariant(ctx))
+
+	// account numbers may not be reused
+	acc1.SetAccountNumber(1)
+	mapper.SetAccount(ctx, acc1)
+	require.NotNil(t, invariant(ctx))
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DelegationKeeper reports whether the coins of an account are bonded,
+// unbonding or redelegating, the accounts of such delegators are not reaped
+type DelegationKeeper interface {
+	HasDelegations(ctx sdk.Context, delegator sdk.Address) bool
+}
+
+// AccountReaper removes the accounts which have held no coins for the
+// dormancy period, apps opt in by queueing the empty accounts of their
+// AccountMapper and calling EndBlocker at the end of every block. Accounts with delegations or which are still vesting are kept and
+// their dormancy starts over.
+type AccountReaper struct {
+	am             AccountMapper
+	dk             DelegationKeeper
+	dormancyPeriod int64 // blocks an account must have been empty for before it is removed
+}
+
+// NewAccountReaper returns a reaper removing the accounts which have been
+// empty for the dormancy period in blocks, the mapper must queue them
+func NewAccountReaper(am AccountMapper, dk DelegationKeeper, dormancyPeriod int64) AccountReaper {
+	if dormancyPeriod <= 0 {
+		panic("the dormancy period of the account reaper must be positive")
+	}
+	if !am.emptyAccountQueue {
+		panic("the account reaper requires a mapper queueing empty accounts")
+	}
+	return AccountReaper{
+		am:             am,
+		dk:             dk,
+		dormancyPeriod: dormancyPeriod,
+	}
+}
+
+// EndBlocker removes the accounts which have been empty since the dormancy
+// period, the address of every removed account is tagged
+func (ar AccountReaper) EndBlocker(ctx sdk.Context) (tags sdk.Tags) {
+	logger := ctx.Logger().With("module", "x/auth")
+	tags = sdk.NewTags()
+
+	height := ctx.BlockHeight() - ar.dormancyPeriod
+	if height < 0 {
+		return tags
+	}
+
+	for _, addr := range ar.am.popEmptyAccountQueue(ctx, height) {
+		acc := ar.am.GetAccount(ctx, addr)
+		if acc == nil {
+			continue
+		}
+		if !ar.reapable(ctx, acc) {
+			// queue the account again from the current height
+			ar.am.SetAccount(ctx, acc)
+			continue
+		}
+		ar.am.RemoveAccount(ctx, acc)
+
+		tags = tags.AppendTag("reaped-account", []byte(addr.String()))
+		logger.Info(fmt.Sprintf("Account %s with account number %d reaped", addr, acc.GetAccountNumber()))
+	}
+	return tags
+}
+
+// whether an account holds nothing which would be lost by removing it
+func (ar AccountReaper) reapable(ctx sdk.Context, acc Account) bool {
+	if !acc.GetCoins().IsZero() {
+		return false
+	}
+	if vacc, ok := acc.(VestingAccount); ok && vacc.GetEndTime() > ctx.BlockHeader().Time {
+		return false
+	}
+	return !ar.dk.HasDelegations(ctx, acc.GetAddress())
+}
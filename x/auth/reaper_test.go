@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/abci/types"
+	"github.com/tendermint/tmlibs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	wire "github.com/cosmos/cosmos-sdk/wire"
+)
+
+type testDelegationKeeper map[string]bool
+
+func (dk testDelegationKeeper) HasDelegations(ctx sdk.Context, delegator sdk.Address) bool {
+	return dk[delegator.String()]
+}
+
+func TestAccountReaper(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := wire.NewCodec()
+	RegisterWire(cdc)
+	wire.RegisterCrypto(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1}, false, nil, log.NewNopLogger())
+	mapper := NewAccountMapper(cdc, capKey, &BaseAccount{})
+	dk := testDelegationKeeper{}
+	require.Panics(t, func() { NewAccountReaper(mapper, dk, 10) })
+	mapper = mapper.WithEmptyAccountQueue()
+	reaper := NewAccountReaper(mapper, dk, 10)
+
+	_, _, addrEmpty := keyPubAddr()
+	_, _, addrFunded := keyPubAddr()
+	_, _, addrDelegator := keyPubAddr()
+	_, _, addrVesting := keyPubAddr()
+	dk[addrDelegator.String()] = true
+
+	mapper.SetAccount(ctx, mapper.NewAccountWithAddress(ctx, addrEmpty))
+	mapper.SetAccount(ctx, mapper.NewAccountWithAddress(ctx, addrDelegator))
+	funded := mapper.NewAccountWithAddress(ctx, addrFunded)
+	funded.SetCoins(sdk.Coins{{"atom", 10}})
+	mapper.SetAccount(ctx, funded)
+	vesting := NewDelayedVestingAccount(NewBaseAccountWithAddress(addrVesting), 1000)
+	mapper.SetAccount(ctx, mapper.NewAccount(ctx, vesting))
+
+	// nothing is reaped before the dormancy period has passed
+	tags := reaper.EndBlocker(ctx.WithBlockHeight(10))
+	require.Empty(t, tags)
+
+	// activity restarts the dormancy of an account
+	acc := mapper.GetAccount(ctx, addrEmpty)
+	acc.SetSequence(1)
+	mapper.SetAccount(ctx.WithBlockHeight(5), acc)
+	tags = reaper.EndBlocker(ctx.WithBlockHeight(11))
+	require.Empty(t, tags)
+	require.NotNil(t, mapper.GetAccount(ctx, addrEmpty))
+
+	// only the dormant empty account without delegations nor vesting is reaped
+	tags = reaper.EndBlocker(ctx.WithBlockHeight(15))
+	require.Equal(t, sdk.NewTags("reaped-account", []byte(addrEmpty.String())), tags)
+	require.Nil(t, mapper.GetAccount(ctx, addrEmpty))
+	require.Equal(t, []int64{0}, mapper.GetRemovedAccountNumbers(ctx, addrEmpty))
+	require.NotNil(t, mapper.GetAccount(ctx, addrFunded))
+	require.NotNil(t, mapper.GetAccount(ctx, addrDelegator))
+	require.NotNil(t, mapper.GetAccount(ctx, addrVesting))
+
+	// accounts which were kept are checked again after another dormancy period
+	dk[addrDelegator.String()] = false
+	tags = reaper.EndBlocker(ctx.WithBlockHeight(20))
+	require.Empty(t, tags)
+	tags = reaper.EndBlocker(ctx.WithBlockHeight(21))
+	require.Equal(t, sdk.NewTags("reaped-account", []byte(addrDelegator.String())), tags)
+	require.NotNil(t, mapper.GetAccount(ctx, addrVesting))
+	require.Nil(t, AccountNumberInvariant(mapper)(ctx))
+
+	// mappers without the queue write nothing but the accounts
+	ms, capKey, _ = setupMultiStore()
+	ctx = sdk.NewContext(ms, abci.Header{Height: 1}, false, nil, log.NewNopLogger())
+	mapper = NewAccountMapper(cdc, capKey, &BaseAccount{})
+	mapper.SetAccount(ctx, mapper.NewAccountWithAddress(ctx, addrEmpty))
+	require.Empty(t, WriteGenesis(ctx, mapper, NewFeeCollectionKeeper(cdc, capKey)).EmptyAccounts)
+}
@@ -9,39 +9,70 @@ import (
 
 var _ sdk.Tx = (*StdTx)(nil)
 
-// StdTx is a standard way to wrap a Msg with Fee and Signatures.
-// NOTE: the first signature is the FeePayer (Signatures must not be nil).
+// MaxMemoCharacters is the maximum length of the memo of a StdTx
+const MaxMemoCharacters = 256
+
+// StdTx is a standard way to wrap Msgs with Fee and Signatures.
+// NOTE: the first signature is the FeePayer (Signatures must not be nil),
+// its fees are paid by the granter of the Fee when there is one.
 type StdTx struct {
-	Msg        sdk.Msg        `json:"msg"`
-	Fee        StdFee         `json:"fee"`
-	Signatures []StdSignature `json:"signatures"`
+	Msgs          []sdk.Msg      `json:"msgs"`
+	Fee           StdFee         `json:"fee"`
+	Signatures    []StdSignature `json:"signatures"`
+	Memo          string         `json:"memo"`           // arbitrary note, eg. to attribute a deposit
+	TimeoutHeight int64          `json:"timeout_height"` // last height at which the tx may be included, zero if it does not time out
 }
 
-func NewStdTx(msg sdk.Msg, fee StdFee, sigs []StdSignature) StdTx {
+func NewStdTx(msgs []sdk.Msg, fee StdFee, sigs []StdSignature, memo string, timeoutHeight int64) StdTx {
 	return StdTx{
-		Msg:        msg,
-		Fee:        fee,
-		Signatures: sigs,
+		Msgs:          msgs,
+		Fee:           fee,
+		Signatures:    sigs,
+		Memo:          memo,
+		TimeoutHeight: timeoutHeight,
 	}
 }
 
 //nolint
-func (tx StdTx) GetMsg() sdk.Msg { return tx.Msg }
+func (tx StdTx) GetMsgs() []sdk.Msg { return tx.Msgs }
+
+// GetSigners returns the addresses that must sign the transaction.
+// Addresses are returned in the order they first appear in the signers
+// of the Msgs, an address signing several Msgs only signs once.
+func (tx StdTx) GetSigners() []sdk.Address {
+	return GetTxSigners(tx)
+}
 
-// Signatures returns the signature of signers who signed the Msg.
+// Signatures returns the signature of signers who signed the Msgs.
 // CONTRACT: Length returned is same as length of
-// pubkeys returned from MsgKeySigners, and the order
+// addresses returned from GetSigners, and the order
 // matches.
 // CONTRACT: If the signature is missing (ie the Msg is
 // invalid), then the corresponding signature is
 // .Empty().
 func (tx StdTx) GetSignatures() []StdSignature { return tx.Signatures }
 
+// GetTxSigners returns the deduplicated signers of all the Msgs of a
+// transaction, in the order they first appear.
+func GetTxSigners(tx sdk.Tx) []sdk.Address {
+	seen := make(map[string]bool)
+	var signers []sdk.Address
+	for _, msg := range tx.GetMsgs() {
+		for _, addr := range msg.GetSigners() {
+			if !seen[string(addr)] {
+				seen[string(addr)] = true
+				signers = append(signers, addr)
+			}
+		}
+	}
+	return signers
+}
+
 // FeePayer returns the address responsible for paying the fees
-// for the transactions. It's the first address returned by msg.GetSigners().
-// If GetSigners() is empty, this panics.
+// for the transactions. It's the first signer of the transaction.
+// If there are no signers, this panics.
 func FeePayer(tx sdk.Tx) sdk.Address {
-	return tx.GetMsg().GetSigners()[0]
+	return GetTxSigners(tx)[0]
 }
 
 //__________________________________________________________
@@ -49,9 +80,12 @@ func FeePayer(tx sdk.Tx) sdk.Address {
 // StdFee includes the amount of coins paid in fees and the maximum
 // gas to be used by the transaction. The ratio yields an effective "gasprice",
 // which must be above some miminum to be accepted into the mempool.
+// The amount is paid by the granter instead of the fee payer when set,
+// within the fee allowance it gave to the fee payer.
 type StdFee struct {
-	Amount sdk.Coins `json:"amount"`
-	Gas    int64     `json:"gas"`
+	Amount  sdk.Coins   `json:"amount"`
+	Gas     int64       `json:"gas"`
+	Granter sdk.Address `json:"granter,omitempty"`
 }
 
 func NewStdFee(gas int64, amount ...sdk.Coin) StdFee {
@@ -77,31 +111,48 @@ func (fee StdFee) Bytes() []byte {
 	return bz
 }
 
+// WithGranter returns the fee paid by the given granter
+func (fee StdFee) WithGranter(granter sdk.Address) StdFee {
+	fee.Granter = granter
+	return fee
+}
+
 //__________________________________________________________
 
 // StdSignDoc is replay-prevention structure.
-// It includes the result of msg.GetSignBytes(),
+// It includes the result of msg.GetSignBytes() for each Msg,
 // as well as the ChainID (prevent cross chain replay)
 // and the Sequence numbers for each signature (prevent
 // inchain replay and enforce tx ordering per account).
+// The memo and timeout height of the tx are signed too.
 type StdSignDoc struct {
-	ChainID        string  `json:"chain_id"`
-	AccountNumbers []int64 `json:"account_numbers"`
-	Sequences      []int64 `json:"sequences"`
-	FeeBytes       []byte  `json:"fee_bytes"`
-	MsgBytes       []byte  `json:"msg_bytes"`
-	AltBytes       []byte  `json:"alt_bytes"`
+	ChainID        string   `json:"chain_id"`
+	AccountNumbers []int64  `json:"account_numbers"`
+	Sequences      []int64  `json:"sequences"`
+	FeeBytes       []byte   `json:"fee_bytes"`
+	MsgsBytes      [][]byte `json:"msgs_bytes"`
+	AltBytes       []byte   `json:"alt_bytes"`
+	Memo           string   `json:"memo"`
+	TimeoutHeight  int64    `json:"timeout_height"`
 }
 
 // StdSignBytes returns the bytes to sign for a transaction.
 // TODO: change the API to just take a chainID and StdTx ?
-func StdSignBytes(chainID string, accnums []int64, sequences []int64, fee StdFee, msg sdk.Msg) []byte {
+func StdSignBytes(chainID string, accnums []int64, sequences []int64, fee StdFee, msgs []sdk.Msg,
+	memo string, timeoutHeight int64) []byte {
+
+	msgsBytes := make([][]byte, len(msgs))
+	for i, msg := range msgs {
+		msgsBytes[i] = msg.GetSignBytes()
+	}
 	bz, err := json.Marshal(StdSignDoc{
 		ChainID:        chainID,
 		AccountNumbers: accnums,
 		Sequences:      sequences,
 		FeeBytes:       fee.Bytes(),
-		MsgBytes:       msg.GetSignBytes(),
+		MsgsBytes:      msgsBytes,
+		Memo:           memo,
+		TimeoutHeight:  timeoutHeight,
 	})
 	if err != nil {
 		panic(err)
@@ -110,20 +161,22 @@ func StdSignBytes(chainID string, accnums []int64, sequences []int64, fee StdFee
 }
 
 // StdSignMsg is a convenience structure for passing along
-// a Msg with the other requirements for a StdSignDoc before
+// Msgs with the other requirements for a StdSignDoc before
 // it is signed. For use in the CLI.
 type StdSignMsg struct {
 	ChainID        string
 	AccountNumbers []int64
 	Sequences      []int64
 	Fee            StdFee
-	Msg            sdk.Msg
+	Msgs           []sdk.Msg
+	Memo           string
+	TimeoutHeight  int64
 	// XXX: Alt
 }
 
 // get message bytes
 func (msg StdSignMsg) Bytes() []byte {
-	return StdSignBytes(msg.ChainID, msg.AccountNumbers, msg.Sequences, msg.Fee, msg.Msg)
+	return StdSignBytes(msg.ChainID, msg.AccountNumbers, msg.Sequences, msg.Fee, msg.Msgs, msg.Memo, msg.TimeoutHeight)
 }
 
 // Standard Signature
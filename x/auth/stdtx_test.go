@@ -23,10 +23,27 @@ func TestStdTx(t *testing.T) {
 	fee := newStdFee()
 	sigs := []StdSignature{}
 
-	tx := NewStdTx(msg, fee, sigs)
-	assert.Equal(t, msg, tx.GetMsg())
+	tx := NewStdTx([]sdk.Msg{msg}, fee, sigs, "memo", 10)
+	assert.Equal(t, []sdk.Msg{msg}, tx.GetMsgs())
 	assert.Equal(t, sigs, tx.GetSignatures())
+	assert.Equal(t, "memo", tx.Memo)
+	assert.Equal(t, int64(10), tx.TimeoutHeight)
 
 	feePayer := FeePayer(tx)
 	assert.Equal(t, addr, feePayer)
 }
+
+func TestStdTxSigners(t *testing.T) {
+	addr1 := crypto.GenPrivKeyEd25519().PubKey().Address()
+	addr2 := crypto.GenPrivKeyEd25519().PubKey().Address()
+	addr3 := crypto.GenPrivKeyEd25519().PubKey().Address()
+	msgs := []sdk.Msg{
+		sdk.NewTestMsg(addr1, addr2),
+		sdk.NewTestMsg(addr3, addr1),
+	}
+
+	// signers are deduplicated in order of first appearance
+	tx := NewStdTx(msgs, newStdFee(), nil, "", 0)
+	assert.Equal(t, []sdk.Address{addr1, addr2, addr3}, tx.GetSigners())
+	assert.Equal(t, addr1, FeePayer(tx))
+}
@@ -0,0 +1,241 @@
+package auth
+
+import (
+	"errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// VestingAccount is an account whose original coins are locked until they
+// vest. Locked coins cannot be spent but may be delegated, the coins
+// delegated while vesting are tracked so that they are locked again once
+// they are undelegated.
+type VestingAccount interface {
+	Account
+
+	// coins which may be spent at the given block time
+	SpendableCoins(blockTime int64) sdk.Coins
+
+	// coins which have not vested yet at the given block time
+	GetVestingCoins(blockTime int64) sdk.Coins
+
+	// record the delegation or undelegation of coins, the coins of the
+	// account must be updated separately
+	TrackDelegation(blockTime int64, amount sdk.Coins)
+	TrackUndelegation(amount sdk.Coins)
+
+	GetOriginalVesting() sdk.Coins
+	GetDelegatedFree() sdk.Coins
+	GetDelegatedVesting() sdk.Coins
+	GetStartTime() int64
+	GetEndTime() int64
+}
+
+// SpendableCoins returns the coins of an account which may be spent at the
+// given block time, all coins of an account which does not vest
+func SpendableCoins(acc Account, blockTime int64) sdk.Coins {
+	vacc, ok := acc.(VestingAccount)
+	if !ok {
+		return acc.GetCoins()
+	}
+	return vacc.SpendableCoins(blockTime)
+}
+
+//-----------------------------------------------------------
+// BaseVestingAccount
+
+// BaseVestingAccount - common fields and logic of the vesting accounts,
+// which only differ in how their coins vest over time.
+type BaseVestingAccount struct {
+	BaseAccount
+
+	OriginalVesting  sdk.Coins `json:"original_vesting"`  // coins locked at the creation of the account
+	DelegatedFree    sdk.Coins `json:"delegated_free"`    // delegated coins which had vested when delegated
+	DelegatedVesting sdk.Coins `json:"delegated_vesting"` // delegated coins which were vesting when delegated
+	EndTime          int64     `json:"end_time"`          // time at which all coins have vested
+}
+
+// coins which may be spent given the coins which are still vesting, the
+// vesting coins which are delegated are no longer held by the account
+func (bva BaseVestingAccount) spendableCoins(vestingCoins sdk.Coins) sdk.Coins {
+	var spendable sdk.Coins
+	for _, coin := range bva.Coins {
+		locked := vestingCoins.AmountOf(coin.Denom) - bva.DelegatedVesting.AmountOf(coin.Denom)
+		if locked < 0 {
+			locked = 0
+		}
+		amount := coin.Amount - locked
+		if amount > 0 {
+			spendable = append(spendable, sdk.Coin{coin.Denom, amount})
+		}
+	}
+	return spendable
+}
+
+// delegated coins are taken from the vesting coins first
+func (bva *BaseVestingAccount) trackDelegation(vestingCoins, amount sdk.Coins) {
+	for _, coin := range amount {
+		vesting := vestingCoins.AmountOf(coin.Denom) - bva.DelegatedVesting.AmountOf(coin.Denom)
+		if vesting < 0 {
+			vesting = 0
+		}
+		if vesting > coin.Amount {
+			vesting = coin.Amount
+		}
+		free := coin.Amount - vesting
+		if vesting > 0 {
+			bva.DelegatedVesting = bva.DelegatedVesting.Plus(sdk.Coins{{coin.Denom, vesting}})
+		}
+		if free > 0 {
+			bva.DelegatedFree = bva.DelegatedFree.Plus(sdk.Coins{{coin.Denom, free}})
+		}
+	}
+}
+
+// Implements VestingAccount, undelegated coins are returned to the free
+// coins first so that coins which vested while delegated are spendable again
+func (bva *BaseVestingAccount) TrackUndelegation(amount sdk.Coins) {
+	for _, coin := range amount {
+		free := bva.DelegatedFree.AmountOf(coin.Denom)
+		if free > coin.Amount {
+			free = coin.Amount
+		}
+		vesting := bva.DelegatedVesting.AmountOf(coin.Denom)
+		if vesting > coin.Amount-free {
+			vesting = coin.Amount - free
+		}
+		if free > 0 {
+			bva.DelegatedFree = bva.DelegatedFree.Minus(sdk.Coins{{coin.Denom, free}})
+		}
+		if vesting > 0 {
+			bva.DelegatedVesting = bva.DelegatedVesting.Minus(sdk.Coins{{coin.Denom, vesting}})
+		}
+	}
+}
+
+// Implements VestingAccount
+func (bva BaseVestingAccount) GetOriginalVesting() sdk.Coins {
+	return bva.OriginalVesting
+}
+
+// Implements VestingAccount
+func (bva BaseVestingAccount) GetDelegatedFree() sdk.Coins {
+	return bva.DelegatedFree
+}
+
+// Implements VestingAccount
+func (bva BaseVestingAccount) GetDelegatedVesting() sdk.Coins {
+	return bva.DelegatedVesting
+}
+
+// Implements VestingAccount
+func (bva BaseVestingAccount) GetEndTime() int64 {
+	return bva.EndTime
+}
+
+//-----------------------------------------------------------
+// ContinuousVestingAccount
+
+var _ VestingAccount = (*ContinuousVestingAccount)(nil)
+
+// ContinuousVestingAccount - vesting account whose coins vest linearly
+// between its start and end time.
+type ContinuousVestingAccount struct {
+	BaseVestingAccount
+
+	StartTime int64 `json:"start_time"` // time at which the coins start to vest
+}
+
+// NewContinuousVestingAccount returns an account whose coins all vest
+// linearly between the start and end time
+func NewContinuousVestingAccount(acc BaseAccount, startTime, endTime int64) (*ContinuousVestingAccount, error) {
+	if startTime >= endTime {
+		return nil, errors.New("vesting must start before it ends")
+	}
+	return &ContinuousVestingAccount{
+		BaseVestingAccount: BaseVestingAccount{
+			BaseAccount:     acc,
+			OriginalVesting: acc.Coins,
+			EndTime:         endTime,
+		},
+		StartTime: startTime,
+	}, nil
+}
+
+// Implements VestingAccount
+func (cva ContinuousVestingAccount) GetVestingCoins(blockTime int64) sdk.Coins {
+	if blockTime <= cva.StartTime {
+		return cva.OriginalVesting
+	}
+	if blockTime >= cva.EndTime {
+		return nil
+	}
+	var vesting sdk.Coins
+	for _, coin := range cva.OriginalVesting {
+		vested := coin.Amount * (blockTime - cva.StartTime) / (cva.EndTime - cva.StartTime)
+		if coin.Amount-vested > 0 {
+			vesting = append(vesting, sdk.Coin{coin.Denom, coin.Amount - vested})
+		}
+	}
+	return vesting
+}
+
+// Implements VestingAccount
+func (cva ContinuousVestingAccount) SpendableCoins(blockTime int64) sdk.Coins {
+	return cva.spendableCoins(cva.GetVestingCoins(blockTime))
+}
+
+// Implements VestingAccount
+func (cva *ContinuousVestingAccount) TrackDelegation(blockTime int64, amount sdk.Coins) {
+	cva.trackDelegation(cva.GetVestingCoins(blockTime), amount)
+}
+
+// Implements VestingAccount
+func (cva ContinuousVestingAccount) GetStartTime() int64 {
+	return cva.StartTime
+}
+
+//-----------------------------------------------------------
+// DelayedVestingAccount
+
+var _ VestingAccount = (*DelayedVestingAccount)(nil)
+
+// DelayedVestingAccount - vesting account whose coins all vest at once at
+// its end time.
+type DelayedVestingAccount struct {
+	BaseVestingAccount
+}
+
+// NewDelayedVestingAccount returns an account whose coins all vest at the end time
+func NewDelayedVestingAccount(acc BaseAccount, endTime int64) *DelayedVestingAccount {
+	return &DelayedVestingAccount{
+		BaseVestingAccount: BaseVestingAccount{
+			BaseAccount:     acc,
+			OriginalVesting: acc.Coins,
+			EndTime:         endTime,
+		},
+	}
+}
+
+// Implements VestingAccount
+func (dva DelayedVestingAccount) GetVestingCoins(blockTime int64) sdk.Coins {
+	if blockTime >= dva.EndTime {
+		return nil
+	}
+	return dva.OriginalVesting
+}
+
+// Implements VestingAccount
+func (dva DelayedVestingAccount) SpendableCoins(blockTime int64) sdk.Coins {
+	return dva.spendableCoins(dva.GetVestingCoins(blockTime))
+}
+
+// Implements VestingAccount
+func (dva *DelayedVestingAccount) TrackDelegation(blockTime int64, amount sdk.Coins) {
+	dva.trackDelegation(dva.GetVestingCoins(blockTime), amount)
+}
+
+// Implements VestingAccount, the coins vest all at once
+func (dva DelayedVestingAccount) GetStartTime() int64 {
+	return 0
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func newVestingBaseAccount() BaseAccount {
+	_, _, addr := keyPubAddr()
+	acc := NewBaseAccountWithAddress(addr)
+	acc.Coins = sdk.Coins{{"fee", 1000}, {"steak", 100}}
+	return acc
+}
+
+func TestContinuousVestingAccount(t *testing.T) {
+	_, err := NewContinuousVestingAccount(newVestingBaseAccount(), 200, 100)
+	require.NotNil(t, err)
+
+	cva, err := NewContinuousVestingAccount(newVestingBaseAccount(), 100, 200)
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{{"fee", 1000}, {"steak", 100}}, cva.GetOriginalVesting())
+
+	// nothing vests before the start time
+	require.Equal(t, cva.OriginalVesting, cva.GetVestingCoins(50))
+	require.Nil(t, cva.SpendableCoins(100))
+
+	// coins vest linearly until the end time
+	require.Equal(t, sdk.Coins{{"fee", 500}, {"steak", 50}}, cva.GetVestingCoins(150))
+	require.Equal(t, sdk.Coins{{"fee", 750}, {"steak", 75}}, cva.SpendableCoins(175))
+	require.Nil(t, cva.GetVestingCoins(200))
+	require.Equal(t, cva.Coins, cva.SpendableCoins(250))
+
+	// received coins are spendable straight away
+	cva.SetCoins(cva.Coins.Plus(sdk.Coins{{"steak", 10}}))
+	require.Equal(t, sdk.Coins{{"steak", 10}}, cva.SpendableCoins(100))
+}
+
+func TestDelayedVestingAccount(t *testing.T) {
+	dva := NewDelayedVestingAccount(newVestingBaseAccount(), 200)
+	require.Equal(t, int64(0), dva.GetStartTime())
+
+	// all coins vest at once at the end time
+	require.Equal(t, dva.OriginalVesting, dva.GetVestingCoins(199))
+	require.Nil(t, dva.SpendableCoins(199))
+	require.Nil(t, dva.GetVestingCoins(200))
+	require.Equal(t, dva.Coins, dva.SpendableCoins(200))
+}
+
+func TestVestingAccountTrackDelegation(t *testing.T) {
+	cva, err := NewContinuousVestingAccount(newVestingBaseAccount(), 100, 200)
+	require.Nil(t, err)
+
+	// half of the coins have vested, delegations are taken from the vesting coins first
+	cva.TrackDelegation(150, sdk.Coins{{"steak", 30}})
+	cva.SetCoins(cva.Coins.Minus(sdk.Coins{{"steak", 30}}))
+	require.Equal(t, sdk.Coins{{"steak", 30}}, cva.GetDelegatedVesting())
+	require.Nil(t, cva.GetDelegatedFree())
+	require.Equal(t, int64(50), cva.SpendableCoins(150).AmountOf("steak"))
+
+	cva.TrackDelegation(150, sdk.Coins{{"steak", 40}})
+	cva.SetCoins(cva.Coins.Minus(sdk.Coins{{"steak", 40}}))
+	require.Equal(t, sdk.Coins{{"steak", 50}}, cva.GetDelegatedVesting())
+	require.Equal(t, sdk.Coins{{"steak", 20}}, cva.GetDelegatedFree())
+	require.Equal(t, int64(30), cva.SpendableCoins(150).AmountOf("steak"))
+
+	// undelegations are returned to the free coins first
+	cva.TrackUndelegation(sdk.Coins{{"steak", 30}})
+	cva.SetCoins(cva.Coins.Plus(sdk.Coins{{"steak", 30}}))
+	require.Nil(t, cva.GetDelegatedFree())
+	require.Equal(t, sdk.Coins{{"steak", 40}}, cva.GetDelegatedVesting())
+	require.Equal(t, int64(50), cva.SpendableCoins(150).AmountOf("steak"))
+
+	// the delegated coins no longer lock anything once vested
+	require.Equal(t, int64(60), cva.SpendableCoins(200).AmountOf("steak"))
+}
+
+func TestDeductFeesVestingAccount(t *testing.T) {
+	dva := NewDelayedVestingAccount(newVestingBaseAccount(), 200)
+	fee := NewStdFee(100, sdk.Coin{"fee", 10})
+
+	// vesting coins cannot pay fees
+	_, res := deductFees(dva, fee, 100)
+	assert.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInsufficientFunds), res.Code)
+
+	acc, res := deductFees(dva, fee, 200)
+	require.True(t, res.IsOK())
+	assert.Equal(t, int64(990), acc.GetCoins().AmountOf("fee"))
+}
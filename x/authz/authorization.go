@@ -0,0 +1,206 @@
+package authz
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+// MsgName returns the name identifying the Msgs of the type of msg, made of
+// its route and type name, eg. "bank/MsgSend"
+func MsgName(msg sdk.Msg) string {
+	t := reflect.TypeOf(msg)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return msg.Type() + "/" + t.Name()
+}
+
+// Authorization allows a grantee to execute the Msgs of one type on behalf
+// of a granter
+type Authorization interface {
+	// name of the Msgs which may be executed
+	MsgName() string
+
+	// check that the authorization holds
+	ValidateBasic() sdk.Error
+
+	// Accept checks that a Msg signed by the granter may be executed and
+	// returns the authorization left once it is, nil if it is used up
+	Accept(granter sdk.Address, msg sdk.Msg) (remaining Authorization, err sdk.Error)
+}
+
+// Grant - authorization of a granter to a grantee
+type Grant struct {
+	Granter       sdk.Address   `json:"granter"`
+	Grantee       sdk.Address   `json:"grantee"`
+	Authorization Authorization `json:"authorization"`
+	Expiration    int64         `json:"expiration"` // block time from which the grant may no longer be used, zero if it does not expire
+}
+
+func NewGrant(granter, grantee sdk.Address, authorization Authorization, expiration int64) Grant {
+	return Grant{
+		Granter:       granter,
+		Grantee:       grantee,
+		Authorization: authorization,
+		Expiration:    expiration,
+	}
+}
+
+// IsExpired returns whether the grant may no longer be used at the given
+// block time
+func (g Grant) IsExpired(blockTime int64) bool {
+	return g.Expiration != 0 && blockTime >= g.Expiration
+}
+
+//______________________________________________________________________
+
+var _ Authorization = GenericAuthorization{}
+
+// GenericAuthorization - authorization to execute any Msg of a type
+type GenericAuthorization struct {
+	Msg string `json:"msg"` // name of the Msgs, see MsgName
+}
+
+func NewGenericAuthorization(msgName string) GenericAuthorization {
+	return GenericAuthorization{
+		Msg: msgName,
+	}
+}
+
+// Implements Authorization
+func (a GenericAuthorization) MsgName() string { return a.Msg }
+
+// Implements Authorization
+func (a GenericAuthorization) ValidateBasic() sdk.Error {
+	if len(a.Msg) == 0 {
+		return ErrInvalidAuthorization(DefaultCodespace, "msg name cannot be empty")
+	}
+	return nil
+}
+
+// Implements Authorization
+func (a GenericAuthorization) Accept(granter sdk.Address, msg sdk.Msg) (Authorization, sdk.Error) {
+	return a, nil
+}
+
+//______________________________________________________________________
+
+var _ Authorization = SendAuthorization{}
+
+// SendAuthorization - authorization to send coins of the granter with
+// bank.MsgSend, up to a spend limit
+type SendAuthorization struct {
+	SpendLimit sdk.Coins `json:"spend_limit"` // coins which may still be sent, must be strictly positive
+}
+
+func NewSendAuthorization(spendLimit sdk.Coins) SendAuthorization {
+	return SendAuthorization{
+		SpendLimit: spendLimit,
+	}
+}
+
+// Implements Authorization
+func (a SendAuthorization) MsgName() string { return MsgName(bank.MsgSend{}) }
+
+// Implements Authorization
+func (a SendAuthorization) ValidateBasic() sdk.Error {
+	if !a.SpendLimit.IsValid() || !a.SpendLimit.IsPositive() {
+		return ErrInvalidAuthorization(DefaultCodespace, fmt.Sprintf("spend limit %v must be positive", a.SpendLimit))
+	}
+	return nil
+}
+
+// Implements Authorization, the coins sent by the inputs of the granter are
+// taken from the spend limit
+func (a SendAuthorization) Accept(granter sdk.Address, msg sdk.Msg) (Authorization, sdk.Error) {
+	send, ok := msg.(bank.MsgSend)
+	if !ok {
+		return nil, ErrUnauthorizedMsg(DefaultCodespace, fmt.Sprintf("expected %s, got %s", a.MsgName(), MsgName(msg)))
+	}
+	var sent sdk.Coins
+	for _, in := range send.Inputs {
+		if bytes.Equal(in.Address, granter) {
+			sent = sent.Plus(in.Coins)
+		}
+	}
+	remaining := a.SpendLimit.Minus(sent)
+	if !remaining.IsNotNegative() {
+		return nil, ErrUnauthorizedMsg(DefaultCodespace, fmt.Sprintf("sent coins %v exceed the spend limit %v", sent, a.SpendLimit))
+	}
+	if remaining.IsZero() {
+		return nil, nil
+	}
+	return NewSendAuthorization(remaining), nil
+}
+
+//______________________________________________________________________
+
+var _ Authorization = DelegateAuthorization{}
+
+// DelegateAuthorization - authorization to delegate the coins of the
+// granter with stake.MsgDelegate, optionally only to some validators and
+// up to a maximum amount
+type DelegateAuthorization struct {
+	AllowedValidators []sdk.Address `json:"allowed_validators"` // validators which may be delegated to, any if empty
+	MaxTokens         sdk.Coins     `json:"max_tokens"`         // coins which may still be delegated, no limit if empty
+}
+
+func NewDelegateAuthorization(allowedValidators []sdk.Address, maxTokens sdk.Coins) DelegateAuthorization {
+	return DelegateAuthorization{
+		AllowedValidators: allowedValidators,
+		MaxTokens:         maxTokens,
+	}
+}
+
+// Implements Authorization
+func (a DelegateAuthorization) MsgName() string { return MsgName(stake.MsgDelegate{}) }
+
+// Implements Authorization
+func (a DelegateAuthorization) ValidateBasic() sdk.Error {
+	for _, validator := range a.AllowedValidators {
+		if validator == nil {
+			return ErrInvalidAuthorization(DefaultCodespace, "allowed validators cannot be nil")
+		}
+	}
+	if len(a.MaxTokens) != 0 && (!a.MaxTokens.IsValid() || !a.MaxTokens.IsPositive()) {
+		return ErrInvalidAuthorization(DefaultCodespace, fmt.Sprintf("max tokens %v must be positive", a.MaxTokens))
+	}
+	return nil
+}
+
+// Implements Authorization, the delegated coins are taken from the maximum
+// amount when there is one
+func (a DelegateAuthorization) Accept(granter sdk.Address, msg sdk.Msg) (Authorization, sdk.Error) {
+	delegate, ok := msg.(stake.MsgDelegate)
+	if !ok {
+		return nil, ErrUnauthorizedMsg(DefaultCodespace, fmt.Sprintf("expected %s, got %s", a.MsgName(), MsgName(msg)))
+	}
+	if len(a.AllowedValidators) != 0 {
+		allowed := false
+		for _, validator := range a.AllowedValidators {
+			if bytes.Equal(validator, delegate.ValidatorAddr) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, ErrUnauthorizedMsg(DefaultCodespace, fmt.Sprintf("validator %v is not allowed", delegate.ValidatorAddr))
+		}
+	}
+	if len(a.MaxTokens) == 0 {
+		return a, nil
+	}
+	remaining := a.MaxTokens.Minus(sdk.Coins{delegate.Bond})
+	if !remaining.IsNotNegative() {
+		return nil, ErrUnauthorizedMsg(DefaultCodespace, fmt.Sprintf("delegation %v exceeds the max tokens %v", delegate.Bond, a.MaxTokens))
+	}
+	if remaining.IsZero() {
+		return nil, nil
+	}
+	return NewDelegateAuthorization(a.AllowedValidators, remaining), nil
+}
@@ -0,0 +1,65 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestMsgName(t *testing.T) {
+	require.Equal(t, "bank/MsgSend", MsgName(bank.MsgSend{}))
+	require.Equal(t, "stake/MsgDelegate", MsgName(stake.MsgDelegate{}))
+	require.Equal(t, "TestMsg/TestMsg", MsgName(sdk.NewTestMsg()))
+}
+
+func TestSendAuthorization(t *testing.T) {
+	authorization := NewSendAuthorization(sdk.Coins{{"steak", 100}})
+	send := func(from sdk.Address, amount int64) bank.MsgSend {
+		coins := sdk.Coins{{"steak", amount}}
+		return bank.NewMsgSend([]bank.Input{bank.NewInput(from, coins)}, []bank.Output{bank.NewOutput(addrs[2], coins)})
+	}
+
+	remaining, err := authorization.Accept(addrs[0], send(addrs[0], 60))
+	require.Nil(t, err)
+	require.Equal(t, NewSendAuthorization(sdk.Coins{{"steak", 40}}), remaining)
+
+	// only the coins sent by the granter count
+	remaining, err = authorization.Accept(addrs[0], send(addrs[1], 60))
+	require.Nil(t, err)
+	require.Equal(t, authorization, remaining)
+
+	_, err = authorization.Accept(addrs[0], send(addrs[0], 101))
+	require.Equal(t, CodeUnauthorizedMsg, err.Code())
+	_, err = authorization.Accept(addrs[0], stake.NewMsgDelegate(addrs[0], addrs[1], sdk.Coin{"steak", 10}))
+	require.Equal(t, CodeUnauthorizedMsg, err.Code())
+
+	remaining, err = authorization.Accept(addrs[0], send(addrs[0], 100))
+	require.Nil(t, err)
+	require.Nil(t, remaining)
+}
+
+func TestDelegateAuthorization(t *testing.T) {
+	delegate := func(validator sdk.Address, amount int64) stake.MsgDelegate {
+		return stake.NewMsgDelegate(addrs[0], validator, sdk.Coin{"steak", amount})
+	}
+
+	// any validator, no limit
+	authorization := NewDelegateAuthorization(nil, nil)
+	remaining, err := authorization.Accept(addrs[0], delegate(addrs[1], 1000))
+	require.Nil(t, err)
+	require.Equal(t, authorization, remaining)
+
+	// allowed validators up to a maximum amount
+	authorization = NewDelegateAuthorization([]sdk.Address{addrs[1]}, sdk.Coins{{"steak", 100}})
+	_, err = authorization.Accept(addrs[0], delegate(addrs[2], 10))
+	require.Equal(t, CodeUnauthorizedMsg, err.Code())
+	_, err = authorization.Accept(addrs[0], delegate(addrs[1], 101))
+	require.Equal(t, CodeUnauthorizedMsg, err.Code())
+	remaining, err = authorization.Accept(addrs[0], delegate(addrs[1], 30))
+	require.Nil(t, err)
+	require.Equal(t, NewDelegateAuthorization([]sdk.Address{addrs[1]}, sdk.Coins{{"steak", 70}}), remaining)
+}
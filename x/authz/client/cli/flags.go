@@ -0,0 +1,11 @@
+package cli
+
+// nolint
+const (
+	FlagGrantee    = "grantee"
+	FlagGranter    = "granter"
+	FlagMsgName    = "msg-name"
+	FlagSpendLimit = "spend-limit"
+	FlagValidators = "validators"
+	FlagExpiration = "expiration"
+)
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+// get the command to query the grant of a granter to a grantee for a type of msgs
+func GetCmdQueryGrant(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant",
+		Short: "Query the grant of a granter to a grantee for a type of msgs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			granter, err := sdk.GetAccAddressBech32(viper.GetString(FlagGranter))
+			if err != nil {
+				return err
+			}
+			grantee, err := sdk.GetAccAddressBech32(viper.GetString(FlagGrantee))
+			if err != nil {
+				return err
+			}
+			msgName := viper.GetString(FlagMsgName)
+			key := authz.GetGrantKey(granter, grantee, msgName)
+			ctx := context.NewCoreContextFromViper()
+			res, err := ctx.Query(key, storeName)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("no grant of %s found from %s to %s", msgName, viper.GetString(FlagGranter), viper.GetString(FlagGrantee))
+			}
+			var grant authz.Grant
+			cdc.MustUnmarshalBinary(res, &grant)
+
+			output, err := wire.MarshalJSONIndent(cdc, grant)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(FlagGranter, "", "bech32 address of the granter")
+	cmd.Flags().String(FlagGrantee, "", "bech32 address of the grantee")
+	cmd.Flags().String(FlagMsgName, "", "name of the msgs, eg. bank/MsgSend")
+	return cmd
+}
+
+// get the command to query all grants of a granter to a grantee
+func GetCmdQueryGrants(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grants",
+		Short: "Query all grants of a granter to a grantee",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			granter, err := sdk.GetAccAddressBech32(viper.GetString(FlagGranter))
+			if err != nil {
+				return err
+			}
+			grantee, err := sdk.GetAccAddressBech32(viper.GetString(FlagGrantee))
+			if err != nil {
+				return err
+			}
+			key := authz.GetGrantsKey(granter, grantee)
+			ctx := context.NewCoreContextFromViper()
+			resKVs, err := ctx.QuerySubspace(cdc, key, storeName)
+			if err != nil {
+				return err
+			}
+
+			// parse out the grants
+			var grants []authz.Grant
+			for _, KV := range resKVs {
+				var grant authz.Grant
+				cdc.MustUnmarshalBinary(KV.Value, &grant)
+				grants = append(grants, grant)
+			}
+
+			output, err := wire.MarshalJSONIndent(cdc, grants)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(FlagGranter, "", "bech32 address of the granter")
+	cmd.Flags().String(FlagGrantee, "", "bech32 address of the grantee")
+	return cmd
+}
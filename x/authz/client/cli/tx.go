@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+// create grant authorization command
+func GetCmdGrantAuthorization(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant",
+		Short: "authorize a grantee to execute msgs on behalf of your account",
+		Long: `Authorize a grantee to execute the msgs named by --msg-name, eg. bank/MsgSend.
+A --spend-limit restricts the coins sent with bank/MsgSend or delegated with
+stake/MsgDelegate, --validators restricts the validators delegated to.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			granter, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+			grantee, err := sdk.GetAccAddressBech32(viper.GetString(FlagGrantee))
+			if err != nil {
+				return err
+			}
+			authorization, err := buildAuthorization()
+			if err != nil {
+				return err
+			}
+
+			msg := authz.NewMsgGrantAuthorization(granter, grantee, authorization, viper.GetInt64(FlagExpiration))
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().String(FlagGrantee, "", "bech32 address of the account executing the msgs")
+	cmd.Flags().String(FlagMsgName, "", "name of the msgs which may be executed, eg. bank/MsgSend")
+	cmd.Flags().String(FlagSpendLimit, "", "coins which may be sent or delegated")
+	cmd.Flags().String(FlagValidators, "", "comma separated bech32 addresses of the validators which may be delegated to")
+	cmd.Flags().Int64(FlagExpiration, 0, "block time from which the grant expires, omit for no expiration")
+	return cmd
+}
+
+// authorization built from the flags of the grant command
+func buildAuthorization() (authz.Authorization, error) {
+	msgName := viper.GetString(FlagMsgName)
+	spendLimit, err := sdk.ParseCoins(viper.GetString(FlagSpendLimit))
+	if err != nil {
+		return nil, err
+	}
+	var validators []sdk.Address
+	for _, bech := range strings.Split(viper.GetString(FlagValidators), ",") {
+		if len(bech) == 0 {
+			continue
+		}
+		validator, err := sdk.GetValAddressBech32(bech)
+		if err != nil {
+			return nil, err
+		}
+		validators = append(validators, validator)
+	}
+
+	switch {
+	case msgName == authz.MsgName(bank.MsgSend{}) && len(spendLimit) != 0:
+		return authz.NewSendAuthorization(spendLimit), nil
+	case msgName == authz.MsgName(stake.MsgDelegate{}) && (len(spendLimit) != 0 || len(validators) != 0):
+		return authz.NewDelegateAuthorization(validators, spendLimit), nil
+	case len(spendLimit) != 0 || len(validators) != 0:
+		return nil, errors.Errorf("%s cannot be restricted by --%s or --%s", msgName, FlagSpendLimit, FlagValidators)
+	default:
+		return authz.NewGenericAuthorization(msgName), nil
+	}
+}
+
+// create revoke authorization command
+func GetCmdRevokeAuthorization(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke",
+		Short: "revoke the authorization of a grantee to execute msgs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			granter, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+			grantee, err := sdk.GetAccAddressBech32(viper.GetString(FlagGrantee))
+			if err != nil {
+				return err
+			}
+
+			msg := authz.NewMsgRevokeAuthorization(granter, grantee, viper.GetString(FlagMsgName))
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().String(FlagGrantee, "", "bech32 address of the account executing the msgs")
+	cmd.Flags().String(FlagMsgName, "", "name of the msgs which may no longer be executed")
+	return cmd
+}
+
+// create exec command
+func GetCmdExec(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec [msgs-file]",
+		Short: "execute the JSON encoded msgs of a file on behalf of their signers",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			grantee, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var msgs []sdk.Msg
+			err = cdc.UnmarshalJSON(bz, &msgs)
+			if err != nil {
+				return err
+			}
+
+			msg := authz.NewMsgExec(grantee, msgs)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,73 @@
+//nolint
+package authz
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Local code type
+type CodeType = sdk.CodeType
+
+const (
+	// Default authz codespace
+	DefaultCodespace sdk.CodespaceType = 14
+
+	CodeInvalidAddress       CodeType = 1
+	CodeInvalidAuthorization CodeType = 2
+	CodeInvalidExec          CodeType = 3
+	CodeNoAuthorization      CodeType = 4
+	CodeAuthorizationExpired CodeType = 5
+	CodeUnauthorizedMsg      CodeType = 6
+)
+
+func ErrInvalidAddress(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidAddress, msg)
+}
+func ErrInvalidAuthorization(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidAuthorization, msg)
+}
+func ErrInvalidExec(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidExec, msg)
+}
+func ErrNoAuthorization(codespace sdk.CodespaceType, granter, grantee sdk.Address, msgName string) sdk.Error {
+	return newError(codespace, CodeNoAuthorization, fmt.Sprintf("%v is not authorized by %v to execute %s", grantee, granter, msgName))
+}
+func ErrAuthorizationExpired(codespace sdk.CodespaceType, expiration int64) sdk.Error {
+	return newError(codespace, CodeAuthorizationExpired, fmt.Sprintf("Authorization expired at %d", expiration))
+}
+func ErrUnauthorizedMsg(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeUnauthorizedMsg, msg)
+}
+
+func codeToDefaultMsg(code CodeType) string {
+	switch code {
+	case CodeInvalidAddress:
+		return "Invalid address"
+	case CodeInvalidAuthorization:
+		return "Invalid authorization"
+	case CodeInvalidExec:
+		return "Invalid exec"
+	case CodeNoAuthorization:
+		return "No authorization"
+	case CodeAuthorizationExpired:
+		return "Authorization expired"
+	case CodeUnauthorizedMsg:
+		return "Msg not allowed by the authorization"
+	default:
+		return sdk.CodeToDefaultMsg(code)
+	}
+}
+
+func msgOrDefaultMsg(msg string, code CodeType) string {
+	if msg != "" {
+		return msg
+	}
+	return codeToDefaultMsg(code)
+}
+
+func newError(codespace sdk.CodespaceType, code CodeType, msg string) sdk.Error {
+	msg = msgOrDefaultMsg(msg, code)
+	return sdk.NewError(codespace, code, msg)
+}
@@ -0,0 +1,29 @@
+package authz
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - the authorizations granted at genesis
+type GenesisState struct {
+	Grants []Grant `json:"grants"`
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// InitGenesis - store the genesis grants
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	for _, grant := range data.Grants {
+		k.setGrant(ctx, grant)
+	}
+}
+
+// WriteGenesis - output all grants
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	return GenesisState{
+		Grants: k.getAllGrants(ctx),
+	}
+}
@@ -0,0 +1,96 @@
+package authz
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	abci "github.com/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Handle all "authz" type messages, the Msgs executed by MsgExec are run by
+// the handlers of the router once authorized.
+func NewHandler(k Keeper, router baseapp.Router) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case MsgGrantAuthorization:
+			return handleMsgGrantAuthorization(ctx, k, msg)
+		case MsgRevokeAuthorization:
+			return handleMsgRevokeAuthorization(ctx, k, msg)
+		case MsgExec:
+			return handleMsgExec(ctx, k, router, msg)
+		default:
+			errMsg := "Unrecognized authz Msg type: " + reflect.TypeOf(msg).Name()
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+func handleMsgGrantAuthorization(ctx sdk.Context, k Keeper, msg MsgGrantAuthorization) sdk.Result {
+	grant := NewGrant(msg.Granter, msg.Grantee, msg.Authorization, msg.Expiration)
+	k.setGrant(ctx, grant)
+
+	tags := sdk.NewTags("action", []byte("grantAuthorization"), "granter", msg.Granter.Bytes(), "grantee", msg.Grantee.Bytes())
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+func handleMsgRevokeAuthorization(ctx sdk.Context, k Keeper, msg MsgRevokeAuthorization) sdk.Result {
+	_, found := k.GetGrant(ctx, msg.Granter, msg.Grantee, msg.MsgName)
+	if !found {
+		return ErrNoAuthorization(k.codespace, msg.Granter, msg.Grantee, msg.MsgName).Result()
+	}
+	k.deleteGrant(ctx, msg.Granter, msg.Grantee, msg.MsgName)
+
+	tags := sdk.NewTags("action", []byte("revokeAuthorization"), "granter", msg.Granter.Bytes(), "grantee", msg.Grantee.Bytes())
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+// Each signer of an executed Msg other than the grantee must have authorized
+// the grantee, the Msgs are run in order and the first failure fails the tx.
+func handleMsgExec(ctx sdk.Context, k Keeper, router baseapp.Router, msg MsgExec) sdk.Result {
+	var data []byte
+	var logs []string
+	var valUpdates []abci.Validator
+	tags := sdk.NewTags("action", []byte("exec"), "grantee", msg.Grantee.Bytes())
+	for i, m := range msg.Msgs {
+		for _, signer := range m.GetSigners() {
+			if bytes.Equal(signer, msg.Grantee) {
+				continue
+			}
+			err := k.useAuthorization(ctx, signer, msg.Grantee, m)
+			if err != nil {
+				return err.Result()
+			}
+		}
+
+		handler := router.Route(m.Type())
+		if handler == nil {
+			return sdk.ErrUnknownRequest("Unrecognized Msg type: " + m.Type()).Result()
+		}
+		res := handler(ctx, m)
+		if !res.IsOK() {
+			res.Log = fmt.Sprintf("Executed msg %d failed: %s", i, res.Log)
+			return res
+		}
+		data = append(data, res.Data...)
+		if len(res.Log) != 0 {
+			logs = append(logs, fmt.Sprintf("Executed msg %d: %s", i, res.Log))
+		}
+		valUpdates = append(valUpdates, res.ValidatorUpdates...)
+		tags = tags.AppendTags(res.Tags)
+	}
+	return sdk.Result{
+		Data:             data,
+		Log:              strings.Join(logs, "\n"),
+		ValidatorUpdates: valUpdates,
+		Tags:             tags,
+	}
+}
@@ -0,0 +1,97 @@
+package authz
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// Keeper of the authorization store
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *wire.Codec
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+// NewKeeper creates an authz keeper
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		storeKey:  key,
+		cdc:       cdc,
+		codespace: codespace,
+	}
+}
+
+// GetGrant returns the grant of a granter to a grantee for a type of Msgs
+func (k Keeper) GetGrant(ctx sdk.Context, granter, grantee sdk.Address, msgName string) (grant Grant, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetGrantKey(granter, grantee, msgName))
+	if bz == nil {
+		return grant, false
+	}
+	k.cdc.MustUnmarshalBinary(bz, &grant)
+	return grant, true
+}
+
+// GetGrants returns all the grants of a granter to a grantee
+func (k Keeper) GetGrants(ctx sdk.Context, granter, grantee sdk.Address) (grants []Grant) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetGrantsKey(granter, grantee))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var grant Grant
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &grant)
+		grants = append(grants, grant)
+	}
+	return grants
+}
+
+// load all grants used during genesis dump
+func (k Keeper) getAllGrants(ctx sdk.Context) (grants []Grant) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GrantsKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var grant Grant
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &grant)
+		grants = append(grants, grant)
+	}
+	return grants
+}
+
+func (k Keeper) setGrant(ctx sdk.Context, grant Grant) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(grant)
+	store.Set(GetGrantKey(grant.Granter, grant.Grantee, grant.Authorization.MsgName()), bz)
+}
+
+func (k Keeper) deleteGrant(ctx sdk.Context, granter, grantee sdk.Address, msgName string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetGrantKey(granter, grantee, msgName))
+}
+
+// check that the grantee may execute the msg on behalf of the granter and
+// update the authorization, the grant is removed once used up
+func (k Keeper) useAuthorization(ctx sdk.Context, granter, grantee sdk.Address, msg sdk.Msg) sdk.Error {
+	msgName := MsgName(msg)
+	grant, found := k.GetGrant(ctx, granter, grantee, msgName)
+	if !found {
+		return ErrNoAuthorization(k.codespace, granter, grantee, msgName)
+	}
+	if grant.IsExpired(ctx.BlockHeader().Time) {
+		return ErrAuthorizationExpired(k.codespace, grant.Expiration)
+	}
+
+	remaining, err := grant.Authorization.Accept(granter, msg)
+	if err != nil {
+		return err
+	}
+	if remaining == nil {
+		k.deleteGrant(ctx, granter, grantee, msgName)
+		return nil
+	}
+	grant.Authorization = remaining
+	k.setGrant(ctx, grant)
+	return nil
+}
@@ -0,0 +1,21 @@
+package authz
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+//nolint
+var (
+	// Keys for store prefixes
+	GrantsKey = []byte{0x00} // prefix for each key to a grant
+)
+
+// get the key for the grant of a granter to a grantee for a type of Msgs
+func GetGrantKey(granter, grantee sdk.Address, msgName string) []byte {
+	return append(GetGrantsKey(granter, grantee), []byte(msgName)...)
+}
+
+// get the prefix for all grants of a granter to a grantee
+func GetGrantsKey(granter, grantee sdk.Address) []byte {
+	return append(append(GrantsKey, granter.Bytes()...), grantee.Bytes()...)
+}
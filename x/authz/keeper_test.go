@@ -0,0 +1,118 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/abci/types"
+	crypto "github.com/tendermint/go-crypto"
+	dbm "github.com/tendermint/tmlibs/db"
+	"github.com/tendermint/tmlibs/log"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+var addrs = []sdk.Address{
+	crypto.GenPrivKeyEd25519().PubKey().Address(),
+	crypto.GenPrivKeyEd25519().PubKey().Address(),
+	crypto.GenPrivKeyEd25519().PubKey().Address(),
+}
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper) {
+	keyAuthz := sdk.NewKVStoreKey("authz")
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAuthz, sdk.StoreTypeIAVL, db)
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "foochainid", Time: 100}, false, nil, log.NewNopLogger())
+	cdc := wire.NewCodec()
+	RegisterWire(cdc)
+	return ctx, NewKeeper(cdc, keyAuthz, DefaultCodespace)
+}
+
+// router recording the Msgs it handles
+func createTestRouter(handled *[]sdk.Msg) baseapp.Router {
+	handler := func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		*handled = append(*handled, msg)
+		return sdk.Result{}
+	}
+	return baseapp.NewRouter().
+		AddRoute("TestMsg", handler).
+		AddRoute("bank", handler)
+}
+
+func TestGrants(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	handler := NewHandler(keeper, baseapp.NewRouter())
+
+	res := handler(ctx, NewMsgGrantAuthorization(addrs[0], addrs[1], NewGenericAuthorization("TestMsg/TestMsg"), 0))
+	require.True(t, res.IsOK())
+	res = handler(ctx, NewMsgGrantAuthorization(addrs[0], addrs[1], NewSendAuthorization(sdk.Coins{{"steak", 10}}), 200))
+	require.True(t, res.IsOK())
+	res = handler(ctx, NewMsgGrantAuthorization(addrs[0], addrs[2], NewGenericAuthorization("TestMsg/TestMsg"), 0))
+	require.True(t, res.IsOK())
+
+	require.Len(t, keeper.GetGrants(ctx, addrs[0], addrs[1]), 2)
+	require.Len(t, keeper.GetGrants(ctx, addrs[0], addrs[2]), 1)
+	require.Len(t, keeper.GetGrants(ctx, addrs[1], addrs[0]), 0)
+
+	grant, found := keeper.GetGrant(ctx, addrs[0], addrs[1], "bank/MsgSend")
+	require.True(t, found)
+	require.Equal(t, NewGrant(addrs[0], addrs[1], NewSendAuthorization(sdk.Coins{{"steak", 10}}), 200), grant)
+
+	res = handler(ctx, NewMsgRevokeAuthorization(addrs[0], addrs[1], "bank/MsgSend"))
+	require.True(t, res.IsOK())
+	_, found = keeper.GetGrant(ctx, addrs[0], addrs[1], "bank/MsgSend")
+	require.False(t, found)
+	res = handler(ctx, NewMsgRevokeAuthorization(addrs[0], addrs[1], "bank/MsgSend"))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNoAuthorization), res.Code)
+}
+
+func TestMsgExec(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	var handled []sdk.Msg
+	handler := NewHandler(keeper, createTestRouter(&handled))
+
+	// msgs of the grantee itself need no authorization
+	res := handler(ctx, NewMsgExec(addrs[1], []sdk.Msg{sdk.NewTestMsg(addrs[1])}))
+	require.True(t, res.IsOK())
+	require.Len(t, handled, 1)
+
+	// msgs of the granter need its authorization
+	msg := sdk.NewTestMsg(addrs[0], addrs[1])
+	res = handler(ctx, NewMsgExec(addrs[1], []sdk.Msg{msg}))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNoAuthorization), res.Code)
+	require.Len(t, handled, 1)
+
+	res = handler(ctx, NewMsgGrantAuthorization(addrs[0], addrs[1], NewGenericAuthorization("TestMsg/TestMsg"), 200))
+	require.True(t, res.IsOK())
+	res = handler(ctx, NewMsgExec(addrs[1], []sdk.Msg{msg, msg}))
+	require.True(t, res.IsOK())
+	require.Equal(t, []sdk.Msg{msg, msg}, handled[1:])
+
+	// another grantee is not authorized
+	res = handler(ctx, NewMsgExec(addrs[2], []sdk.Msg{sdk.NewTestMsg(addrs[0])}))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNoAuthorization), res.Code)
+
+	// expired grants cannot be used
+	res = handler(ctx.WithBlockHeader(abci.Header{Time: 200}), NewMsgExec(addrs[1], []sdk.Msg{msg}))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeAuthorizationExpired), res.Code)
+
+	// limited authorizations are used up
+	coins := sdk.Coins{{"steak", 10}}
+	send := bank.NewMsgSend([]bank.Input{bank.NewInput(addrs[0], coins)}, []bank.Output{bank.NewOutput(addrs[1], coins)})
+	res = handler(ctx, NewMsgGrantAuthorization(addrs[0], addrs[1], NewSendAuthorization(coins), 0))
+	require.True(t, res.IsOK())
+	res = handler(ctx, NewMsgExec(addrs[1], []sdk.Msg{send}))
+	require.True(t, res.IsOK())
+	res = handler(ctx, NewMsgExec(addrs[1], []sdk.Msg{send}))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNoAuthorization), res.Code)
+	require.Len(t, handled, 4)
+}
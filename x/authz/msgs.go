@@ -0,0 +1,190 @@
+package authz
+
+import (
+	"bytes"
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+var msgCdc = wire.NewCodec()
+
+// name to identify transaction types
+const MsgType = "authz"
+
+// verify interface at compile time
+var _, _, _ sdk.Msg = MsgGrantAuthorization{}, MsgRevokeAuthorization{}, MsgExec{}
+
+//______________________________________________________________________
+
+// MsgGrantAuthorization - authorize a grantee to execute Msgs on behalf of
+// the granter, replacing any previous grant for the same type of Msgs
+type MsgGrantAuthorization struct {
+	Granter       sdk.Address   `json:"granter"`       // account on whose behalf the Msgs are executed
+	Grantee       sdk.Address   `json:"grantee"`       // account executing the Msgs
+	Authorization Authorization `json:"authorization"` // Msgs which may be executed
+	Expiration    int64         `json:"expiration"`    // block time from which the grant may no longer be used, zero if it does not expire
+}
+
+func NewMsgGrantAuthorization(granter, grantee sdk.Address, authorization Authorization, expiration int64) MsgGrantAuthorization {
+	return MsgGrantAuthorization{
+		Granter:       granter,
+		Grantee:       grantee,
+		Authorization: authorization,
+		Expiration:    expiration,
+	}
+}
+
+//nolint
+func (msg MsgGrantAuthorization) Type() string              { return MsgType }
+func (msg MsgGrantAuthorization) GetSigners() []sdk.Address { return []sdk.Address{msg.Granter} }
+
+// get the bytes for the message signer to sign on
+func (msg MsgGrantAuthorization) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Granter       string        `json:"granter"`
+		Grantee       string        `json:"grantee"`
+		Authorization Authorization `json:"authorization"`
+		Expiration    int64         `json:"expiration"`
+	}{
+		Granter:       sdk.MustBech32ifyAcc(msg.Granter),
+		Grantee:       sdk.MustBech32ifyAcc(msg.Grantee),
+		Authorization: msg.Authorization,
+		Expiration:    msg.Expiration,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check
+func (msg MsgGrantAuthorization) ValidateBasic() sdk.Error {
+	if err := validateGranterGrantee(msg.Granter, msg.Grantee); err != nil {
+		return err
+	}
+	if msg.Authorization == nil {
+		return ErrInvalidAuthorization(DefaultCodespace, "authorization cannot be nil")
+	}
+	return msg.Authorization.ValidateBasic()
+}
+
+//______________________________________________________________________
+
+// MsgRevokeAuthorization - remove the grant of a granter to a grantee for a
+// type of Msgs
+type MsgRevokeAuthorization struct {
+	Granter sdk.Address `json:"granter"`  // account on whose behalf the Msgs are executed
+	Grantee sdk.Address `json:"grantee"`  // account executing the Msgs
+	MsgName string      `json:"msg_name"` // name of the Msgs, see MsgName
+}
+
+func NewMsgRevokeAuthorization(granter, grantee sdk.Address, msgName string) MsgRevokeAuthorization {
+	return MsgRevokeAuthorization{
+		Granter: granter,
+		Grantee: grantee,
+		MsgName: msgName,
+	}
+}
+
+//nolint
+func (msg MsgRevokeAuthorization) Type() string              { return MsgType }
+func (msg MsgRevokeAuthorization) GetSigners() []sdk.Address { return []sdk.Address{msg.Granter} }
+
+// get the bytes for the message signer to sign on
+func (msg MsgRevokeAuthorization) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Granter string `json:"granter"`
+		Grantee string `json:"grantee"`
+		MsgName string `json:"msg_name"`
+	}{
+		Granter: sdk.MustBech32ifyAcc(msg.Granter),
+		Grantee: sdk.MustBech32ifyAcc(msg.Grantee),
+		MsgName: msg.MsgName,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check
+func (msg MsgRevokeAuthorization) ValidateBasic() sdk.Error {
+	if err := validateGranterGrantee(msg.Granter, msg.Grantee); err != nil {
+		return err
+	}
+	if len(msg.MsgName) == 0 {
+		return ErrInvalidAuthorization(DefaultCodespace, "msg name cannot be empty")
+	}
+	return nil
+}
+
+//______________________________________________________________________
+
+// MsgExec - execute Msgs on behalf of the granters which authorized the
+// grantee, Msgs signed by the grantee itself need no authorization
+type MsgExec struct {
+	Grantee sdk.Address `json:"grantee"` // account executing the Msgs
+	Msgs    []sdk.Msg   `json:"msgs"`    // Msgs to execute, in order
+}
+
+func NewMsgExec(grantee sdk.Address, msgs []sdk.Msg) MsgExec {
+	return MsgExec{
+		Grantee: grantee,
+		Msgs:    msgs,
+	}
+}
+
+//nolint
+func (msg MsgExec) Type() string              { return MsgType }
+func (msg MsgExec) GetSigners() []sdk.Address { return []sdk.Address{msg.Grantee} }
+
+// get the bytes for the message signer to sign on, made of the sign bytes
+// of the executed Msgs
+func (msg MsgExec) GetSignBytes() []byte {
+	msgsBytes := make([]json.RawMessage, len(msg.Msgs))
+	for i, m := range msg.Msgs {
+		msgsBytes[i] = json.RawMessage(m.GetSignBytes())
+	}
+	b, err := json.Marshal(struct {
+		Grantee string            `json:"grantee"`
+		Msgs    []json.RawMessage `json:"msgs"`
+	}{
+		Grantee: sdk.MustBech32ifyAcc(msg.Grantee),
+		Msgs:    msgsBytes,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check
+func (msg MsgExec) ValidateBasic() sdk.Error {
+	if msg.Grantee == nil {
+		return ErrInvalidAddress(DefaultCodespace, "grantee cannot be nil")
+	}
+	if len(msg.Msgs) == 0 {
+		return ErrInvalidExec(DefaultCodespace, "no msgs to execute")
+	}
+	for _, m := range msg.Msgs {
+		if _, ok := m.(MsgExec); ok {
+			return ErrInvalidExec(DefaultCodespace, "cannot execute nested exec msgs")
+		}
+		if err := m.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateGranterGrantee(granter, grantee sdk.Address) sdk.Error {
+	if granter == nil || grantee == nil {
+		return ErrInvalidAddress(DefaultCodespace, "granter and grantee cannot be nil")
+	}
+	if bytes.Equal(granter, grantee) {
+		return ErrInvalidAddress(DefaultCodespace, "granter cannot authorize itself")
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestMsgGrantAuthorization(t *testing.T) {
+	tests := []struct {
+		granter, grantee sdk.Address
+		authorization    Authorization
+		expectPass       bool
+	}{
+		{addrs[0], addrs[1], NewGenericAuthorization("bank/MsgSend"), true},
+		{addrs[0], addrs[1], NewSendAuthorization(sdk.Coins{{"steak", 10}}), true},
+		{addrs[0], addrs[1], NewDelegateAuthorization(nil, nil), true},
+		{nil, addrs[1], NewGenericAuthorization("bank/MsgSend"), false},
+		{addrs[0], nil, NewGenericAuthorization("bank/MsgSend"), false},
+		{addrs[0], addrs[0], NewGenericAuthorization("bank/MsgSend"), false},
+		{addrs[0], addrs[1], nil, false},
+		{addrs[0], addrs[1], NewGenericAuthorization(""), false},
+		{addrs[0], addrs[1], NewSendAuthorization(nil), false},
+		{addrs[0], addrs[1], NewDelegateAuthorization([]sdk.Address{nil}, nil), false},
+		{addrs[0], addrs[1], NewDelegateAuthorization(nil, sdk.Coins{{"steak", 0}}), false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgGrantAuthorization(tc.granter, tc.grantee, tc.authorization, 0)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
+func TestMsgExecValidation(t *testing.T) {
+	tests := []struct {
+		grantee    sdk.Address
+		msgs       []sdk.Msg
+		expectPass bool
+	}{
+		{addrs[1], []sdk.Msg{sdk.NewTestMsg(addrs[0])}, true},
+		{nil, []sdk.Msg{sdk.NewTestMsg(addrs[0])}, false},
+		{addrs[1], nil, false},
+		{addrs[1], []sdk.Msg{NewMsgExec(addrs[0], []sdk.Msg{sdk.NewTestMsg(addrs[2])})}, false},
+		{addrs[1], []sdk.Msg{NewMsgGrantAuthorization(addrs[0], addrs[0], NewGenericAuthorization("TestMsg/TestMsg"), 0)}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgExec(tc.grantee, tc.msgs)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
+func TestMsgExecGetSignBytes(t *testing.T) {
+	msg := NewMsgExec(addrs[1], []sdk.Msg{NewMsgRevokeAuthorization(addrs[0], addrs[1], "bank/MsgSend")})
+	expected := `{"grantee":"` + sdk.MustBech32ifyAcc(addrs[1]) + `","msgs":[` +
+		string(NewMsgRevokeAuthorization(addrs[0], addrs[1], "bank/MsgSend").GetSignBytes()) + `]}`
+	require.Equal(t, expected, string(msg.GetSignBytes()))
+}
@@ -0,0 +1,20 @@
+package authz
+
+import (
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// Register concrete types on wire codec
+func RegisterWire(cdc *wire.Codec) {
+	cdc.RegisterInterface((*Authorization)(nil), nil)
+	cdc.RegisterConcrete(GenericAuthorization{}, "cosmos-sdk/GenericAuthorization", nil)
+	cdc.RegisterConcrete(SendAuthorization{}, "cosmos-sdk/SendAuthorization", nil)
+	cdc.RegisterConcrete(DelegateAuthorization{}, "cosmos-sdk/DelegateAuthorization", nil)
+	cdc.RegisterConcrete(MsgGrantAuthorization{}, "cosmos-sdk/MsgGrantAuthorization", nil)
+	cdc.RegisterConcrete(MsgRevokeAuthorization{}, "cosmos-sdk/MsgRevokeAuthorization", nil)
+	cdc.RegisterConcrete(MsgExec{}, "cosmos-sdk/MsgExec", nil)
+}
+
+func init() {
+	RegisterWire(msgCdc)
+}
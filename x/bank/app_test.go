@@ -28,8 +28,8 @@ var (
 	manyCoins = sdk.Coins{{"foocoin", 1}, {"barcoin", 1}}
 
 	freeFee = auth.StdFee{ // no fees for a buncha gas
-		sdk.Coins{{"foocoin", 0}},
-		100000,
+		Amount: sdk.Coins{{"foocoin", 0}},
+		Gas:    100000,
 	}
 
 	sendMsg1 = MsgSend{
@@ -80,13 +80,26 @@ func getMockApp(t *testing.T) *mock.App {
 	mapp := mock.NewApp()
 
 	RegisterWire(mapp.Cdc)
-	coinKeeper := NewKeeper(mapp.AccountMapper)
+	keyBank := sdk.NewKVStoreKey("bank")
+	coinKeeper := NewKeeper(mapp.Cdc, keyBank, mapp.AccountMapper, mapp.RegisterCodespace(DefaultCodespace))
 	mapp.Router().AddRoute("bank", NewHandler(coinKeeper))
 
-	mapp.CompleteSetup(t, []*sdk.KVStoreKey{})
+	mapp.SetInitChainer(getInitChainer(mapp, coinKeeper))
+	mapp.CompleteSetup(t, []*sdk.KVStoreKey{keyBank})
 	return mapp
 }
 
+// overwrite the mock init chainer, registering a denomination issued by addr1
+func getInitChainer(mapp *mock.App, keeper Keeper) sdk.InitChainer {
+	return func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+		mapp.InitChainer(ctx, req)
+		InitGenesis(ctx, keeper, NewGenesisState([]Denomination{
+			NewDenomination("issuecoin", addr1, 100, 0),
+		}))
+		return abci.ResponseInitChain{}
+	}
+}
+
 func TestMsgSendWithAccounts(t *testing.T) {
 	mapp := getMockApp(t)
 
@@ -107,25 +120,25 @@ func TestMsgSendWithAccounts(t *testing.T) {
 	assert.Equal(t, acc, res1.(*auth.BaseAccount))
 
 	// Run a CheckDeliver
-	mock.SignCheckDeliver(t, mapp.BaseApp, sendMsg1, []int64{0}, []int64{0}, true, priv1)
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{sendMsg1}, []int64{0}, []int64{0}, true, priv1)
 
 	// Check balances
 	mock.CheckBalance(t, mapp, addr1, sdk.Coins{{"foocoin", 57}})
 	mock.CheckBalance(t, mapp, addr2, sdk.Coins{{"foocoin", 10}})
 
 	// Delivering again should cause replay error
-	mock.SignCheckDeliver(t, mapp.BaseApp, sendMsg1, []int64{0}, []int64{0}, false, priv1)
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{sendMsg1}, []int64{0}, []int64{0}, false, priv1)
 
 	// bumping the txnonce number without resigning should be an auth error
 	mapp.BeginBlock(abci.RequestBeginBlock{})
-	tx := mock.GenTx(sendMsg1, []int64{0}, []int64{0}, priv1)
+	tx := mock.GenTx([]sdk.Msg{sendMsg1}, []int64{0}, []int64{0}, priv1)
 	tx.Signatures[0].Sequence = 1
 	res := mapp.Deliver(tx)
 
 	assert.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnauthorized), res.Code, res.Log)
 
 	// resigning the tx with the bumped sequence should work
-	mock.SignCheckDeliver(t, mapp.BaseApp, sendMsg1, []int64{0}, []int64{1}, true, priv1)
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{sendMsg1}, []int64{0}, []int64{1}, true, priv1)
 }
 
 func TestMsgSendMultipleOut(t *testing.T) {
@@ -145,7 +158,7 @@ func TestMsgSendMultipleOut(t *testing.T) {
 	mock.SetGenesis(mapp, accs)
 
 	// Simulate a Block
-	mock.SignCheckDeliver(t, mapp.BaseApp, sendMsg2, []int64{0}, []int64{0}, true, priv1)
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{sendMsg2}, []int64{0}, []int64{0}, true, priv1)
 
 	// Check balances
 	mock.CheckBalance(t, mapp, addr1, sdk.Coins{{"foocoin", 32}})
@@ -173,7 +186,7 @@ func TestSengMsgMultipleInOut(t *testing.T) {
 	mock.SetGenesis(mapp, accs)
 
 	// CheckDeliver
-	mock.SignCheckDeliver(t, mapp.BaseApp, sendMsg3, []int64{0, 2}, []int64{0, 0}, true, priv1, priv4)
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{sendMsg3}, []int64{0, 2}, []int64{0, 0}, true, priv1, priv4)
 
 	// Check balances
 	mock.CheckBalance(t, mapp, addr1, sdk.Coins{{"foocoin", 32}})
@@ -194,15 +207,49 @@ func TestMsgSendDependent(t *testing.T) {
 	mock.SetGenesis(mapp, accs)
 
 	// CheckDeliver
-	mock.SignCheckDeliver(t, mapp.BaseApp, sendMsg1, []int64{0}, []int64{0}, true, priv1)
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{sendMsg1}, []int64{0}, []int64{0}, true, priv1)
 
 	// Check balances
 	mock.CheckBalance(t, mapp, addr1, sdk.Coins{{"foocoin", 32}})
 	mock.CheckBalance(t, mapp, addr2, sdk.Coins{{"foocoin", 10}})
 
 	// Simulate a Block
-	mock.SignCheckDeliver(t, mapp.BaseApp, sendMsg4, []int64{1}, []int64{0}, true, priv2)
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{sendMsg4}, []int64{1}, []int64{0}, true, priv2)
 
 	// Check balances
 	mock.CheckBalance(t, mapp, addr1, sdk.Coins{{"foocoin", 42}})
 }
+
+func TestMsgIssue(t *testing.T) {
+	mapp := getMockApp(t)
+
+	acc1 := &auth.BaseAccount{
+		Address: addr1,
+		Coins:   sdk.Coins{{"foocoin", 42}},
+	}
+	acc2 := &auth.BaseAccount{
+		Address: addr2,
+		Coins:   sdk.Coins{{"foocoin", 42}},
+	}
+	mock.SetGenesis(mapp, []auth.Account{acc1, acc2})
+
+	// the issuer mints new coins
+	issueMsg := NewMsgIssue(addr1, []Output{NewOutput(addr2, sdk.Coins{{"issuecoin", 60}})})
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{issueMsg}, []int64{0}, []int64{0}, true, priv1)
+	mock.CheckBalance(t, mapp, addr2, sdk.Coins{{"foocoin", 42}, {"issuecoin", 60}})
+
+	// other accounts may not issue, nor exceed the max supply
+	issueMsg2 := NewMsgIssue(addr2, []Output{NewOutput(addr2, sdk.Coins{{"issuecoin", 10}})})
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{issueMsg2}, []int64{1}, []int64{0}, false, priv2)
+	issueMsg3 := NewMsgIssue(addr1, []Output{NewOutput(addr1, sdk.Coins{{"issuecoin", 41}})})
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{issueMsg3}, []int64{0}, []int64{1}, false, priv1)
+
+	// once minting is renounced no coins may be issued, the failed tx above
+	// still used up its sequence
+	renounceMsg := NewMsgRenounceMinting(addr1, "issuecoin")
+	issueMsg4 := NewMsgIssue(addr1, []Output{NewOutput(addr1, sdk.Coins{{"issuecoin", 1}})})
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{renounceMsg}, []int64{0}, []int64{2}, true, priv1)
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{issueMsg4}, []int64{0}, []int64{3}, false, priv1)
+	mock.CheckBalance(t, mapp, addr1, sdk.Coins{{"foocoin", 42}})
+	mock.CheckBalance(t, mapp, addr2, sdk.Coins{{"foocoin", 42}, {"issuecoin", 60}})
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/bank/client"
+)
+
+const (
+	flagDenom = "denom"
+)
+
+// IssueTxCmd will create an issue tx minting new coins and sign it with the issuer's key
+func IssueTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "issue",
+		Short: "Issue new coins of denominations you are the issuer of",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			// get the issuer/to address
+			issuer, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			toStr := viper.GetString(flagTo)
+
+			to, err := sdk.GetAccAddressBech32(toStr)
+			if err != nil {
+				return err
+			}
+			// parse coins
+			amount := viper.GetString(flagAmount)
+			coins, err := sdk.ParseCoins(amount)
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := client.BuildIssueMsg(issuer, to, coins)
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagTo, "", "Address to issue coins to")
+	cmd.Flags().String(flagAmount, "", "Amount of coins to issue")
+	return cmd
+}
+
+// RenounceMintingTxCmd will create a tx permanently disabling the issuance of a denomination
+func RenounceMintingTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "renounce-minting",
+		Short: "Permanently give up the right to issue new coins of a denomination",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			issuer, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := bank.NewMsgRenounceMinting(issuer, viper.GetString(flagDenom))
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagDenom, "", "Denomination to stop issuing")
+	return cmd
+}
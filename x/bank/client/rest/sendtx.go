@@ -18,6 +18,8 @@ import (
 // RegisterRoutes - Central function to define routes that get registered by the main application
 func RegisterRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec, kb keys.Keybase) {
 	r.HandleFunc("/accounts/{address}/send", SendRequestHandlerFn(cdc, kb, ctx)).Methods("POST")
+	r.HandleFunc("/accounts/{address}/issue", IssueRequestHandlerFn(cdc, kb, ctx)).Methods("POST")
+	r.HandleFunc("/denoms/{denom}/renounce-minting", RenounceMintingRequestHandlerFn(cdc, kb, ctx)).Methods("POST")
 }
 
 type sendBody struct {
@@ -30,6 +32,8 @@ type sendBody struct {
 	AccountNumber    int64     `json:"account_number"`
 	Sequence         int64     `json:"sequence"`
 	Gas              int64     `json:"gas"`
+	Memo             string    `json:"memo"`
+	TimeoutHeight    int64     `json:"timeout_height"`
 }
 
 var msgCdc = wire.NewCodec()
@@ -88,13 +92,15 @@ func SendRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx context.CoreCont
 			return
 		}
 
-		// add gas to context
+		// add gas, memo and timeout height to context
 		ctx = ctx.WithGas(m.Gas)
+		ctx = ctx.WithMemo(m.Memo)
+		ctx = ctx.WithTimeoutHeight(m.TimeoutHeight)
 
 		// sign
 		ctx = ctx.WithAccountNumber(m.AccountNumber)
 		ctx = ctx.WithSequence(m.Sequence)
-		txBytes, err := ctx.SignAndBuild(m.LocalAccountName, m.Password, msg, cdc)
+		txBytes, err := ctx.SignAndBuild(m.LocalAccountName, m.Password, []sdk.Msg{msg}, cdc)
 		if err != nil {
 			w.WriteHeader(http.StatusUnauthorized)
 			w.Write([]byte(err.Error()))
@@ -12,3 +12,10 @@ func BuildMsg(from sdk.Address, to sdk.Address, coins sdk.Coins) sdk.Msg {
 	msg := bank.NewMsgSend([]bank.Input{input}, []bank.Output{output})
 	return msg
 }
+
+// build the issue msg
+func BuildIssueMsg(issuer sdk.Address, to sdk.Address, coins sdk.Coins) sdk.Msg {
+	output := bank.NewOutput(to, coins)
+	msg := bank.NewMsgIssue(issuer, []bank.Output{output})
+	return msg
+}
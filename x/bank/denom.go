@@ -0,0 +1,60 @@
+package bank
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Denomination holds the metadata of a denomination whose coins are minted by
+// a designated issuer through MsgIssue
+type Denomination struct {
+	Denom     string      `json:"denom"`
+	Issuer    sdk.Address `json:"issuer"`     // only address allowed to issue new coins of the denomination
+	MaxSupply int64       `json:"max_supply"` // cap on the supply, zero for no cap
+	Decimals  uint16      `json:"decimals"`   // number of decimal places of the display unit
+	Mintable  bool        `json:"mintable"`   // false once the issuer has renounced minting
+}
+
+// NewDenomination - initialize a mintable denomination
+func NewDenomination(denom string, issuer sdk.Address, maxSupply int64, decimals uint16) Denomination {
+	return Denomination{
+		Denom:     denom,
+		Issuer:    issuer,
+		MaxSupply: maxSupply,
+		Decimals:  decimals,
+		Mintable:  true,
+	}
+}
+
+// whether amount more coins may be issued on top of the current supply
+// without exceeding the max supply
+func (d Denomination) canIssue(supply, amount int64) bool {
+	if d.MaxSupply == 0 {
+		return supply+amount >= supply // guard against overflow
+	}
+	return amount <= d.MaxSupply-supply
+}
+
+// check the metadata is consistent
+func (d Denomination) validate() error {
+	if len(d.Denom) == 0 {
+		return fmt.Errorf("denomination must not be empty")
+	}
+	if len(d.Issuer) == 0 {
+		return fmt.Errorf("denomination %s has no issuer", d.Denom)
+	}
+	if d.MaxSupply < 0 {
+		return fmt.Errorf("denomination %s has a negative max supply", d.Denom)
+	}
+	return nil
+}
+
+// human readable representation of the denomination
+func (d Denomination) String() string {
+	return fmt.Sprintf(`Denomination %s:
+  Issuer:     %s
+  Max Supply: %d
+  Decimals:   %d
+  Mintable:   %v`, d.Denom, sdk.MustBech32ifyAcc(d.Issuer), d.MaxSupply, d.Decimals, d.Mintable)
+}
@@ -9,8 +9,12 @@ import (
 const (
 	DefaultCodespace sdk.CodespaceType = 2
 
-	CodeInvalidInput  sdk.CodeType = 101
-	CodeInvalidOutput sdk.CodeType = 102
+	CodeInvalidInput      sdk.CodeType = 101
+	CodeInvalidOutput     sdk.CodeType = 102
+	CodeUnknownDenom      sdk.CodeType = 103
+	CodeInvalidIssuer     sdk.CodeType = 104
+	CodeMintingDisabled   sdk.CodeType = 105
+	CodeMaxSupplyExceeded sdk.CodeType = 106
 )
 
 // NOTE: Don't stringer this, we'll put better messages in later.
@@ -20,6 +24,14 @@ func codeToDefaultMsg(code sdk.CodeType) string {
 		return "Invalid input coins"
 	case CodeInvalidOutput:
 		return "Invalid output coins"
+	case CodeUnknownDenom:
+		return "Unknown denomination"
+	case CodeInvalidIssuer:
+		return "Address is not the issuer of the denomination"
+	case CodeMintingDisabled:
+		return "Minting of the denomination has been renounced"
+	case CodeMaxSupplyExceeded:
+		return "Issuance exceeds the max supply of the denomination"
 	default:
 		return sdk.CodeToDefaultMsg(code)
 	}
@@ -44,6 +56,22 @@ func ErrNoOutputs(codespace sdk.CodespaceType) sdk.Error {
 	return newError(codespace, CodeInvalidOutput, "")
 }
 
+func ErrUnknownDenom(codespace sdk.CodespaceType, denom string) sdk.Error {
+	return newError(codespace, CodeUnknownDenom, "denomination "+denom+" has not been registered")
+}
+
+func ErrInvalidIssuer(codespace sdk.CodespaceType, denom string) sdk.Error {
+	return newError(codespace, CodeInvalidIssuer, "address is not the issuer of "+denom)
+}
+
+func ErrMintingDisabled(codespace sdk.CodespaceType, denom string) sdk.Error {
+	return newError(codespace, CodeMintingDisabled, "minting of "+denom+" has been renounced")
+}
+
+func ErrMaxSupplyExceeded(codespace sdk.CodespaceType, denom string) sdk.Error {
+	return newError(codespace, CodeMaxSupplyExceeded, "issuance exceeds the max supply of "+denom)
+}
+
 //----------------------------------------
 
 func msgOrDefaultMsg(msg string, code sdk.CodeType) string {
@@ -0,0 +1,42 @@
+package bank
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - the issued denominations registered at genesis
+type GenesisState struct {
+	Denominations []Denomination `json:"denominations"`
+}
+
+func NewGenesisState(denominations []Denomination) GenesisState {
+	return GenesisState{
+		Denominations: denominations,
+	}
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// InitGenesis - register the genesis denominations, panics on invalid or
+// duplicated metadata
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	for _, denomination := range data.Denominations {
+		if err := denomination.validate(); err != nil {
+			panic(err)
+		}
+		if _, found := keeper.GetDenomination(ctx, denomination.Denom); found {
+			panic("denomination " + denomination.Denom + " registered twice")
+		}
+		keeper.setDenomination(ctx, denomination)
+	}
+}
+
+// WriteGenesis - output the issued denominations
+func WriteGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	return GenesisState{
+		Denominations: keeper.GetDenominations(ctx),
+	}
+}
@@ -14,6 +14,8 @@ func NewHandler(k Keeper) sdk.Handler {
 			return handleMsgSend(ctx, k, msg)
 		case MsgIssue:
 			return handleMsgIssue(ctx, k, msg)
+		case MsgRenounceMinting:
+			return handleMsgRenounceMinting(ctx, k, msg)
 		default:
 			errMsg := "Unrecognized bank Msg type: " + reflect.TypeOf(msg).Name()
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -37,5 +39,24 @@ func handleMsgSend(ctx sdk.Context, k Keeper, msg MsgSend) sdk.Result {
 
 // Handle MsgIssue.
 func handleMsgIssue(ctx sdk.Context, k Keeper, msg MsgIssue) sdk.Result {
-	panic("not implemented yet")
+	tags, err := k.IssueCoins(ctx, msg.Banker, msg.Outputs)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+// Handle MsgRenounceMinting.
+func handleMsgRenounceMinting(ctx sdk.Context, k Keeper, msg MsgRenounceMinting) sdk.Result {
+	tags, err := k.RenounceMinting(ctx, msg.Issuer, msg.Denom)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: tags,
+	}
 }
@@ -0,0 +1,92 @@
+package bank
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetDenomination returns the metadata of an issued denomination
+func (keeper Keeper) GetDenomination(ctx sdk.Context, denom string) (denomination Denomination, found bool) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(GetDenominationKey(denom))
+	if bz == nil {
+		return denomination, false
+	}
+	keeper.cdc.MustUnmarshalBinary(bz, &denomination)
+	return denomination, true
+}
+
+func (keeper Keeper) setDenomination(ctx sdk.Context, denomination Denomination) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := keeper.cdc.MustMarshalBinary(denomination)
+	store.Set(GetDenominationKey(denomination.Denom), bz)
+}
+
+// GetDenominations returns the metadata of all the issued denominations
+func (keeper Keeper) GetDenominations(ctx sdk.Context) (denominations []Denomination) {
+	store := ctx.KVStore(keeper.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, DenominationKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var denomination Denomination
+		keeper.cdc.MustUnmarshalBinary(iterator.Value(), &denomination)
+		denominations = append(denominations, denomination)
+	}
+	return denominations
+}
+
+// IssueCoins mints new coins to the outputs, the issuer must be allowed to
+// mint all of the denominations issued
+// NOTE: Make sure to revert state changes from tx on error
+func (keeper Keeper) IssueCoins(ctx sdk.Context, issuer sdk.Address, outputs []Output) (sdk.Tags, sdk.Error) {
+	var total sdk.Coins
+	for _, out := range outputs {
+		total = total.Plus(out.Coins)
+	}
+
+	// check every denomination before minting any coin
+	for _, coin := range total {
+		denomination, found := keeper.GetDenomination(ctx, coin.Denom)
+		if !found {
+			return nil, ErrUnknownDenom(keeper.codespace, coin.Denom)
+		}
+		if !bytes.Equal(denomination.Issuer, issuer) {
+			return nil, ErrInvalidIssuer(keeper.codespace, coin.Denom)
+		}
+		if !denomination.Mintable {
+			return nil, ErrMintingDisabled(keeper.codespace, coin.Denom)
+		}
+		if !denomination.canIssue(keeper.sk.GetSupplyOf(ctx, coin.Denom), coin.Amount) {
+			return nil, ErrMaxSupplyExceeded(keeper.codespace, coin.Denom)
+		}
+	}
+
+	allTags := sdk.NewTags("issuer", []byte(issuer.String()))
+	for _, out := range outputs {
+		_, tags, err := keeper.MintCoins(ctx, out.Address, out.Coins)
+		if err != nil {
+			return nil, err
+		}
+		allTags = allTags.AppendTags(tags)
+	}
+	return allTags, nil
+}
+
+// RenounceMinting permanently disables the issuance of new coins of a
+// denomination, only its issuer may do so
+func (keeper Keeper) RenounceMinting(ctx sdk.Context, issuer sdk.Address, denom string) (sdk.Tags, sdk.Error) {
+	denomination, found := keeper.GetDenomination(ctx, denom)
+	if !found {
+		return nil, ErrUnknownDenom(keeper.codespace, denom)
+	}
+	if !bytes.Equal(denomination.Issuer, issuer) {
+		return nil, ErrInvalidIssuer(keeper.codespace, denom)
+	}
+	if !denomination.Mintable {
+		return nil, ErrMintingDisabled(keeper.codespace, denom)
+	}
+	denomination.Mintable = false
+	keeper.setDenomination(ctx, denomination)
+	return sdk.NewTags("issuer", []byte(issuer.String()), "denom", []byte(denom)), nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 )
 
@@ -15,14 +16,24 @@ const (
 	costAddCoins      sdk.Gas = 10
 )
 
-// Keeper manages transfers between accounts
+// Keeper manages transfers between accounts and the issuance of new coins
 type Keeper struct {
-	am auth.AccountMapper
+	storeKey  sdk.StoreKey // the metadata of the issued denominations
+	cdc       *wire.Codec
+	am        auth.AccountMapper
+	sk        SupplyKeeper
+	codespace sdk.CodespaceType
 }
 
 // NewKeeper returns a new Keeper
-func NewKeeper(am auth.AccountMapper) Keeper {
-	return Keeper{am: am}
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, am auth.AccountMapper, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		storeKey:  key,
+		cdc:       cdc,
+		am:        am,
+		sk:        NewSupplyKeeper(cdc, key),
+		codespace: codespace,
+	}
 }
 
 // GetCoins returns the coins at the addr.
@@ -50,6 +61,17 @@ func (keeper Keeper) AddCoins(ctx sdk.Context, addr sdk.Address, amt sdk.Coins)
 	return addCoins(ctx, keeper.am, addr, amt)
 }
 
+// DelegateCoins subtracts amt from the coins at the addr to be delegated,
+// unlike SubtractCoins it may take coins which are still vesting.
+func (keeper Keeper) DelegateCoins(ctx sdk.Context, addr sdk.Address, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
+	return delegateCoins(ctx, keeper.am, addr, amt)
+}
+
+// UndelegateCoins adds amt undelegated coins to the coins at the addr.
+func (keeper Keeper) UndelegateCoins(ctx sdk.Context, addr sdk.Address, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
+	return undelegateCoins(ctx, keeper.am, addr, amt)
+}
+
 // SendCoins moves coins from one account to another
 func (keeper Keeper) SendCoins(ctx sdk.Context, fromAddr sdk.Address, toAddr sdk.Address, amt sdk.Coins) (sdk.Tags, sdk.Error) {
 	return sendCoins(ctx, keeper.am, fromAddr, toAddr, amt)
@@ -60,6 +82,41 @@ func (keeper Keeper) InputOutputCoins(ctx sdk.Context, inputs []Input, outputs [
 	return inputOutputCoins(ctx, keeper.am, inputs, outputs)
 }
 
+// GetSupply returns the total supply of all denominations
+func (keeper Keeper) GetSupply(ctx sdk.Context) sdk.Coins {
+	return keeper.sk.GetSupply(ctx)
+}
+
+// MintCoins creates amt new coins at the addr.
+func (keeper Keeper) MintCoins(ctx sdk.Context, addr sdk.Address, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
+	newCoins, tags, err := addCoins(ctx, keeper.am, addr, amt)
+	if err != nil {
+		return nil, nil, err
+	}
+	keeper.sk.Inflate(ctx, amt)
+	return newCoins, tags, nil
+}
+
+// BurnCoins destroys amt coins at the addr.
+func (keeper Keeper) BurnCoins(ctx sdk.Context, addr sdk.Address, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
+	newCoins, tags, err := subtractCoins(ctx, keeper.am, addr, amt)
+	if err != nil {
+		return nil, nil, err
+	}
+	keeper.sk.Deflate(ctx, amt)
+	return newCoins, tags, nil
+}
+
+// InflateSupply records the creation of coins held by a module rather than an account.
+func (keeper Keeper) InflateSupply(ctx sdk.Context, amt sdk.Coins) {
+	keeper.sk.Inflate(ctx, amt)
+}
+
+// DeflateSupply records the destruction of coins held by a module rather than an account.
+func (keeper Keeper) DeflateSupply(ctx sdk.Context, amt sdk.Coins) {
+	keeper.sk.Deflate(ctx, amt)
+}
+
 //______________________________________________________________________________________________
 
 // SendKeeper only allows transfers between accounts, without the possibility of creating coins
@@ -136,23 +193,73 @@ func setCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.Address, amt sdk.
 	return nil
 }
 
+// coins at the addr which are not locked by a vesting schedule
+func getSpendableCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.Address) sdk.Coins {
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		return sdk.Coins{}
+	}
+	return auth.SpendableCoins(acc, ctx.BlockHeader().Time)
+}
+
 // HasCoins returns whether or not an account has at least amt coins.
 func hasCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.Address, amt sdk.Coins) bool {
 	ctx.GasMeter().ConsumeGas(costHasCoins, "hasCoins")
 	return getCoins(ctx, am, addr).IsGTE(amt)
 }
 
-// SubtractCoins subtracts amt from the coins at the addr.
+// SubtractCoins subtracts amt from the coins at the addr, coins which are
+// still vesting cannot be subtracted.
 func subtractCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.Address, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
 	ctx.GasMeter().ConsumeGas(costSubtractCoins, "subtractCoins")
-	oldCoins := getCoins(ctx, am, addr)
+	spendable := getSpendableCoins(ctx, am, addr)
+	if !spendable.Minus(amt).IsNotNegative() {
+		return amt, nil, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", spendable, amt))
+	}
+	newCoins := getCoins(ctx, am, addr).Minus(amt)
+	err := setCoins(ctx, am, addr, newCoins)
+	tags := sdk.NewTags("sender", []byte(addr.String()))
+	return newCoins, tags, err
+}
+
+// DelegateCoins subtracts amt from the coins at the addr, recording the
+// delegation of vesting coins.
+func delegateCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.Address, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
+	ctx.GasMeter().ConsumeGas(costSubtractCoins, "delegateCoins")
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		return amt, nil, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", sdk.Coins{}, amt))
+	}
+	oldCoins := acc.GetCoins()
 	newCoins := oldCoins.Minus(amt)
 	if !newCoins.IsNotNegative() {
 		return amt, nil, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", oldCoins, amt))
 	}
-	err := setCoins(ctx, am, addr, newCoins)
+	if vacc, ok := acc.(auth.VestingAccount); ok {
+		vacc.TrackDelegation(ctx.BlockHeader().Time, amt)
+	}
+	acc.SetCoins(newCoins)
+	am.SetAccount(ctx, acc)
 	tags := sdk.NewTags("sender", []byte(addr.String()))
-	return newCoins, tags, err
+	return newCoins, tags, nil
+}
+
+// UndelegateCoins adds amt to the coins at the addr, recording the
+// undelegation of vesting coins.
+func undelegateCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.Address, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
+	ctx.GasMeter().ConsumeGas(costAddCoins, "undelegateCoins")
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		acc = am.NewAccountWithAddress(ctx, addr)
+	}
+	newCoins := acc.GetCoins().Plus(amt)
+	if vacc, ok := acc.(auth.VestingAccount); ok {
+		vacc.TrackUndelegation(amt)
+	}
+	acc.SetCoins(newCoins)
+	am.SetAccount(ctx, acc)
+	tags := sdk.NewTags("recipient", []byte(addr.String()))
+	return newCoins, tags, nil
 }
 
 // AddCoins adds amt to the coins at the addr.
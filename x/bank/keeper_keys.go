@@ -0,0 +1,12 @@
+package bank
+
+// keys of the bank store
+var (
+	DenominationKeyPrefix = []byte{0x00} // prefix for the metadata of each issued denomination
+	SupplyKey             = []byte{0x01} // key for the total supply of all denominations
+)
+
+// get the key for the metadata of a denomination
+func GetDenominationKey(denom string) []byte {
+	return append(DenominationKeyPrefix, []byte(denom)...)
+}
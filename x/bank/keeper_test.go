@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/abci/types"
 	dbm "github.com/tendermint/tmlibs/db"
@@ -16,24 +17,26 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 )
 
-func setupMultiStore() (sdk.MultiStore, *sdk.KVStoreKey) {
+func setupMultiStore() (sdk.MultiStore, *sdk.KVStoreKey, *sdk.KVStoreKey) {
 	db := dbm.NewMemDB()
 	authKey := sdk.NewKVStoreKey("authkey")
+	bankKey := sdk.NewKVStoreKey("bankkey")
 	ms := store.NewCommitMultiStore(db)
 	ms.MountStoreWithDB(authKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(bankKey, sdk.StoreTypeIAVL, db)
 	ms.LoadLatestVersion()
-	return ms, authKey
+	return ms, authKey, bankKey
 }
 
 func TestKeeper(t *testing.T) {
-	ms, authKey := setupMultiStore()
+	ms, authKey, bankKey := setupMultiStore()
 
 	cdc := wire.NewCodec()
 	auth.RegisterBaseAccount(cdc)
 
 	ctx := sdk.NewContext(ms, abci.Header{}, false, nil, log.NewNopLogger())
 	accountMapper := auth.NewAccountMapper(cdc, authKey, &auth.BaseAccount{})
-	coinKeeper := NewKeeper(accountMapper)
+	coinKeeper := NewKeeper(cdc, bankKey, accountMapper, DefaultCodespace)
 
 	addr := sdk.Address([]byte("addr1"))
 	addr2 := sdk.Address([]byte("addr2"))
@@ -111,14 +114,14 @@ func TestKeeper(t *testing.T) {
 }
 
 func TestSendKeeper(t *testing.T) {
-	ms, authKey := setupMultiStore()
+	ms, authKey, bankKey := setupMultiStore()
 
 	cdc := wire.NewCodec()
 	auth.RegisterBaseAccount(cdc)
 
 	ctx := sdk.NewContext(ms, abci.Header{}, false, nil, log.NewNopLogger())
 	accountMapper := auth.NewAccountMapper(cdc, authKey, &auth.BaseAccount{})
-	coinKeeper := NewKeeper(accountMapper)
+	coinKeeper := NewKeeper(cdc, bankKey, accountMapper, DefaultCodespace)
 	sendKeeper := NewSendKeeper(accountMapper)
 
 	addr := sdk.Address([]byte("addr1"))
@@ -180,14 +183,14 @@ func TestSendKeeper(t *testing.T) {
 }
 
 func TestViewKeeper(t *testing.T) {
-	ms, authKey := setupMultiStore()
+	ms, authKey, bankKey := setupMultiStore()
 
 	cdc := wire.NewCodec()
 	auth.RegisterBaseAccount(cdc)
 
 	ctx := sdk.NewContext(ms, abci.Header{}, false, nil, log.NewNopLogger())
 	accountMapper := auth.NewAccountMapper(cdc, authKey, &auth.BaseAccount{})
-	coinKeeper := NewKeeper(accountMapper)
+	coinKeeper := NewKeeper(cdc, bankKey, accountMapper, DefaultCodespace)
 	viewKeeper := NewViewKeeper(accountMapper)
 
 	addr := sdk.Address([]byte("addr1"))
@@ -206,3 +209,164 @@ func TestViewKeeper(t *testing.T) {
 	assert.False(t, viewKeeper.HasCoins(ctx, addr, sdk.Coins{{"foocoin", 15}}))
 	assert.False(t, viewKeeper.HasCoins(ctx, addr, sdk.Coins{{"barcoin", 5}}))
 }
+
+func TestKeeperIssueCoins(t *testing.T) {
+	ms, authKey, bankKey := setupMultiStore()
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, nil, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, &auth.BaseAccount{})
+	coinKeeper := NewKeeper(cdc, bankKey, accountMapper, DefaultCodespace)
+
+	issuer := sdk.Address([]byte("issuer"))
+	addr := sdk.Address([]byte("addr1"))
+	addr2 := sdk.Address([]byte("addr2"))
+
+	// register the denominations at genesis, where the issuer already holds some coins
+	coinKeeper.SetCoins(ctx, issuer, sdk.Coins{{"capcoin", 10}})
+	InitGenesis(ctx, coinKeeper, NewGenesisState([]Denomination{
+		NewDenomination("capcoin", issuer, 100, 6),
+		NewDenomination("freecoin", issuer, 0, 0),
+	}))
+	InitSupply(ctx, coinKeeper)
+	require.Equal(t, 2, len(WriteGenesis(ctx, coinKeeper).Denominations))
+
+	// only the issuer may issue registered denominations
+	_, err := coinKeeper.IssueCoins(ctx, addr, []Output{NewOutput(addr, sdk.Coins{{"capcoin", 5}})})
+	require.Equal(t, CodeInvalidIssuer, err.Code())
+	_, err = coinKeeper.IssueCoins(ctx, issuer, []Output{NewOutput(addr, sdk.Coins{{"foocoin", 5}})})
+	require.Equal(t, CodeUnknownDenom, err.Code())
+
+	// issuance is tracked in the supply
+	_, err = coinKeeper.IssueCoins(ctx, issuer, []Output{
+		NewOutput(addr, sdk.Coins{{"capcoin", 50}, {"freecoin", 7}}),
+		NewOutput(addr2, sdk.Coins{{"capcoin", 40}}),
+	})
+	require.Nil(t, err)
+	assert.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{{"capcoin", 50}, {"freecoin", 7}}))
+	assert.True(t, coinKeeper.GetCoins(ctx, addr2).IsEqual(sdk.Coins{{"capcoin", 40}}))
+	require.Equal(t, int64(100), coinKeeper.GetSupply(ctx).AmountOf("capcoin"))
+
+	// the max supply may not be exceeded
+	_, err = coinKeeper.IssueCoins(ctx, issuer, []Output{NewOutput(addr, sdk.Coins{{"capcoin", 1}})})
+	require.Equal(t, CodeMaxSupplyExceeded, err.Code())
+
+	// minting may only be renounced by the issuer, and only once
+	_, err = coinKeeper.RenounceMinting(ctx, addr, "freecoin")
+	require.Equal(t, CodeInvalidIssuer, err.Code())
+	_, err = coinKeeper.RenounceMinting(ctx, issuer, "freecoin")
+	require.Nil(t, err)
+	_, err = coinKeeper.RenounceMinting(ctx, issuer, "freecoin")
+	require.Equal(t, CodeMintingDisabled, err.Code())
+	_, err = coinKeeper.IssueCoins(ctx, issuer, []Output{NewOutput(addr, sdk.Coins{{"freecoin", 1}})})
+	require.Equal(t, CodeMintingDisabled, err.Code())
+	denomination, _ := coinKeeper.GetDenomination(ctx, "freecoin")
+	require.False(t, denomination.Mintable)
+	require.Equal(t, int64(7), coinKeeper.GetSupply(ctx).AmountOf("freecoin"))
+	require.Nil(t, SupplyInvariant(coinKeeper)(ctx))
+}
+
+func TestKeeperSupply(t *testing.T) {
+	ms, authKey, bankKey := setupMultiStore()
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, nil, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, &auth.BaseAccount{})
+	coinKeeper := NewKeeper(cdc, bankKey, accountMapper, DefaultCodespace)
+
+	addr := sdk.Address([]byte("addr1"))
+	addr2 := sdk.Address([]byte("addr2"))
+
+	// coins held by a module outside of any account
+	var held sdk.Coins
+	holder := func(_ sdk.Context) sdk.Coins { return held }
+	invariant := SupplyInvariant(coinKeeper, holder)
+
+	// the genesis supply covers both accounts and modules
+	coinKeeper.SetCoins(ctx, addr, sdk.Coins{{"foocoin", 10}})
+	coinKeeper.SetCoins(ctx, addr2, sdk.Coins{{"barcoin", 5}, {"foocoin", 3}})
+	held = sdk.Coins{{"foocoin", 2}}
+	InitSupply(ctx, coinKeeper, holder)
+	assert.True(t, coinKeeper.GetSupply(ctx).IsEqual(sdk.Coins{{"barcoin", 5}, {"foocoin", 15}}))
+	require.Nil(t, invariant(ctx))
+
+	// transfers do not change the supply
+	_, err := coinKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{{"foocoin", 4}})
+	require.Nil(t, err)
+	require.Nil(t, invariant(ctx))
+
+	// minting and burning coins of accounts
+	_, _, err = coinKeeper.MintCoins(ctx, addr, sdk.Coins{{"foocoin", 5}})
+	require.Nil(t, err)
+	require.Equal(t, int64(20), coinKeeper.GetSupply(ctx).AmountOf("foocoin"))
+	_, _, err = coinKeeper.BurnCoins(ctx, addr2, sdk.Coins{{"barcoin", 5}})
+	require.Nil(t, err)
+	require.Equal(t, int64(0), coinKeeper.GetSupply(ctx).AmountOf("barcoin"))
+	_, _, err = coinKeeper.BurnCoins(ctx, addr2, sdk.Coins{{"foocoin", 100}})
+	require.NotNil(t, err)
+	require.Equal(t, int64(20), coinKeeper.GetSupply(ctx).AmountOf("foocoin"))
+	require.Nil(t, invariant(ctx))
+
+	// minting and burning coins held by a module
+	held = sdk.Coins{{"foocoin", 7}}
+	coinKeeper.InflateSupply(ctx, sdk.Coins{{"foocoin", 5}})
+	require.Nil(t, invariant(ctx))
+	held = sdk.Coins{{"foocoin", 1}}
+	coinKeeper.DeflateSupply(ctx, sdk.Coins{{"foocoin", 6}})
+	require.Nil(t, invariant(ctx))
+	assert.Panics(t, func() { coinKeeper.DeflateSupply(ctx, sdk.Coins{{"foocoin", 100}}) })
+
+	// coins created outside of the keeper break the invariant
+	coinKeeper.AddCoins(ctx, addr, sdk.Coins{{"foocoin", 1}})
+	require.NotNil(t, invariant(ctx))
+}
+
+func TestKeeperVestingAccount(t *testing.T) {
+	ms, authKey, bankKey := setupMultiStore()
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{Time: 100}, false, nil, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, &auth.BaseAccount{})
+	coinKeeper := NewKeeper(cdc, bankKey, accountMapper, DefaultCodespace)
+
+	addr := sdk.Address([]byte("addr1"))
+	addr2 := sdk.Address([]byte("addr2"))
+	bacc := auth.NewBaseAccountWithAddress(addr)
+	bacc.Coins = sdk.Coins{{"steak", 100}}
+	accountMapper.SetAccount(ctx, auth.NewDelayedVestingAccount(bacc, 200))
+
+	// locked coins cannot be spent, received coins can
+	_, err := coinKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{{"steak", 1}})
+	require.NotNil(t, err)
+	coinKeeper.AddCoins(ctx, addr, sdk.Coins{{"steak", 10}})
+	_, err = coinKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{{"steak", 10}})
+	require.Nil(t, err)
+	_, _, err = coinKeeper.SubtractCoins(ctx, addr, sdk.Coins{{"steak", 1}})
+	require.NotNil(t, err)
+
+	// locked coins may be delegated and are locked again once undelegated
+	_, _, err = coinKeeper.DelegateCoins(ctx, addr, sdk.Coins{{"steak", 60}})
+	require.Nil(t, err)
+	assert.True(t, coinKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{{"steak", 40}}))
+	vacc := accountMapper.GetAccount(ctx, addr).(auth.VestingAccount)
+	assert.True(t, vacc.GetDelegatedVesting().IsEqual(sdk.Coins{{"steak", 60}}))
+	_, _, err = coinKeeper.DelegateCoins(ctx, addr, sdk.Coins{{"steak", 41}})
+	require.NotNil(t, err)
+	_, _, err = coinKeeper.UndelegateCoins(ctx, addr, sdk.Coins{{"steak", 60}})
+	require.Nil(t, err)
+	vacc = accountMapper.GetAccount(ctx, addr).(auth.VestingAccount)
+	assert.True(t, vacc.GetDelegatedVesting().IsZero())
+	_, _, err = coinKeeper.SubtractCoins(ctx, addr, sdk.Coins{{"steak", 1}})
+	require.NotNil(t, err)
+
+	// all coins may be spent once vested
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 200})
+	_, err = coinKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{{"steak", 100}})
+	require.Nil(t, err)
+}
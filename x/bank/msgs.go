@@ -105,7 +105,9 @@ func (msg MsgIssue) Type() string { return "bank" } // TODO: "bank/issue"
 
 // Implements Msg.
 func (msg MsgIssue) ValidateBasic() sdk.Error {
-	// XXX
+	if len(msg.Banker) == 0 {
+		return sdk.ErrInvalidAddress(msg.Banker.String())
+	}
 	if len(msg.Outputs) == 0 {
 		return ErrNoOutputs(DefaultCodespace).Trace("")
 	}
@@ -141,6 +143,56 @@ func (msg MsgIssue) GetSigners() []sdk.Address {
 	return []sdk.Address{msg.Banker}
 }
 
+//----------------------------------------
+// MsgRenounceMinting
+
+// MsgRenounceMinting - permanently give up the right to issue a denomination
+type MsgRenounceMinting struct {
+	Issuer sdk.Address `json:"issuer"`
+	Denom  string      `json:"denom"`
+}
+
+var _ sdk.Msg = MsgRenounceMinting{}
+
+// NewMsgRenounceMinting - construct a msg disabling the issuance of a denomination
+func NewMsgRenounceMinting(issuer sdk.Address, denom string) MsgRenounceMinting {
+	return MsgRenounceMinting{Issuer: issuer, Denom: denom}
+}
+
+// Implements Msg.
+func (msg MsgRenounceMinting) Type() string { return "bank" } // TODO: "bank/renounce"
+
+// Implements Msg.
+func (msg MsgRenounceMinting) ValidateBasic() sdk.Error {
+	if len(msg.Issuer) == 0 {
+		return sdk.ErrInvalidAddress(msg.Issuer.String())
+	}
+	if len(msg.Denom) == 0 {
+		return ErrUnknownDenom(DefaultCodespace, msg.Denom)
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgRenounceMinting) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Issuer string `json:"issuer"`
+		Denom  string `json:"denom"`
+	}{
+		Issuer: sdk.MustBech32ifyAcc(msg.Issuer),
+		Denom:  msg.Denom,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Implements Msg.
+func (msg MsgRenounceMinting) GetSigners() []sdk.Address {
+	return []sdk.Address{msg.Issuer}
+}
+
 //----------------------------------------
 // Input
 
@@ -245,7 +245,29 @@ func TestMsgIssueType(t *testing.T) {
 }
 
 func TestMsgIssueValidation(t *testing.T) {
-	// TODO
+	banker := sdk.Address([]byte("input"))
+	addr := sdk.Address([]byte("loan-from-bank"))
+	coins := sdk.Coins{{"atom", 10}}
+
+	cases := []struct {
+		valid bool
+		tx    MsgIssue
+	}{
+		{true, NewMsgIssue(banker, []Output{NewOutput(addr, coins)})},
+		{false, NewMsgIssue(nil, []Output{NewOutput(addr, coins)})},   // no banker
+		{false, NewMsgIssue(banker, nil)},                             // no outputs
+		{false, NewMsgIssue(banker, []Output{NewOutput(addr, nil)})},  // empty coins
+		{false, NewMsgIssue(banker, []Output{NewOutput(nil, coins)})}, // no recipient
+	}
+
+	for i, tc := range cases {
+		err := tc.tx.ValidateBasic()
+		if tc.valid {
+			assert.Nil(t, err, "%d: %+v", i, err)
+		} else {
+			assert.NotNil(t, err, "%d", i)
+		}
+	}
 }
 
 func TestMsgIssueGetSignBytes(t *testing.T) {
@@ -268,3 +290,27 @@ func TestMsgIssueGetSigners(t *testing.T) {
 	res := msg.GetSigners()
 	assert.Equal(t, fmt.Sprintf("%v", res), "[6F6E6C796F6E65]")
 }
+
+// ----------------------------------------
+// MsgRenounceMinting Tests
+
+func TestMsgRenounceMintingValidation(t *testing.T) {
+	issuer := sdk.Address([]byte("input"))
+	assert.Nil(t, NewMsgRenounceMinting(issuer, "atom").ValidateBasic())
+	assert.NotNil(t, NewMsgRenounceMinting(nil, "atom").ValidateBasic())
+	assert.NotNil(t, NewMsgRenounceMinting(issuer, "").ValidateBasic())
+}
+
+func TestMsgRenounceMintingGetSignBytes(t *testing.T) {
+	msg := NewMsgRenounceMinting(sdk.Address([]byte("input")), "atom")
+	res := msg.GetSignBytes()
+
+	expected := `{"issuer":"cosmosaccaddr1d9h8qat5e4ehc5","denom":"atom"}`
+	assert.Equal(t, expected, string(res))
+}
+
+func TestMsgRenounceMintingGetSigners(t *testing.T) {
+	msg := NewMsgRenounceMinting(sdk.Address([]byte("onlyone")), "atom")
+	res := msg.GetSigners()
+	assert.Equal(t, fmt.Sprintf("%v", res), "[6F6E6C796F6E65]")
+}
@@ -0,0 +1,102 @@
+package bank
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// SupplyKeeper records the total supply of every denomination, every path
+// creating or destroying coins must go through it
+type SupplyKeeper struct {
+	storeKey sdk.StoreKey
+	cdc      *wire.Codec
+}
+
+// NewSupplyKeeper returns a new SupplyKeeper
+func NewSupplyKeeper(cdc *wire.Codec, key sdk.StoreKey) SupplyKeeper {
+	return SupplyKeeper{
+		storeKey: key,
+		cdc:      cdc,
+	}
+}
+
+// GetSupply returns the total supply of all denominations
+func (sk SupplyKeeper) GetSupply(ctx sdk.Context) (supply sdk.Coins) {
+	store := ctx.KVStore(sk.storeKey)
+	bz := store.Get(SupplyKey)
+	if bz == nil {
+		return sdk.Coins{}
+	}
+	sk.cdc.MustUnmarshalBinary(bz, &supply)
+	return supply
+}
+
+// GetSupplyOf returns the total supply of a denomination
+func (sk SupplyKeeper) GetSupplyOf(ctx sdk.Context, denom string) int64 {
+	return sk.GetSupply(ctx).AmountOf(denom)
+}
+
+func (sk SupplyKeeper) setSupply(ctx sdk.Context, supply sdk.Coins) {
+	store := ctx.KVStore(sk.storeKey)
+	store.Set(SupplyKey, sk.cdc.MustMarshalBinary(supply))
+}
+
+// Inflate records the creation of new coins
+func (sk SupplyKeeper) Inflate(ctx sdk.Context, amt sdk.Coins) {
+	if amt.IsZero() {
+		return
+	}
+	sk.setSupply(ctx, sk.GetSupply(ctx).Plus(amt))
+}
+
+// Deflate records the destruction of coins, panics if more coins are destroyed
+// than exist
+func (sk SupplyKeeper) Deflate(ctx sdk.Context, amt sdk.Coins) {
+	if amt.IsZero() {
+		return
+	}
+	supply := sk.GetSupply(ctx).Minus(amt)
+	if !supply.IsNotNegative() {
+		panic(fmt.Sprintf("burned %v exceeds the supply %v", amt, sk.GetSupply(ctx)))
+	}
+	sk.setSupply(ctx, supply)
+}
+
+//______________________________________________________________________________________________
+
+// CoinHolder returns the coins a module holds outside of any account
+type CoinHolder func(ctx sdk.Context) sdk.Coins
+
+// sum of the coins of every account and the coins held by the modules
+func totalCoins(ctx sdk.Context, am auth.AccountMapper, holders []CoinHolder) (total sdk.Coins) {
+	am.IterateAccounts(ctx, func(acc auth.Account) (stop bool) {
+		total = total.Plus(acc.GetCoins())
+		return false
+	})
+	for _, holder := range holders {
+		total = total.Plus(holder(ctx))
+	}
+	return total
+}
+
+// InitSupply sets the supply to the coins in existence at genesis, that is
+// the coins of every account and the coins held by the modules
+func InitSupply(ctx sdk.Context, k Keeper, holders ...CoinHolder) {
+	k.sk.setSupply(ctx, totalCoins(ctx, k.am, holders))
+}
+
+// SupplyInvariant checks that the coins of every account and the coins held
+// by the modules add up to the recorded supply
+func SupplyInvariant(k Keeper, holders ...CoinHolder) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		total := totalCoins(ctx, k.am, holders)
+		supply := k.sk.GetSupply(ctx)
+		if !total.IsEqual(supply) {
+			return fmt.Errorf("total coins %v do not match the recorded supply %v", total, supply)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	distribution "github.com/cosmos/cosmos-sdk/x/fee_distribution"
+)
+
+// create withdraw delegator rewards command
+func GetCmdWithdrawDelegatorRewards(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraw-rewards",
+		Short: "withdraw the fee rewards of all delegations of the signer",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			delegatorAddr, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			msg := distribution.NewMsgWithdrawDelegatorRewards(delegatorAddr)
+
+			// build and sign the transaction, then broadcast to Tendermint
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+	return cmd
+}
+
+// create withdraw validator rewards command
+func GetCmdWithdrawValidatorRewards(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraw-validator-rewards",
+		Short: "withdraw the commission and self-delegation rewards of the signing validator owner",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			validatorAddr, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			msg := distribution.NewMsgWithdrawValidatorRewards(validatorAddr)
+
+			// build and sign the transaction, then broadcast to Tendermint
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+	return cmd
+}
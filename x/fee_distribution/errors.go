@@ -0,0 +1,44 @@
+//nolint
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Local code type
+type CodeType = sdk.CodeType
+
+const (
+	// Default distribution codespace
+	DefaultCodespace sdk.CodespaceType = 11
+
+	CodeInvalidInput CodeType = 103
+)
+
+func ErrNilDelegatorAddr(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeInvalidInput, "Delegator address is nil")
+}
+func ErrNilValidatorAddr(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeInvalidInput, "Validator address is nil")
+}
+
+func codeToDefaultMsg(code CodeType) string {
+	switch code {
+	case CodeInvalidInput:
+		return "Invalid Input"
+	default:
+		return sdk.CodeToDefaultMsg(code)
+	}
+}
+
+func msgOrDefaultMsg(msg string, code CodeType) string {
+	if msg != "" {
+		return msg
+	}
+	return codeToDefaultMsg(code)
+}
+
+func newError(codespace sdk.CodespaceType, code CodeType, msg string) sdk.Error {
+	msg = msgOrDefaultMsg(msg, code)
+	return sdk.NewError(codespace, code, msg)
+}
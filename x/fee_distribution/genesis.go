@@ -0,0 +1,50 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all fee distribution state that must be provided at genesis
+type GenesisState struct {
+	FeePool            FeePool             `json:"fee_pool"`
+	Params             Params              `json:"params"`
+	ValidatorDistInfos []ValidatorDistInfo `json:"validator_dist_infos"`
+	DelegatorDistInfos []DelegatorDistInfo `json:"delegator_dist_infos"`
+}
+
+func NewGenesisState(feePool FeePool, params Params) GenesisState {
+	return GenesisState{
+		FeePool: feePool,
+		Params:  params,
+	}
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		FeePool: InitialFeePool(),
+		Params:  DefaultParams(),
+	}
+}
+
+// InitGenesis - store genesis parameters
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	k.setFeePool(ctx, data.FeePool)
+	k.setParams(ctx, data.Params)
+	for _, info := range data.ValidatorDistInfos {
+		k.setValidatorDistInfo(ctx, info)
+	}
+	for _, info := range data.DelegatorDistInfos {
+		k.setDelegatorDistInfo(ctx, info)
+	}
+}
+
+// WriteGenesis - output genesis parameters
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	return GenesisState{
+		FeePool:            k.GetFeePool(ctx),
+		Params:             k.GetParams(ctx),
+		ValidatorDistInfos: k.getAllValidatorDistInfos(ctx),
+		DelegatorDistInfos: k.getAllDelegatorDistInfos(ctx),
+	}
+}
@@ -0,0 +1,53 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		// NOTE msg already has validate basic run
+		switch msg := msg.(type) {
+		case MsgWithdrawDelegatorRewards:
+			return handleMsgWithdrawDelegatorRewards(ctx, msg, k)
+		case MsgWithdrawValidatorRewards:
+			return handleMsgWithdrawValidatorRewards(ctx, msg, k)
+		default:
+			return sdk.ErrTxDecode("invalid message parse in distribution module").Result()
+		}
+	}
+}
+
+func handleMsgWithdrawDelegatorRewards(ctx sdk.Context, msg MsgWithdrawDelegatorRewards, k Keeper) sdk.Result {
+	if ctx.IsCheckTx() {
+		return sdk.Result{}
+	}
+
+	rewards := k.withdrawDelegatorRewards(ctx, msg.DelegatorAddr)
+
+	tags := sdk.NewTags(
+		"action", []byte("withdrawDelegatorRewards"),
+		"delegator", msg.DelegatorAddr.Bytes(),
+		"rewards", []byte(rewards.String()),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+func handleMsgWithdrawValidatorRewards(ctx sdk.Context, msg MsgWithdrawValidatorRewards, k Keeper) sdk.Result {
+	if ctx.IsCheckTx() {
+		return sdk.Result{}
+	}
+
+	rewards := k.withdrawValidatorRewards(ctx, msg.ValidatorAddr)
+
+	tags := sdk.NewTags(
+		"action", []byte("withdrawValidatorRewards"),
+		"validator", msg.ValidatorAddr.Bytes(),
+		"rewards", []byte(rewards.String()),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
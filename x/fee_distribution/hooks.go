@@ -0,0 +1,31 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Hooks - the staking hooks of the fee distribution keeper
+type Hooks struct {
+	k Keeper
+}
+
+var _ sdk.StakingHooks = Hooks{}
+
+// get the staking hooks which keep the delegation rewards up to date
+func (k Keeper) Hooks() Hooks { return Hooks{k} }
+
+// the rewards of a delegation are calculated from its current shares, so
+// they are withdrawn before the shares change
+func (h Hooks) BeforeDelegationSharesModified(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address) {
+	if h.k.delegationSet.Delegation(ctx, delegatorAddr, validatorAddr) == nil {
+		h.k.initDelegationReward(ctx, delegatorAddr, validatorAddr)
+		return
+	}
+	h.k.withdrawDelegationReward(ctx, delegatorAddr, validatorAddr)
+}
+
+// the rewards of a removed delegation have been withdrawn before its shares
+// were removed, its distribution info is no longer needed
+func (h Hooks) OnDelegationRemoved(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address) {
+	h.k.removeDelegatorDistInfo(ctx, delegatorAddr, validatorAddr)
+}
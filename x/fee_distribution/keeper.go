@@ -1,91 +1,153 @@
-package stake
-
-//// keeper of the staking store
-//type Keeper struct {
-//storeKey   sdk.StoreKey
-//cdc        *wire.Codec
-//coinKeeper bank.Keeper
-
-//// codespace
-//codespace sdk.CodespaceType
-//}
-
-//func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, codespace sdk.CodespaceType) Keeper {
-//keeper := Keeper{
-//storeKey:   key,
-//cdc:        cdc,
-//coinKeeper: ck,
-//codespace:  codespace,
-//}
-//return keeper
-//}
-
-////_________________________________________________________________________
-
-//// cummulative power of the non-absent prevotes
-//func (k Keeper) GetTotalPrecommitVotingPower(ctx sdk.Context) sdk.Rat {
-//store := ctx.KVStore(k.storeKey)
-
-//// get absent prevote indexes
-//absents := ctx.AbsentValidators()
-
-//TotalPower := sdk.ZeroRat()
-//i := int32(0)
-//iterator := store.SubspaceIterator(ValidatorsBondedKey)
-//for ; iterator.Valid(); iterator.Next() {
-
-//skip := false
-//for j, absentIndex := range absents {
-//if absentIndex > i {
-//break
-//}
-
-//// if non-voting validator found, skip adding its power
-//if absentIndex == i {
-//absents = append(absents[:j], absents[j+1:]...) // won't need again
-//skip = true
-//break
-//}
-//}
-//if skip {
-//continue
-//}
-
-//bz := iterator.Value()
-//var validator Validator
-//k.cdc.MustUnmarshalBinary(bz, &validator)
-//TotalPower = TotalPower.Add(validator.Power)
-//i++
-//}
-//iterator.Close()
-//return TotalPower
-//}
-
-////_______________________________________________________________________
-
-//// XXX TODO trim functionality
-
-//// retrieve all the power changes which occur after a height
-//func (k Keeper) GetPowerChangesAfterHeight(ctx sdk.Context, earliestHeight int64) (pcs []PowerChange) {
-//store := ctx.KVStore(k.storeKey)
-
-//iterator := store.SubspaceIterator(PowerChangeKey) //smallest to largest
-//for ; iterator.Valid(); iterator.Next() {
-//pcBytes := iterator.Value()
-//var pc PowerChange
-//k.cdc.MustUnmarshalBinary(pcBytes, &pc)
-//if pc.Height < earliestHeight {
-//break
-//}
-//pcs = append(pcs, pc)
-//}
-//iterator.Close()
-//return
-//}
-
-//// set a power change
-//func (k Keeper) setPowerChange(ctx sdk.Context, pc PowerChange) {
-//store := ctx.KVStore(k.storeKey)
-//b := k.cdc.MustMarshalBinary(pc)
-//store.Set(GetPowerChangeKey(pc.Height), b)
-//}
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// keeper of the fee distribution store
+type Keeper struct {
+	storeKey            sdk.StoreKey
+	cdc                 *wire.Codec
+	coinKeeper          bank.Keeper
+	feeCollectionKeeper auth.FeeCollectionKeeper
+	validatorSet        sdk.ValidatorSet
+	delegationSet       sdk.DelegationSet
+	paramSpace          params.Subspace
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, fck auth.FeeCollectionKeeper,
+	vs sdk.ValidatorSet, ds sdk.DelegationSet, paramSpace params.Subspace, codespace sdk.CodespaceType) Keeper {
+
+	keeper := Keeper{
+		storeKey:            key,
+		cdc:                 cdc,
+		coinKeeper:          ck,
+		feeCollectionKeeper: fck,
+		validatorSet:        vs,
+		delegationSet:       ds,
+		paramSpace:          paramSpace.WithParamSet(&Params{}),
+		codespace:           codespace,
+	}
+	return keeper
+}
+
+//_________________________________________________________________________
+
+// load/save the global fee pool
+func (k Keeper) GetFeePool(ctx sdk.Context) (feePool FeePool) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(FeePoolKey)
+	if b == nil {
+		panic("Stored fee pool should not have been nil")
+	}
+	k.cdc.MustUnmarshalBinary(b, &feePool)
+	return
+}
+
+func (k Keeper) setFeePool(ctx sdk.Context, feePool FeePool) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(feePool)
+	store.Set(FeePoolKey, b)
+}
+
+// HeldCoins returns the fees which have been allocated but not yet withdrawn,
+// the fractional amounts of all pools add up to whole coins
+func (k Keeper) HeldCoins(ctx sdk.Context) sdk.Coins {
+	held := k.GetFeePool(ctx).CommunityPool
+	for _, info := range k.getAllValidatorDistInfos(ctx) {
+		held = held.Plus(info.Pool).Plus(info.PoolCommission)
+	}
+	whole, _ := held.TruncateDecimal()
+	return whole
+}
+
+//_________________________________________________________________________
+
+// load/save the global fee distribution params
+func (k Keeper) GetParams(ctx sdk.Context) (params Params) {
+	k.paramSpace.GetParamSet(ctx, &params)
+	return
+}
+
+func (k Keeper) setParams(ctx sdk.Context, params Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
+//_________________________________________________________________________
+
+// get the distribution info of a validator, a validator which has not been
+// allocated any fees yet has an empty distribution info
+func (k Keeper) GetValidatorDistInfo(ctx sdk.Context, validatorAddr sdk.Address) (info ValidatorDistInfo) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(GetValidatorDistInfoKey(validatorAddr))
+	if b == nil {
+		return NewValidatorDistInfo(validatorAddr)
+	}
+	k.cdc.MustUnmarshalBinary(b, &info)
+	return
+}
+
+func (k Keeper) setValidatorDistInfo(ctx sdk.Context, info ValidatorDistInfo) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(info)
+	store.Set(GetValidatorDistInfoKey(info.ValidatorAddr), b)
+}
+
+// load all validator distribution infos used during genesis dump
+func (k Keeper) getAllValidatorDistInfos(ctx sdk.Context) (infos []ValidatorDistInfo) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, ValidatorDistInfoKey)
+	for ; iterator.Valid(); iterator.Next() {
+		var info ValidatorDistInfo
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &info)
+		infos = append(infos, info)
+	}
+	iterator.Close()
+	return infos
+}
+
+//_________________________________________________________________________
+
+// get the distribution info of a delegation
+func (k Keeper) GetDelegatorDistInfo(ctx sdk.Context, delegatorAddr,
+	validatorAddr sdk.Address) (info DelegatorDistInfo, found bool) {
+
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(GetDelegatorDistInfoKey(delegatorAddr, validatorAddr, k.cdc))
+	if b == nil {
+		return info, false
+	}
+	k.cdc.MustUnmarshalBinary(b, &info)
+	return info, true
+}
+
+func (k Keeper) setDelegatorDistInfo(ctx sdk.Context, info DelegatorDistInfo) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(info)
+	store.Set(GetDelegatorDistInfoKey(info.DelegatorAddr, info.ValidatorAddr, k.cdc), b)
+}
+
+func (k Keeper) removeDelegatorDistInfo(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetDelegatorDistInfoKey(delegatorAddr, validatorAddr, k.cdc))
+}
+
+// load all delegator distribution infos used during genesis dump
+func (k Keeper) getAllDelegatorDistInfos(ctx sdk.Context) (infos []DelegatorDistInfo) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, DelegatorDistInfoKey)
+	for ; iterator.Valid(); iterator.Next() {
+		var info DelegatorDistInfo
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &info)
+		infos = append(infos, info)
+	}
+	iterator.Close()
+	return infos
+}
@@ -0,0 +1,33 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+//nolint
+var (
+	// Keys for store prefixes
+	FeePoolKey           = []byte{0x00} // key for the global fee pool
+	ValidatorDistInfoKey = []byte{0x02} // prefix for each key to the distribution info of a validator
+	DelegatorDistInfoKey = []byte{0x03} // prefix for each key to the distribution info of a delegation
+)
+
+// get the key for the distribution info of a validator
+func GetValidatorDistInfoKey(validatorAddr sdk.Address) []byte {
+	return append(ValidatorDistInfoKey, validatorAddr.Bytes()...)
+}
+
+// get the key for the distribution info of a delegation
+func GetDelegatorDistInfoKey(delegatorAddr, validatorAddr sdk.Address, cdc *wire.Codec) []byte {
+	return append(GetDelegatorDistInfosKey(delegatorAddr, cdc), validatorAddr.Bytes()...)
+}
+
+// get the prefix for the distribution info of all delegations of a delegator
+func GetDelegatorDistInfosKey(delegatorAddr sdk.Address, cdc *wire.Codec) []byte {
+	res, err := cdc.MarshalBinary(&delegatorAddr)
+	if err != nil {
+		panic(err)
+	}
+	return append(DelegatorDistInfoKey, res...)
+}
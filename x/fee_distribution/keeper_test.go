@@ -1,31 +1,163 @@
-package stake
-
-//// test if is a gotValidator from the last update
-//func TestGetTotalPrecommitVotingPower(t *testing.T) {
-//ctx, _, keeper := createTestInput(t, false, 0)
-
-//amts := []int64{10000, 1000, 100, 10, 1}
-//var candidatesIn [5]Candidate
-//for i, amt := range amts {
-//candidatesIn[i] = NewCandidate(addrVals[i], pks[i], Description{})
-//candidatesIn[i].BondedShares = sdk.NewRat(amt)
-//candidatesIn[i].DelegatorShares = sdk.NewRat(amt)
-//keeper.setCandidate(ctx, candidatesIn[i])
-//}
-
-//// test that an empty gotValidator set doesn't have any gotValidators
-//gotValidators := keeper.GetValidators(ctx)
-//assert.Equal(t, 5, len(gotValidators))
-
-//totPow := keeper.GetTotalPrecommitVotingPower(ctx)
-//exp := sdk.NewRat(11111)
-//assert.True(t, exp.Equal(totPow), "exp %v, got %v", exp, totPow)
-
-//// set absent gotValidators to be the 1st and 3rd record sorted by pubKey address
-//ctx = ctx.WithAbsentValidators([]int32{1, 3})
-//totPow = keeper.GetTotalPrecommitVotingPower(ctx)
-
-//// XXX verify that this order should infact exclude these two records
-//exp = sdk.NewRat(11100)
-//assert.True(t, exp.Equal(totPow), "exp %v, got %v", exp, totPow)
-//}
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestAllocateFees(t *testing.T) {
+	ctx, _, sk, keeper, keyFee := createTestInput(t)
+	stakeHandler := stake.NewHandler(sk)
+
+	// no fees collected, nothing to allocate
+	keeper.AllocateFees(ctx)
+	require.True(t, keeper.GetFeePool(ctx).CommunityPool.IsZero())
+
+	got := stakeHandler(ctx, newTestMsgCreateValidator(addrs[0], pks[0], 100))
+	require.True(t, got.IsOK(), "%v", got)
+	got = stakeHandler(ctx, newTestMsgCreateValidator(addrs[1], pks[1], 300))
+	require.True(t, got.IsOK(), "%v", got)
+
+	// 2% community tax, the rest split by power
+	setCollectedFees(ctx, keyFee, sdk.Coins{{"steak", 1000}})
+	keeper.AllocateFees(ctx)
+	require.True(t, keeper.feeCollectionKeeper.GetCollectedFees(ctx).IsZero())
+
+	communityPool := keeper.GetFeePool(ctx).CommunityPool
+	require.True(t, sdk.NewRat(20).Equal(communityPool.AmountOf("steak")), "%v", communityPool)
+
+	info := keeper.GetValidatorDistInfo(ctx, addrs[0])
+	require.True(t, sdk.NewRat(245).Equal(info.Pool.AmountOf("steak")), "%v", info.Pool)
+	require.True(t, sdk.NewRat(245, 100).Equal(info.RewardsPerShare.AmountOf("steak")), "%v", info.RewardsPerShare)
+	require.True(t, info.PoolCommission.IsZero())
+
+	info = keeper.GetValidatorDistInfo(ctx, addrs[1])
+	require.True(t, sdk.NewRat(735).Equal(info.Pool.AmountOf("steak")), "%v", info.Pool)
+}
+
+func TestWithdrawRewards(t *testing.T) {
+	ctx, ck, sk, keeper, keyFee := createTestInput(t)
+	stakeHandler := stake.NewHandler(sk)
+	handler := NewHandler(keeper)
+	validatorAddr, delegatorAddr := addrs[0], addrs[2]
+
+	got := stakeHandler(ctx, newTestMsgCreateValidator(validatorAddr, pks[0], 100))
+	require.True(t, got.IsOK(), "%v", got)
+	got = stakeHandler(ctx, newTestMsgCreateValidator(addrs[1], pks[1], 300))
+	require.True(t, got.IsOK(), "%v", got)
+
+	setCollectedFees(ctx, keyFee, sdk.Coins{{"steak", 1000}})
+	keeper.AllocateFees(ctx)
+
+	// the validator withdraws the rewards of its self-delegation
+	got = handler(ctx, NewMsgWithdrawValidatorRewards(validatorAddr))
+	require.True(t, got.IsOK(), "%v", got)
+	require.Equal(t, int64(initCoins-100+245), ck.GetCoins(ctx, validatorAddr).AmountOf("steak"))
+	require.True(t, keeper.GetValidatorDistInfo(ctx, validatorAddr).Pool.IsZero())
+
+	// a new delegation is not entitled to rewards allocated before it existed
+	got = stakeHandler(ctx, newTestMsgDelegate(delegatorAddr, validatorAddr, 100))
+	require.True(t, got.IsOK(), "%v", got)
+	got = handler(ctx, NewMsgWithdrawDelegatorRewards(delegatorAddr))
+	require.True(t, got.IsOK(), "%v", got)
+	require.Equal(t, int64(initCoins-100), ck.GetCoins(ctx, delegatorAddr).AmountOf("steak"))
+
+	// 980 to validators, 200 of 500 bonded shares are with the validator
+	setCollectedFees(ctx, keyFee, sdk.Coins{{"steak", 1000}})
+	keeper.AllocateFees(ctx)
+	got = handler(ctx, NewMsgWithdrawDelegatorRewards(delegatorAddr))
+	require.True(t, got.IsOK(), "%v", got)
+	require.Equal(t, int64(initCoins-100+196), ck.GetCoins(ctx, delegatorAddr).AmountOf("steak"))
+
+	// withdrawing again yields nothing
+	got = handler(ctx, NewMsgWithdrawDelegatorRewards(delegatorAddr))
+	require.True(t, got.IsOK(), "%v", got)
+	require.Equal(t, int64(initCoins-100+196), ck.GetCoins(ctx, delegatorAddr).AmountOf("steak"))
+}
+
+func TestWithdrawRewardsRemainder(t *testing.T) {
+	ctx, ck, sk, keeper, keyFee := createTestInput(t)
+	handler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+	validatorAddr, delegatorAddr := addrs[0], addrs[2]
+
+	got := stakeHandler(ctx, newTestMsgCreateValidator(validatorAddr, pks[0], 100))
+	require.True(t, got.IsOK(), "%v", got)
+	got = stakeHandler(ctx, newTestMsgDelegate(delegatorAddr, validatorAddr, 100))
+	require.True(t, got.IsOK(), "%v", got)
+
+	// 980.98 to the validator, the delegator is owed half of it
+	setCollectedFees(ctx, keyFee, sdk.Coins{{"steak", 1001}})
+	keeper.AllocateFees(ctx)
+	require.True(t, sdk.NewRat(2002, 100).Equal(keeper.GetFeePool(ctx).CommunityPool.AmountOf("steak")))
+
+	// the fraction of a coin which is not paid out goes to the community pool
+	got = handler(ctx, NewMsgWithdrawDelegatorRewards(delegatorAddr))
+	require.True(t, got.IsOK(), "%v", got)
+	require.Equal(t, int64(initCoins-100+490), ck.GetCoins(ctx, delegatorAddr).AmountOf("steak"))
+	info := keeper.GetValidatorDistInfo(ctx, validatorAddr)
+	require.True(t, sdk.NewRat(49049, 100).Equal(info.Pool.AmountOf("steak")), "%v", info.Pool)
+	require.True(t, sdk.NewRat(2051, 100).Equal(keeper.GetFeePool(ctx).CommunityPool.AmountOf("steak")))
+	require.Equal(t, sdk.Coins{{"steak", 511}}, keeper.HeldCoins(ctx))
+}
+
+func TestWithdrawOnDelegationChange(t *testing.T) {
+	ctx, ck, sk, keeper, keyFee := createTestInput(t)
+	stakeHandler := stake.NewHandler(sk)
+	validatorAddr, delegatorAddr := addrs[0], addrs[2]
+
+	got := stakeHandler(ctx, newTestMsgCreateValidator(validatorAddr, pks[0], 100))
+	require.True(t, got.IsOK(), "%v", got)
+	got = stakeHandler(ctx, newTestMsgDelegate(delegatorAddr, validatorAddr, 100))
+	require.True(t, got.IsOK(), "%v", got)
+
+	setCollectedFees(ctx, keyFee, sdk.Coins{{"steak", 1000}})
+	keeper.AllocateFees(ctx)
+
+	// adding to the delegation pays out the pending rewards first
+	got = stakeHandler(ctx, newTestMsgDelegate(delegatorAddr, validatorAddr, 10))
+	require.True(t, got.IsOK(), "%v", got)
+	require.Equal(t, int64(initCoins-110+490), ck.GetCoins(ctx, delegatorAddr).AmountOf("steak"))
+
+	// so does unbonding
+	setCollectedFees(ctx, keyFee, sdk.Coins{{"steak", 1050}})
+	keeper.AllocateFees(ctx)
+	got = stakeHandler(ctx, stake.NewMsgUnbond(delegatorAddr, validatorAddr, "MAX"))
+	require.True(t, got.IsOK(), "%v", got)
+	require.Equal(t, int64(initCoins-110+490+539), ck.GetCoins(ctx, delegatorAddr).AmountOf("steak"))
+
+	// the rest of the validator pool belongs to the self-delegation
+	info := keeper.GetValidatorDistInfo(ctx, validatorAddr)
+	require.True(t, sdk.NewRat(490+490).Equal(info.Pool.AmountOf("steak")), "%v", info.Pool)
+
+	// the distribution info of the fully unbonded delegation is removed
+	_, found := keeper.GetDelegatorDistInfo(ctx, delegatorAddr, validatorAddr)
+	require.False(t, found)
+	require.Equal(t, 1, len(keeper.getAllDelegatorDistInfos(ctx)))
+	require.True(t, keeper.withdrawDelegationReward(ctx, delegatorAddr, validatorAddr).IsZero())
+	_, found = keeper.GetDelegatorDistInfo(ctx, delegatorAddr, validatorAddr)
+	require.False(t, found)
+
+	// and a new delegation earns nothing of the past rewards
+	got = stakeHandler(ctx, newTestMsgDelegate(delegatorAddr, validatorAddr, 100))
+	require.True(t, got.IsOK(), "%v", got)
+	require.True(t, keeper.withdrawDelegationReward(ctx, delegatorAddr, validatorAddr).IsZero())
+}
+
+func TestDecCoins(t *testing.T) {
+	coins := NewDecCoins(sdk.Coins{{"atom", 10}, {"steak", 5}})
+	require.True(t, sdk.NewRat(10).Equal(coins.AmountOf("atom")))
+	require.True(t, coins.AmountOf("photon").IsZero())
+
+	third := coins.QuoRat(sdk.NewRat(3))
+	truncated, change := third.TruncateDecimal()
+	require.Equal(t, sdk.Coins{{"atom", 3}, {"steak", 1}}, truncated)
+	require.True(t, change.AmountOf("steak").GT(sdk.NewRat(666, 1000)), "%v", change)
+	require.True(t, change.AmountOf("steak").LT(sdk.NewRat(2, 3)), "%v", change)
+
+	require.True(t, coins.Minus(coins).IsZero())
+}
@@ -1,72 +1,149 @@
-package stake
+package distribution
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
-// burn burn burn
-func BurnFeeHandler(ctx sdk.Context, _ sdk.Tx, collectedFees sdk.Coins) {}
-
-//// Handle fee distribution to the validators and delegators
-//func (k Keeper) FeeHandler(ctx sdk.Context, collectedFees sdk.Coins) {
-//pool := k.GetPool(ctx)
-//params := k.GetParams(ctx)
-
-//// XXX determine
-//candidate := NewCandidate(addrs[0], pks[0], Description{})
-
-//// calculate the proposer reward
-//precommitPower := k.GetTotalPrecommitVotingPower(ctx)
-//toProposer := coinsMulRat(collectedFees, (sdk.NewRat(1, 100).Add(sdk.NewRat(4, 100).Mul(precommitPower).Quo(pool.BondedShares))))
-//candidate.ProposerRewardPool = candidate.ProposerRewardPool.Plus(toProposer)
-
-//toReservePool := coinsMulRat(collectedFees, params.ReservePoolFee)
-//pool.FeeReservePool = pool.FeeReservePool.Plus(toReservePool)
-
-//distributedReward := (collectedFees.Minus(toProposer)).Minus(toReservePool)
-//pool.FeePool = pool.FeePool.Plus(distributedReward)
-//pool.FeeSumReceived = pool.FeeSumReceived.Plus(distributedReward)
-//pool.FeeRecent = distributedReward
-
-//// lastly update the FeeRecent term
-//pool.FeeRecent = collectedFees
-
-//k.setPool(ctx, pool)
-//}
-
-//func coinsMulRat(coins sdk.Coins, rat sdk.Rat) sdk.Coins {
-//var res sdk.Coins
-//for _, coin := range coins {
-//coinMulAmt := rat.Mul(sdk.NewRat(coin.Amount)).Evaluate()
-//coinMul := sdk.Coins{{coin.Denom, coinMulAmt}}
-//res = res.Plus(coinMul)
-//}
-//return res
-//}
-
-////____________________________________________________________________________-
-
-//// calculate adjustment changes for a candidate at a height
-//func CalculateAdjustmentChange(candidate Candidate, pool Pool, denoms []string, height int64) (Candidate, Pool) {
-
-//heightRat := sdk.NewRat(height)
-//lastHeightRat := sdk.NewRat(height - 1)
-//candidateFeeCount := candidate.BondedShares.Mul(heightRat)
-//poolFeeCount := pool.BondedShares.Mul(heightRat)
-
-//for i, denom := range denoms {
-//poolFeeSumReceived := sdk.NewRat(pool.FeeSumReceived.AmountOf(denom))
-//poolFeeRecent := sdk.NewRat(pool.FeeRecent.AmountOf(denom))
-//// calculate simple and projected pools
-//simplePool := candidateFeeCount.Quo(poolFeeCount).Mul(poolFeeSumReceived)
-//calc1 := candidate.PrevBondedShares.Mul(lastHeightRat).Quo(pool.PrevBondedShares.Mul(lastHeightRat)).Mul(poolFeeRecent)
-//calc2 := candidate.BondedShares.Quo(pool.BondedShares).Mul(poolFeeRecent)
-//projectedPool := calc1.Add(calc2)
-
-//AdjustmentChange := simplePool.Sub(projectedPool)
-//candidate.FeeAdjustments[i] = candidate.FeeAdjustments[i].Add(AdjustmentChange)
-//pool.FeeAdjustments[i] = pool.FeeAdjustments[i].Add(AdjustmentChange)
-//}
-
-//return candidate, pool
-//}
+// move the fees collected since the last allocation into the fee pool: the
+// community tax goes to the community pool and the rest is split between the
+// bonded validators by power
+func (k Keeper) AllocateFees(ctx sdk.Context) {
+	collectedFees := k.feeCollectionKeeper.GetCollectedFees(ctx)
+	if collectedFees.IsZero() {
+		return
+	}
+	k.feeCollectionKeeper.ClearCollectedFees(ctx)
+
+	feePool := k.GetFeePool(ctx)
+	params := k.GetParams(ctx)
+	fees := NewDecCoins(collectedFees)
+	toValidators := fees.Minus(fees.MulRat(params.CommunityTax))
+
+	distributed := DecCoins{}
+	totalPower := k.validatorSet.TotalPower(ctx)
+	if totalPower.GT(sdk.ZeroRat()) {
+		k.validatorSet.IterateValidatorsBonded(ctx, func(_ int64, validator sdk.Validator) (stop bool) {
+			reward := toValidators.MulRat(validator.GetPower().Quo(totalPower))
+			k.allocateToValidator(ctx, validator, reward)
+			distributed = distributed.Plus(reward)
+			return false
+		})
+	}
+
+	// the community pool receives the tax as well as any rounding remainder
+	feePool.CommunityPool = feePool.CommunityPool.Plus(fees.Minus(distributed))
+	k.setFeePool(ctx, feePool)
+}
+
+// split the reward of a validator into its commission and the pool of its delegators
+func (k Keeper) allocateToValidator(ctx sdk.Context, validator sdk.Validator, reward DecCoins) {
+	info := k.GetValidatorDistInfo(ctx, validator.GetOwner())
+
+	// without delegator shares there is nobody to share the reward with
+	delShares := validator.GetDelegatorShares()
+	if !delShares.GT(sdk.ZeroRat()) {
+		info.PoolCommission = info.PoolCommission.Plus(reward)
+		k.setValidatorDistInfo(ctx, info)
+		return
+	}
+
+	commission := reward.MulRat(validator.GetCommission())
+	toDelegators := reward.Minus(commission)
+	info.PoolCommission = info.PoolCommission.Plus(commission)
+	info.Pool = info.Pool.Plus(toDelegators)
+	info.RewardsPerShare = info.RewardsPerShare.Plus(toDelegators.QuoRat(delShares))
+	k.setValidatorDistInfo(ctx, info)
+}
+
+//_________________________________________________________________________
+
+// withdraw the rewards a delegation has earned since its last withdrawal,
+// only whole coins are paid out and the remainder goes to the community pool
+func (k Keeper) withdrawDelegationReward(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address) sdk.Coins {
+	valInfo := k.GetValidatorDistInfo(ctx, validatorAddr)
+	delInfo, found := k.GetDelegatorDistInfo(ctx, delegatorAddr, validatorAddr)
+	if !found {
+		delInfo = DelegatorDistInfo{
+			DelegatorAddr: delegatorAddr,
+			ValidatorAddr: validatorAddr,
+		}
+	}
+
+	// without a delegation there is nothing to withdraw nor to keep track of
+	delegation := k.delegationSet.Delegation(ctx, delegatorAddr, validatorAddr)
+	if delegation == nil {
+		if found {
+			k.removeDelegatorDistInfo(ctx, delegatorAddr, validatorAddr)
+		}
+		return nil
+	}
+
+	owed := valInfo.RewardsPerShare.Minus(delInfo.RewardsPerShare).MulRat(delegation.GetBondShares())
+	reward, change := owed.TruncateDecimal()
+	valInfo.Pool = valInfo.Pool.Minus(owed)
+	k.setValidatorDistInfo(ctx, valInfo)
+
+	// the fractional remainder of the reward goes to the community pool
+	if !change.IsZero() {
+		feePool := k.GetFeePool(ctx)
+		feePool.CommunityPool = feePool.CommunityPool.Plus(change)
+		k.setFeePool(ctx, feePool)
+	}
+
+	delInfo.RewardsPerShare = valInfo.RewardsPerShare
+	k.setDelegatorDistInfo(ctx, delInfo)
+
+	if !reward.IsZero() {
+		_, _, err := k.coinKeeper.AddCoins(ctx, delegatorAddr, reward)
+		if err != nil {
+			panic(err) // adding coins to an account cannot fail
+		}
+	}
+	return reward
+}
+
+// start tracking the rewards of a delegation about to be created, which is
+// owed nothing of the rewards earned by the validator so far
+func (k Keeper) initDelegationReward(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address) {
+	valInfo := k.GetValidatorDistInfo(ctx, validatorAddr)
+	k.setDelegatorDistInfo(ctx, DelegatorDistInfo{
+		DelegatorAddr:   delegatorAddr,
+		ValidatorAddr:   validatorAddr,
+		RewardsPerShare: valInfo.RewardsPerShare,
+	})
+}
+
+// withdraw the rewards of all delegations of a delegator
+func (k Keeper) withdrawDelegatorRewards(ctx sdk.Context, delegatorAddr sdk.Address) (rewards sdk.Coins) {
+
+	// collect the validators first, withdrawing writes to the store
+	var validatorAddrs []sdk.Address
+	k.delegationSet.IterateDelegators(ctx, delegatorAddr, func(_ int64, delegation sdk.Delegation) (stop bool) {
+		validatorAddrs = append(validatorAddrs, delegation.GetValidator())
+		return false
+	})
+
+	for _, validatorAddr := range validatorAddrs {
+		rewards = rewards.Plus(k.withdrawDelegationReward(ctx, delegatorAddr, validatorAddr))
+	}
+	return rewards
+}
+
+// withdraw the commission of a validator as well as the rewards of its
+// self-delegation to the validator owner
+func (k Keeper) withdrawValidatorRewards(ctx sdk.Context, validatorAddr sdk.Address) (rewards sdk.Coins) {
+	rewards = k.withdrawDelegationReward(ctx, validatorAddr, validatorAddr)
+
+	info := k.GetValidatorDistInfo(ctx, validatorAddr)
+	commission, change := info.PoolCommission.TruncateDecimal()
+	info.PoolCommission = change
+	k.setValidatorDistInfo(ctx, info)
+
+	if !commission.IsZero() {
+		_, _, err := k.coinKeeper.AddCoins(ctx, validatorAddr, commission)
+		if err != nil {
+			panic(err) // adding coins to an account cannot fail
+		}
+	}
+	return rewards.Plus(commission)
+}
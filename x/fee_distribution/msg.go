@@ -0,0 +1,93 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+var msgCdc = wire.NewCodec()
+
+// name to identify transaction types
+const MsgType = "distr"
+
+// verify interface at compile time
+var _, _ sdk.Msg = &MsgWithdrawDelegatorRewards{}, &MsgWithdrawValidatorRewards{}
+
+// MsgWithdrawDelegatorRewards - withdraw the rewards of all delegations of a delegator
+type MsgWithdrawDelegatorRewards struct {
+	DelegatorAddr sdk.Address `json:"delegator_addr"`
+}
+
+func NewMsgWithdrawDelegatorRewards(delegatorAddr sdk.Address) MsgWithdrawDelegatorRewards {
+	return MsgWithdrawDelegatorRewards{
+		DelegatorAddr: delegatorAddr,
+	}
+}
+
+// nolint
+func (msg MsgWithdrawDelegatorRewards) Type() string { return MsgType }
+func (msg MsgWithdrawDelegatorRewards) GetSigners() []sdk.Address {
+	return []sdk.Address{msg.DelegatorAddr}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgWithdrawDelegatorRewards) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		DelegatorAddr string `json:"delegator_addr"`
+	}{
+		DelegatorAddr: sdk.MustBech32ifyAcc(msg.DelegatorAddr),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check
+func (msg MsgWithdrawDelegatorRewards) ValidateBasic() sdk.Error {
+	if msg.DelegatorAddr == nil {
+		return ErrNilDelegatorAddr(DefaultCodespace)
+	}
+	return nil
+}
+
+//______________________________________________________________________
+
+// MsgWithdrawValidatorRewards - withdraw the commission of a validator along
+// with the rewards of its self-delegation
+type MsgWithdrawValidatorRewards struct {
+	ValidatorAddr sdk.Address `json:"validator_addr"` // address of the validator owner
+}
+
+func NewMsgWithdrawValidatorRewards(validatorAddr sdk.Address) MsgWithdrawValidatorRewards {
+	return MsgWithdrawValidatorRewards{
+		ValidatorAddr: validatorAddr,
+	}
+}
+
+// nolint
+func (msg MsgWithdrawValidatorRewards) Type() string { return MsgType }
+func (msg MsgWithdrawValidatorRewards) GetSigners() []sdk.Address {
+	return []sdk.Address{msg.ValidatorAddr}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgWithdrawValidatorRewards) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		ValidatorAddr string `json:"validator_addr"`
+	}{
+		ValidatorAddr: sdk.MustBech32ifyVal(msg.ValidatorAddr),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check
+func (msg MsgWithdrawValidatorRewards) ValidateBasic() sdk.Error {
+	if msg.ValidatorAddr == nil {
+		return ErrNilValidatorAddr(DefaultCodespace)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestMsgWithdrawDelegatorRewards(t *testing.T) {
+	tests := []struct {
+		name          string
+		delegatorAddr sdk.Address
+		expectPass    bool
+	}{
+		{"regular", addrs[0], true},
+		{"empty delegator", nil, false},
+	}
+
+	for _, tc := range tests {
+		msg := NewMsgWithdrawDelegatorRewards(tc.delegatorAddr)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+			require.Equal(t, []sdk.Address{tc.delegatorAddr}, msg.GetSigners())
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
+
+func TestMsgWithdrawValidatorRewards(t *testing.T) {
+	tests := []struct {
+		name          string
+		validatorAddr sdk.Address
+		expectPass    bool
+	}{
+		{"regular", addrs[0], true},
+		{"empty validator", nil, false},
+	}
+
+	for _, tc := range tests {
+		msg := NewMsgWithdrawValidatorRewards(tc.validatorAddr)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+			require.Equal(t, []sdk.Address{tc.validatorAddr}, msg.GetSigners())
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package distribution
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/abci/types"
+	crypto "github.com/tendermint/go-crypto"
+	dbm "github.com/tendermint/tmlibs/db"
+	"github.com/tendermint/tmlibs/log"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+var (
+	addrs = []sdk.Address{
+		testAddr("A58856F0FD53BF058B4909A21AEC019107BA6160"),
+		testAddr("A58856F0FD53BF058B4909A21AEC019107BA6161"),
+		testAddr("A58856F0FD53BF058B4909A21AEC019107BA6162"),
+	}
+	pks = []crypto.PubKey{
+		newPubKey("0B485CFC0EECC619440448436F8FC9DF40566F2369E72400281454CB552AFB50"),
+		newPubKey("0B485CFC0EECC619440448436F8FC9DF40566F2369E72400281454CB552AFB51"),
+		newPubKey("0B485CFC0EECC619440448436F8FC9DF40566F2369E72400281454CB552AFB52"),
+	}
+	initCoins int64 = 1000
+)
+
+func createTestCodec() *wire.Codec {
+	cdc := wire.NewCodec()
+	sdk.RegisterWire(cdc)
+	auth.RegisterWire(cdc)
+	bank.RegisterWire(cdc)
+	stake.RegisterWire(cdc)
+	RegisterWire(cdc)
+	wire.RegisterCrypto(cdc)
+	return cdc
+}
+
+// hogpodge of all sorts of input required for testing, the returned stake
+// keeper has the distribution hooks set
+func createTestInput(t *testing.T) (sdk.Context, bank.Keeper, stake.Keeper, Keeper, *sdk.KVStoreKey) {
+	keyAcc := sdk.NewKVStoreKey("acc")
+	keyBank := sdk.NewKVStoreKey("bank")
+	keyStake := sdk.NewKVStoreKey("stake")
+	keyFeeCollection := sdk.NewKVStoreKey("fee")
+	keyDistr := sdk.NewKVStoreKey("distr")
+	keyParams := sdk.NewKVStoreKey("params")
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyBank, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyStake, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyFeeCollection, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyDistr, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+	ctx := sdk.NewContext(ms, abci.Header{}, false, nil, log.NewNopLogger())
+	cdc := createTestCodec()
+	accountMapper := auth.NewAccountMapper(cdc, keyAcc, &auth.BaseAccount{})
+	ck := bank.NewKeeper(cdc, keyBank, accountMapper, bank.DefaultCodespace)
+	fck := auth.NewFeeCollectionKeeper(cdc, keyFeeCollection)
+	pk := params.NewKeeper(cdc, keyParams)
+	sk := stake.NewKeeper(cdc, keyStake, ck, pk.Subspace(stake.DefaultParamspace), stake.DefaultCodespace)
+	genesis := stake.DefaultGenesisState()
+	genesis.Pool.LooseUnbondedTokens = initCoins * int64(len(addrs))
+	stake.InitGenesis(ctx, sk, genesis)
+	for _, addr := range addrs {
+		ck.MintCoins(ctx, addr, sdk.Coins{
+			{sk.GetParams(ctx).BondDenom, initCoins},
+		})
+	}
+	keeper := NewKeeper(cdc, keyDistr, ck, fck, sk, sk, pk.Subspace(DefaultParamspace), DefaultCodespace)
+	InitGenesis(ctx, keeper, DefaultGenesisState())
+	sk = sk.WithHooks(keeper.Hooks())
+	return ctx, ck, sk, keeper, keyFeeCollection
+}
+
+// set the fees collected by the ante handler
+func setCollectedFees(ctx sdk.Context, keyFeeCollection *sdk.KVStoreKey, fees sdk.Coins) {
+	cdc := createTestCodec()
+	ctx.KVStore(keyFeeCollection).Set([]byte("collectedFees"), cdc.MustMarshalBinary(fees))
+}
+
+func newPubKey(pk string) (res crypto.PubKey) {
+	pkBytes, err := hex.DecodeString(pk)
+	if err != nil {
+		panic(err)
+	}
+	var pkEd crypto.PubKeyEd25519
+	copy(pkEd[:], pkBytes[:])
+	return pkEd
+}
+
+func testAddr(addr string) sdk.Address {
+	res := []byte(addr)
+	return res
+}
+
+func newTestMsgCreateValidator(address sdk.Address, pubKey crypto.PubKey, amt int64) stake.MsgCreateValidator {
+	return stake.MsgCreateValidator{
+		Description:   stake.Description{},
+		ValidatorAddr: address,
+		PubKey:        pubKey,
+		Bond:          sdk.Coin{"steak", amt},
+	}
+}
+
+func newTestMsgDelegate(delegatorAddr, validatorAddr sdk.Address, amt int64) stake.MsgDelegate {
+	return stake.MsgDelegate{
+		DelegatorAddr: delegatorAddr,
+		ValidatorAddr: validatorAddr,
+		Bond:          sdk.Coin{"steak", amt},
+	}
+}
@@ -1,107 +1,230 @@
-package stake
-
-//// GenesisState - all staking state that must be provided at genesis
-//type GenesisState struct {
-//Pool   Pool   `json:"pool"`
-//Params Params `json:"params"`
-//}
-
-//func NewGenesisState(pool Pool, params Params, candidates []Candidate, bonds []Delegation) GenesisState {
-//return GenesisState{
-//Pool:   pool,
-//Params: params,
-//}
-//}
-
-//// get raw genesis raw message for testing
-//func DefaultGenesisState() GenesisState {
-//return GenesisState{
-//Pool:   initialPool(),
-//Params: defaultParams(),
-//}
-//}
-
-//// fee information for a validator
-//type Validator struct {
-//Adjustments      []sdk.Rat `json:"fee_adjustments"`    // XXX Adjustment factors for lazy fee accounting, couples with Params.BondDenoms
-//PrevBondedShares sdk.Rat   `json:"prev_bonded_shares"` // total shares of a global hold pools
-//}
-
-////_________________________________________________________________________
-
-//// Params defines the high level settings for staking
-//type Params struct {
-//FeeDenoms      []string `json:"fee_denoms"`       // accepted fee denoms
-//ReservePoolFee sdk.Rat  `json:"reserve_pool_fee"` // percent of fees which go to reserve pool
-//}
-
-//func (p Params) equal(p2 Params) bool {
-//return p.BondDenom == p2.BondDenom &&
-//p.ReservePoolFee.Equal(p2.ReservePoolFee)
-//}
-
-//func defaultParams() Params {
-//return Params{
-//FeeDenoms:      []string{"steak"},
-//ReservePoolFee: sdk.NewRat(5, 100),
-//}
-//}
-
-////_________________________________________________________________________
-
-//// Pool - dynamic parameters of the current state
-//type Pool struct {
-//FeeReservePool   sdk.Coins `json:"fee_reserve_pool"`   // XXX reserve pool of collected fees for use by governance
-//FeePool          sdk.Coins `json:"fee_pool"`           // XXX fee pool for all the fee shares which have already been distributed
-//FeeSumReceived   sdk.Coins `json:"fee_sum_received"`   // XXX sum of all fees received, post reserve pool `json:"fee_sum_received"`
-//FeeRecent        sdk.Coins `json:"fee_recent"`         // XXX most recent fee collected
-//FeeAdjustments   []sdk.Rat `json:"fee_adjustments"`    // XXX Adjustment factors for lazy fee accounting, couples with Params.BondDenoms
-//PrevBondedShares sdk.Rat   `json:"prev_bonded_shares"` // XXX last recorded bonded shares
-//}
-
-//func (p Pool) equal(p2 Pool) bool {
-//return p.FeeReservePool.IsEqual(p2.FeeReservePool) &&
-//p.FeePool.IsEqual(p2.FeePool) &&
-//p.FeeSumReceived.IsEqual(p2.FeeSumReceived) &&
-//p.FeeRecent.IsEqual(p2.FeeRecent) &&
-//sdk.RatsEqual(p.FeeAdjustments, p2.FeeAdjustments) &&
-//p.PrevBondedShares.Equal(p2.PrevBondedShares)
-//}
-
-//// initial pool for testing
-//func initialPool() Pool {
-//return Pool{
-//FeeReservePool:   sdk.Coins(nil),
-//FeePool:          sdk.Coins(nil),
-//FeeSumReceived:   sdk.Coins(nil),
-//FeeRecent:        sdk.Coins(nil),
-//FeeAdjustments:   []sdk.Rat{sdk.ZeroRat()},
-//PrevBondedShares: sdk.ZeroRat(),
-//}
-//}
-
-////_________________________________________________________________________
-
-//// Used in calculation of fee shares, added to a queue for each block where a power change occures
-//type PowerChange struct {
-//Height      int64     `json:"height"`        // block height at change
-//Power       sdk.Rat   `json:"power"`         // total power at change
-//PrevPower   sdk.Rat   `json:"prev_power"`    // total power at previous height-1
-//FeesIn      sdk.Coins `json:"fees_in"`       // fees in at block height
-//PrevFeePool sdk.Coins `json:"prev_fee_pool"` // total fees in at previous block height
-//}
-
-////_________________________________________________________________________
-//// KEY MANAGEMENT
-
-//var (
-//// Keys for store prefixes
-//PowerChangeKey = []byte{0x09} // prefix for power change object
-//)
-
-//// get the key for the accumulated update validators
-//func GetPowerChangeKey(height int64) []byte {
-//heightBytes := make([]byte, binary.MaxVarintLen64)
-//binary.BigEndian.PutUint64(heightBytes, ^uint64(height)) // invert height (older validators first)
-//return append(PowerChangeKey, heightBytes...)
-//}
+package distribution
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// number of decimal places kept when fees are split into fractional amounts,
+// anything smaller is left undistributed
+const precision = 1000000000000000000
+
+// truncate a non-negative rational to the distribution precision
+func truncate(r sdk.Rat) sdk.Rat {
+	num := new(big.Int).Mul(r.Rat.Num(), big.NewInt(precision))
+	num.Quo(num, r.Rat.Denom())
+	return sdk.Rat{*new(big.Rat).SetFrac(num, big.NewInt(precision))}
+}
+
+// round a non-negative rational down to a whole number
+func floor(r sdk.Rat) int64 {
+	return new(big.Int).Quo(r.Rat.Num(), r.Rat.Denom()).Int64()
+}
+
+//_________________________________________________________________________
+
+// DecCoin - a coin with a fractional amount, used to account for shares of
+// fees which are not (yet) worth a whole coin
+type DecCoin struct {
+	Denom  string  `json:"denom"`
+	Amount sdk.Rat `json:"amount"`
+}
+
+// DecCoins - set of fractional coins, sorted by denom
+type DecCoins []DecCoin
+
+// convert whole coins to fractional coins
+func NewDecCoins(coins sdk.Coins) DecCoins {
+	decCoins := make(DecCoins, 0, len(coins))
+	for _, coin := range coins.Sort() {
+		if coin.Amount == 0 {
+			continue
+		}
+		decCoins = append(decCoins, DecCoin{coin.Denom, sdk.NewRat(coin.Amount)})
+	}
+	return decCoins
+}
+
+// add two sets of fractional coins, dropping any denoms which sum to zero
+func (coins DecCoins) Plus(coinsB DecCoins) DecCoins {
+	sum := make(DecCoins, 0, len(coins)+len(coinsB))
+	appendNonZero := func(coin DecCoin) {
+		if !coin.Amount.IsZero() {
+			sum = append(sum, coin)
+		}
+	}
+	i, j := 0, 0
+	for i < len(coins) && j < len(coinsB) {
+		coinA, coinB := coins[i], coinsB[j]
+		switch {
+		case coinA.Denom < coinB.Denom:
+			appendNonZero(coinA)
+			i++
+		case coinA.Denom > coinB.Denom:
+			appendNonZero(coinB)
+			j++
+		default:
+			appendNonZero(DecCoin{coinA.Denom, coinA.Amount.Add(coinB.Amount)})
+			i++
+			j++
+		}
+	}
+	for ; i < len(coins); i++ {
+		appendNonZero(coins[i])
+	}
+	for ; j < len(coinsB); j++ {
+		appendNonZero(coinsB[j])
+	}
+	return sum
+}
+
+// subtract a set of fractional coins
+func (coins DecCoins) Minus(coinsB DecCoins) DecCoins {
+	negative := make(DecCoins, len(coinsB))
+	for i, coin := range coinsB {
+		negative[i] = DecCoin{coin.Denom, sdk.ZeroRat().Sub(coin.Amount)}
+	}
+	return coins.Plus(negative)
+}
+
+// multiply each coin by a non-negative rational, truncated to the distribution precision
+func (coins DecCoins) MulRat(r sdk.Rat) DecCoins {
+	product := make(DecCoins, 0, len(coins))
+	for _, coin := range coins {
+		amount := truncate(coin.Amount.Mul(r))
+		if !amount.IsZero() {
+			product = append(product, DecCoin{coin.Denom, amount})
+		}
+	}
+	return product
+}
+
+// divide each coin by a positive rational, truncated to the distribution precision
+func (coins DecCoins) QuoRat(r sdk.Rat) DecCoins {
+	return coins.MulRat(sdk.OneRat().Quo(r))
+}
+
+// amount of a particular denom
+func (coins DecCoins) AmountOf(denom string) sdk.Rat {
+	i := sort.Search(len(coins), func(i int) bool { return coins[i].Denom >= denom })
+	if i < len(coins) && coins[i].Denom == denom {
+		return coins[i].Amount
+	}
+	return sdk.ZeroRat()
+}
+
+// whether no coins are held
+func (coins DecCoins) IsZero() bool {
+	for _, coin := range coins {
+		if !coin.Amount.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// split the coins into the whole coins which can be paid out and the
+// fractional change which remains
+func (coins DecCoins) TruncateDecimal() (whole sdk.Coins, change DecCoins) {
+	for _, coin := range coins {
+		amount := floor(coin.Amount)
+		if amount > 0 {
+			whole = append(whole, sdk.Coin{coin.Denom, amount})
+		}
+	}
+	return whole, coins.Minus(NewDecCoins(whole))
+}
+
+//_________________________________________________________________________
+
+// name of the fee distribution subspace in the params store
+const DefaultParamspace = "distr"
+
+// keys of the fee distribution parameters in the params store
+var (
+	KeyCommunityTax = []byte("CommunityTax")
+)
+
+// Params defines the high level settings for fee distribution
+type Params struct {
+	CommunityTax sdk.Rat `json:"community_tax"` // fraction of collected fees sent to the community pool
+}
+
+// implements params.ParamSet
+func (p *Params) ParamSetPairs() []params.ParamSetPair {
+	return []params.ParamSetPair{
+		params.NewParamSetPair(KeyCommunityTax, &p.CommunityTax, validateCommunityTax),
+	}
+}
+
+func validateCommunityTax(value interface{}) error {
+	r := value.(sdk.Rat)
+	if r.LT(sdk.ZeroRat()) || r.GT(sdk.OneRat()) {
+		return errors.New("must be between 0 and 1")
+	}
+	return nil
+}
+
+func (p Params) equal(p2 Params) bool {
+	return p.CommunityTax.Equal(p2.CommunityTax)
+}
+
+func DefaultParams() Params {
+	return Params{
+		CommunityTax: sdk.NewRat(2, 100),
+	}
+}
+
+//_________________________________________________________________________
+
+// FeePool - global pool of collected fees which have not been paid out to a
+// validator or delegator
+type FeePool struct {
+	CommunityPool DecCoins `json:"community_pool"` // reserve pool of collected fees for use by governance
+}
+
+// initial fee pool
+func InitialFeePool() FeePool {
+	return FeePool{
+		CommunityPool: DecCoins{},
+	}
+}
+
+//_________________________________________________________________________
+
+// ValidatorDistInfo - fees allocated to a validator which have not been
+// withdrawn yet
+//
+// Every block each bonded validator is allocated its share of the collected
+// fees. The validator commission is set aside, the rest is added to the pool
+// of the delegators and recorded as a cumulative reward per delegator share,
+// so the reward of a single delegation can be calculated without iterating
+// over the other delegators.
+type ValidatorDistInfo struct {
+	ValidatorAddr   sdk.Address `json:"validator_addr"`    // owner of the validator
+	PoolCommission  DecCoins    `json:"pool_commission"`   // commission collected by the validator owner
+	Pool            DecCoins    `json:"pool"`              // rewards held for the delegators of the validator
+	RewardsPerShare DecCoins    `json:"rewards_per_share"` // cumulative rewards of a single delegator share
+}
+
+// ValidatorDistInfo of a validator which has not been allocated any fees
+func NewValidatorDistInfo(validatorAddr sdk.Address) ValidatorDistInfo {
+	return ValidatorDistInfo{
+		ValidatorAddr:   validatorAddr,
+		PoolCommission:  DecCoins{},
+		Pool:            DecCoins{},
+		RewardsPerShare: DecCoins{},
+	}
+}
+
+// DelegatorDistInfo - the cumulative reward per share of a validator at the
+// last time the rewards of a delegation were withdrawn
+type DelegatorDistInfo struct {
+	DelegatorAddr   sdk.Address `json:"delegator_addr"`
+	ValidatorAddr   sdk.Address `json:"validator_addr"`
+	RewardsPerShare DecCoins    `json:"rewards_per_share"`
+}
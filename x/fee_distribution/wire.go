@@ -0,0 +1,16 @@
+package distribution
+
+import (
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// Register concrete types on wire codec
+func RegisterWire(cdc *wire.Codec) {
+	cdc.RegisterConcrete(MsgWithdrawDelegatorRewards{}, "cosmos-sdk/MsgWithdrawDelegatorRewards", nil)
+	cdc.RegisterConcrete(MsgWithdrawValidatorRewards{}, "cosmos-sdk/MsgWithdrawValidatorRewards", nil)
+}
+
+func init() {
+	RegisterWire(msgCdc)
+	wire.RegisterCrypto(msgCdc)
+}
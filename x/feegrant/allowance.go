@@ -0,0 +1,42 @@
+package feegrant
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FeeAllowance - fees which a granter allows to be paid from its account
+// for the txs of a grantee
+type FeeAllowance struct {
+	Granter    sdk.Address `json:"granter"`     // account paying the fees
+	Grantee    sdk.Address `json:"grantee"`     // fee payer of the txs whose fees are paid
+	SpendLimit sdk.Coins   `json:"spend_limit"` // fees which may still be paid
+	Expiration int64       `json:"expiration"`  // block time from which the allowance may no longer be used, zero if it does not expire
+}
+
+func NewFeeAllowance(granter, grantee sdk.Address, spendLimit sdk.Coins, expiration int64) FeeAllowance {
+	return FeeAllowance{
+		Granter:    granter,
+		Grantee:    grantee,
+		SpendLimit: spendLimit,
+		Expiration: expiration,
+	}
+}
+
+// IsExpired returns whether the allowance may no longer be used at the
+// given block time
+func (fa FeeAllowance) IsExpired(blockTime int64) bool {
+	return fa.Expiration != 0 && blockTime >= fa.Expiration
+}
+
+// HumanReadableString returns a human readable string representation of the
+// allowance
+func (fa FeeAllowance) HumanReadableString() string {
+	resp := "Fee Allowance \n"
+	resp += fmt.Sprintf("Granter: %s\n", sdk.MustBech32ifyAcc(fa.Granter))
+	resp += fmt.Sprintf("Grantee: %s\n", sdk.MustBech32ifyAcc(fa.Grantee))
+	resp += fmt.Sprintf("Spend Limit: %s\n", fa.SpendLimit)
+	resp += fmt.Sprintf("Expiration: %d", fa.Expiration)
+	return resp
+}
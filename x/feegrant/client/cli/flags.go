@@ -0,0 +1,9 @@
+package cli
+
+// nolint
+const (
+	FlagGrantee    = "grantee"
+	FlagGranter    = "granter"
+	FlagSpendLimit = "spend-limit"
+	FlagExpiration = "expiration"
+)
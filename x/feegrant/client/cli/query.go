@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+)
+
+// get the command to query the fee allowance of a granter to a grantee
+func GetCmdQueryFeeAllowance(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "allowance",
+		Short: "Query the fee allowance of a granter to a grantee",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			granter, err := sdk.GetAccAddressBech32(viper.GetString(FlagGranter))
+			if err != nil {
+				return err
+			}
+			grantee, err := sdk.GetAccAddressBech32(viper.GetString(FlagGrantee))
+			if err != nil {
+				return err
+			}
+			key := feegrant.GetFeeAllowanceKey(granter, grantee)
+			ctx := context.NewCoreContextFromViper()
+			res, err := ctx.Query(key, storeName)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("no fee allowance found from %s to %s", viper.GetString(FlagGranter), viper.GetString(FlagGrantee))
+			}
+			var allowance feegrant.FeeAllowance
+			cdc.MustUnmarshalBinary(res, &allowance)
+
+			output, err := wire.MarshalJSONIndent(cdc, allowance)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(FlagGranter, "", "bech32 address of the granter")
+	cmd.Flags().String(FlagGrantee, "", "bech32 address of the grantee")
+	return cmd
+}
+
+// get the command to query all fee allowances to a grantee
+func GetCmdQueryFeeAllowances(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "allowances",
+		Short: "Query all fee allowances to a grantee",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			grantee, err := sdk.GetAccAddressBech32(viper.GetString(FlagGrantee))
+			if err != nil {
+				return err
+			}
+			key := feegrant.GetFeeAllowancesKey(grantee)
+			ctx := context.NewCoreContextFromViper()
+			resKVs, err := ctx.QuerySubspace(cdc, key, storeName)
+			if err != nil {
+				return err
+			}
+
+			// parse out the allowances
+			var allowances []feegrant.FeeAllowance
+			for _, KV := range resKVs {
+				var allowance feegrant.FeeAllowance
+				cdc.MustUnmarshalBinary(KV.Value, &allowance)
+				allowances = append(allowances, allowance)
+			}
+
+			output, err := wire.MarshalJSONIndent(cdc, allowances)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(FlagGrantee, "", "bech32 address of the grantee")
+	return cmd
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+)
+
+// create grant fee allowance command
+func GetCmdGrantFeeAllowance(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant",
+		Short: "allow a grantee to have its fees paid by your account",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			granter, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+			grantee, err := sdk.GetAccAddressBech32(viper.GetString(FlagGrantee))
+			if err != nil {
+				return err
+			}
+			spendLimit, err := sdk.ParseCoins(viper.GetString(FlagSpendLimit))
+			if err != nil {
+				return err
+			}
+
+			msg := feegrant.NewMsgGrantFeeAllowance(granter, grantee, spendLimit, viper.GetInt64(FlagExpiration))
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().String(FlagGrantee, "", "bech32 address of the account whose fees are paid")
+	cmd.Flags().String(FlagSpendLimit, "", "total fees which may be paid for the grantee")
+	cmd.Flags().Int64(FlagExpiration, 0, "block time from which the allowance expires, omit for no expiration")
+	return cmd
+}
+
+// create revoke fee allowance command
+func GetCmdRevokeFeeAllowance(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke",
+		Short: "revoke the fee allowance given to a grantee",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			granter, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+			grantee, err := sdk.GetAccAddressBech32(viper.GetString(FlagGrantee))
+			if err != nil {
+				return err
+			}
+
+			msg := feegrant.NewMsgRevokeFeeAllowance(granter, grantee)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().String(FlagGrantee, "", "bech32 address of the account whose fees are paid")
+	return cmd
+}
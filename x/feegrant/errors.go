@@ -0,0 +1,67 @@
+//nolint
+package feegrant
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Local code type
+type CodeType = sdk.CodeType
+
+const (
+	// Default fee grant codespace
+	DefaultCodespace sdk.CodespaceType = 13
+
+	CodeInvalidAddress    CodeType = 1
+	CodeInvalidSpendLimit CodeType = 2
+	CodeNoAllowance       CodeType = 3
+	CodeAllowanceExpired  CodeType = 4
+	CodeAllowanceExceeded CodeType = 5
+)
+
+func ErrInvalidAddress(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidAddress, msg)
+}
+func ErrInvalidSpendLimit(codespace sdk.CodespaceType, spendLimit sdk.Coins) sdk.Error {
+	return newError(codespace, CodeInvalidSpendLimit, fmt.Sprintf("Spend limit %v is not valid, it must be positive", spendLimit))
+}
+func ErrNoAllowance(codespace sdk.CodespaceType, granter, grantee sdk.Address) sdk.Error {
+	return newError(codespace, CodeNoAllowance, fmt.Sprintf("%v has no fee allowance from %v", grantee, granter))
+}
+func ErrAllowanceExpired(codespace sdk.CodespaceType, expiration int64) sdk.Error {
+	return newError(codespace, CodeAllowanceExpired, fmt.Sprintf("Fee allowance expired at %d", expiration))
+}
+func ErrAllowanceExceeded(codespace sdk.CodespaceType, spendLimit, fee sdk.Coins) sdk.Error {
+	return newError(codespace, CodeAllowanceExceeded, fmt.Sprintf("Fee %v exceeds the remaining allowance %v", fee, spendLimit))
+}
+
+func codeToDefaultMsg(code CodeType) string {
+	switch code {
+	case CodeInvalidAddress:
+		return "Invalid address"
+	case CodeInvalidSpendLimit:
+		return "Invalid spend limit"
+	case CodeNoAllowance:
+		return "No fee allowance"
+	case CodeAllowanceExpired:
+		return "Fee allowance expired"
+	case CodeAllowanceExceeded:
+		return "Fee allowance exceeded"
+	default:
+		return sdk.CodeToDefaultMsg(code)
+	}
+}
+
+func msgOrDefaultMsg(msg string, code CodeType) string {
+	if msg != "" {
+		return msg
+	}
+	return codeToDefaultMsg(code)
+}
+
+func newError(codespace sdk.CodespaceType, code CodeType, msg string) sdk.Error {
+	msg = msgOrDefaultMsg(msg, code)
+	return sdk.NewError(codespace, code, msg)
+}
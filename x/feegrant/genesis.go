@@ -0,0 +1,29 @@
+package feegrant
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - the fee allowances granted at genesis
+type GenesisState struct {
+	FeeAllowances []FeeAllowance `json:"fee_allowances"`
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// InitGenesis - store the genesis fee allowances
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	for _, allowance := range data.FeeAllowances {
+		k.setFeeAllowance(ctx, allowance)
+	}
+}
+
+// WriteGenesis - output all fee allowances
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	return GenesisState{
+		FeeAllowances: k.getAllFeeAllowances(ctx),
+	}
+}
@@ -0,0 +1,45 @@
+package feegrant
+
+import (
+	"reflect"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Handle all "feegrant" type messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case MsgGrantFeeAllowance:
+			return handleMsgGrantFeeAllowance(ctx, k, msg)
+		case MsgRevokeFeeAllowance:
+			return handleMsgRevokeFeeAllowance(ctx, k, msg)
+		default:
+			errMsg := "Unrecognized feegrant Msg type: " + reflect.TypeOf(msg).Name()
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+func handleMsgGrantFeeAllowance(ctx sdk.Context, k Keeper, msg MsgGrantFeeAllowance) sdk.Result {
+	allowance := NewFeeAllowance(msg.Granter, msg.Grantee, msg.SpendLimit, msg.Expiration)
+	k.setFeeAllowance(ctx, allowance)
+
+	tags := sdk.NewTags("action", []byte("grantFeeAllowance"), "granter", msg.Granter.Bytes(), "grantee", msg.Grantee.Bytes())
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+func handleMsgRevokeFeeAllowance(ctx sdk.Context, k Keeper, msg MsgRevokeFeeAllowance) sdk.Result {
+	_, found := k.GetFeeAllowance(ctx, msg.Granter, msg.Grantee)
+	if !found {
+		return ErrNoAllowance(k.codespace, msg.Granter, msg.Grantee).Result()
+	}
+	k.deleteFeeAllowance(ctx, msg.Granter, msg.Grantee)
+
+	tags := sdk.NewTags("action", []byte("revokeFeeAllowance"), "granter", msg.Granter.Bytes(), "grantee", msg.Grantee.Bytes())
+	return sdk.Result{
+		Tags: tags,
+	}
+}
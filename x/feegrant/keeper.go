@@ -0,0 +1,100 @@
+package feegrant
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+var _ auth.FeeGrantKeeper = Keeper{}
+
+// Keeper of the fee allowances store
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *wire.Codec
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+// NewKeeper creates a fee grant keeper
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		storeKey:  key,
+		cdc:       cdc,
+		codespace: codespace,
+	}
+}
+
+// GetFeeAllowance returns the allowance of a granter to a grantee
+func (k Keeper) GetFeeAllowance(ctx sdk.Context, granter, grantee sdk.Address) (allowance FeeAllowance, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetFeeAllowanceKey(granter, grantee))
+	if bz == nil {
+		return allowance, false
+	}
+	k.cdc.MustUnmarshalBinary(bz, &allowance)
+	return allowance, true
+}
+
+// GetFeeAllowances returns all the allowances to a grantee
+func (k Keeper) GetFeeAllowances(ctx sdk.Context, grantee sdk.Address) (allowances []FeeAllowance) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetFeeAllowancesKey(grantee))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var allowance FeeAllowance
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &allowance)
+		allowances = append(allowances, allowance)
+	}
+	return allowances
+}
+
+// load all fee allowances used during genesis dump
+func (k Keeper) getAllFeeAllowances(ctx sdk.Context) (allowances []FeeAllowance) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, FeeAllowancesKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var allowance FeeAllowance
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &allowance)
+		allowances = append(allowances, allowance)
+	}
+	return allowances
+}
+
+func (k Keeper) setFeeAllowance(ctx sdk.Context, allowance FeeAllowance) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(allowance)
+	store.Set(GetFeeAllowanceKey(allowance.Granter, allowance.Grantee), bz)
+}
+
+func (k Keeper) deleteFeeAllowance(ctx sdk.Context, granter, grantee sdk.Address) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetFeeAllowanceKey(granter, grantee))
+}
+
+// UseGrantedFees takes the fee paid by the granter for a tx of the grantee
+// from its allowance, the allowance is removed once used up. Implements
+// auth.FeeGrantKeeper.
+func (k Keeper) UseGrantedFees(ctx sdk.Context, granter, grantee sdk.Address, fee sdk.Coins) sdk.Error {
+	allowance, found := k.GetFeeAllowance(ctx, granter, grantee)
+	if !found {
+		return ErrNoAllowance(k.codespace, granter, grantee)
+	}
+	if allowance.IsExpired(ctx.BlockHeader().Time) {
+		return ErrAllowanceExpired(k.codespace, allowance.Expiration)
+	}
+
+	remaining := allowance.SpendLimit.Minus(fee)
+	if !remaining.IsNotNegative() {
+		return ErrAllowanceExceeded(k.codespace, allowance.SpendLimit, fee)
+	}
+	if remaining.IsZero() {
+		k.deleteFeeAllowance(ctx, granter, grantee)
+		return nil
+	}
+	allowance.SpendLimit = remaining
+	k.setFeeAllowance(ctx, allowance)
+	return nil
+}
@@ -0,0 +1,21 @@
+package feegrant
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+//nolint
+var (
+	// Keys for store prefixes
+	FeeAllowancesKey = []byte{0x00} // prefix for each key to a fee allowance
+)
+
+// get the key for the allowance of a granter to a grantee
+func GetFeeAllowanceKey(granter, grantee sdk.Address) []byte {
+	return append(GetFeeAllowancesKey(grantee), granter.Bytes()...)
+}
+
+// get the prefix for all allowances to a grantee
+func GetFeeAllowancesKey(grantee sdk.Address) []byte {
+	return append(FeeAllowancesKey, grantee.Bytes()...)
+}
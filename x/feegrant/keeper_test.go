@@ -0,0 +1,97 @@
+package feegrant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/abci/types"
+	crypto "github.com/tendermint/go-crypto"
+	dbm "github.com/tendermint/tmlibs/db"
+	"github.com/tendermint/tmlibs/log"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+var addrs = []sdk.Address{
+	crypto.GenPrivKeyEd25519().PubKey().Address(),
+	crypto.GenPrivKeyEd25519().PubKey().Address(),
+	crypto.GenPrivKeyEd25519().PubKey().Address(),
+}
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper) {
+	keyFeeGrant := sdk.NewKVStoreKey("feegrant")
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyFeeGrant, sdk.StoreTypeIAVL, db)
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "foochainid", Time: 100}, false, nil, log.NewNopLogger())
+	cdc := wire.NewCodec()
+	RegisterWire(cdc)
+	return ctx, NewKeeper(cdc, keyFeeGrant, DefaultCodespace)
+}
+
+func TestUseGrantedFees(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	fee := sdk.Coins{{"steak", 40}}
+
+	err := keeper.UseGrantedFees(ctx, addrs[0], addrs[1], fee)
+	require.Equal(t, CodeNoAllowance, err.Code())
+
+	// the fees are taken from the allowance until it is used up
+	keeper.setFeeAllowance(ctx, NewFeeAllowance(addrs[0], addrs[1], sdk.Coins{{"steak", 100}}, 0))
+	require.Nil(t, keeper.UseGrantedFees(ctx, addrs[0], addrs[1], fee))
+	require.Nil(t, keeper.UseGrantedFees(ctx, addrs[0], addrs[1], fee))
+	allowance, found := keeper.GetFeeAllowance(ctx, addrs[0], addrs[1])
+	require.True(t, found)
+	require.Equal(t, sdk.Coins{{"steak", 20}}, allowance.SpendLimit)
+
+	err = keeper.UseGrantedFees(ctx, addrs[0], addrs[1], fee)
+	require.Equal(t, CodeAllowanceExceeded, err.Code())
+	err = keeper.UseGrantedFees(ctx, addrs[0], addrs[1], sdk.Coins{{"atom", 1}})
+	require.Equal(t, CodeAllowanceExceeded, err.Code())
+
+	require.Nil(t, keeper.UseGrantedFees(ctx, addrs[0], addrs[1], sdk.Coins{{"steak", 20}}))
+	_, found = keeper.GetFeeAllowance(ctx, addrs[0], addrs[1])
+	require.False(t, found)
+
+	// expired allowances cannot be used
+	keeper.setFeeAllowance(ctx, NewFeeAllowance(addrs[0], addrs[1], sdk.Coins{{"steak", 100}}, 100))
+	err = keeper.UseGrantedFees(ctx, addrs[0], addrs[1], fee)
+	require.Equal(t, CodeAllowanceExpired, err.Code())
+	require.Nil(t, keeper.UseGrantedFees(ctx.WithBlockHeader(abci.Header{Time: 99}), addrs[0], addrs[1], fee))
+}
+
+func TestGetFeeAllowances(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+
+	res := handler(ctx, NewMsgGrantFeeAllowance(addrs[0], addrs[2], sdk.Coins{{"steak", 10}}, 0))
+	require.True(t, res.IsOK())
+	res = handler(ctx, NewMsgGrantFeeAllowance(addrs[1], addrs[2], sdk.Coins{{"steak", 20}}, 200))
+	require.True(t, res.IsOK())
+	res = handler(ctx, NewMsgGrantFeeAllowance(addrs[2], addrs[0], sdk.Coins{{"steak", 30}}, 0))
+	require.True(t, res.IsOK())
+
+	allowances := keeper.GetFeeAllowances(ctx, addrs[2])
+	require.Len(t, allowances, 2)
+	require.Len(t, keeper.GetFeeAllowances(ctx, addrs[0]), 1)
+	require.Len(t, keeper.GetFeeAllowances(ctx, addrs[1]), 0)
+
+	// a new grant replaces the previous allowance
+	res = handler(ctx, NewMsgGrantFeeAllowance(addrs[0], addrs[2], sdk.Coins{{"steak", 50}}, 0))
+	require.True(t, res.IsOK())
+	allowance, found := keeper.GetFeeAllowance(ctx, addrs[0], addrs[2])
+	require.True(t, found)
+	require.Equal(t, sdk.Coins{{"steak", 50}}, allowance.SpendLimit)
+
+	res = handler(ctx, NewMsgRevokeFeeAllowance(addrs[0], addrs[2]))
+	require.True(t, res.IsOK())
+	require.Len(t, keeper.GetFeeAllowances(ctx, addrs[2]), 1)
+	res = handler(ctx, NewMsgRevokeFeeAllowance(addrs[0], addrs[2]))
+	require.False(t, res.IsOK())
+}
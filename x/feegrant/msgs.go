@@ -0,0 +1,119 @@
+package feegrant
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+var msgCdc = wire.NewCodec()
+
+// name to identify transaction types
+const MsgType = "feegrant"
+
+// verify interface at compile time
+var _, _ sdk.Msg = MsgGrantFeeAllowance{}, MsgRevokeFeeAllowance{}
+
+//______________________________________________________________________
+
+// MsgGrantFeeAllowance - allow a grantee to have its fees paid by the
+// granter, replacing any previous allowance between them
+type MsgGrantFeeAllowance struct {
+	Granter    sdk.Address `json:"granter"`     // account paying the fees
+	Grantee    sdk.Address `json:"grantee"`     // account whose fees are paid
+	SpendLimit sdk.Coins   `json:"spend_limit"` // total fees which may be paid, must be strictly positive
+	Expiration int64       `json:"expiration"`  // block time from which the allowance may no longer be used, zero if it does not expire
+}
+
+func NewMsgGrantFeeAllowance(granter, grantee sdk.Address, spendLimit sdk.Coins, expiration int64) MsgGrantFeeAllowance {
+	return MsgGrantFeeAllowance{
+		Granter:    granter,
+		Grantee:    grantee,
+		SpendLimit: spendLimit,
+		Expiration: expiration,
+	}
+}
+
+//nolint
+func (msg MsgGrantFeeAllowance) Type() string              { return MsgType }
+func (msg MsgGrantFeeAllowance) GetSigners() []sdk.Address { return []sdk.Address{msg.Granter} }
+
+// get the bytes for the message signer to sign on
+func (msg MsgGrantFeeAllowance) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Granter    string    `json:"granter"`
+		Grantee    string    `json:"grantee"`
+		SpendLimit sdk.Coins `json:"spend_limit"`
+		Expiration int64     `json:"expiration"`
+	}{
+		Granter:    sdk.MustBech32ifyAcc(msg.Granter),
+		Grantee:    sdk.MustBech32ifyAcc(msg.Grantee),
+		SpendLimit: msg.SpendLimit,
+		Expiration: msg.Expiration,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check
+func (msg MsgGrantFeeAllowance) ValidateBasic() sdk.Error {
+	if err := validateGranterGrantee(msg.Granter, msg.Grantee); err != nil {
+		return err
+	}
+	if !msg.SpendLimit.IsValid() || !msg.SpendLimit.IsPositive() {
+		return ErrInvalidSpendLimit(DefaultCodespace, msg.SpendLimit)
+	}
+	return nil
+}
+
+//______________________________________________________________________
+
+// MsgRevokeFeeAllowance - remove the allowance of a granter to a grantee
+type MsgRevokeFeeAllowance struct {
+	Granter sdk.Address `json:"granter"` // account paying the fees
+	Grantee sdk.Address `json:"grantee"` // account whose fees are paid
+}
+
+func NewMsgRevokeFeeAllowance(granter, grantee sdk.Address) MsgRevokeFeeAllowance {
+	return MsgRevokeFeeAllowance{
+		Granter: granter,
+		Grantee: grantee,
+	}
+}
+
+//nolint
+func (msg MsgRevokeFeeAllowance) Type() string              { return MsgType }
+func (msg MsgRevokeFeeAllowance) GetSigners() []sdk.Address { return []sdk.Address{msg.Granter} }
+
+// get the bytes for the message signer to sign on
+func (msg MsgRevokeFeeAllowance) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Granter string `json:"granter"`
+		Grantee string `json:"grantee"`
+	}{
+		Granter: sdk.MustBech32ifyAcc(msg.Granter),
+		Grantee: sdk.MustBech32ifyAcc(msg.Grantee),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check
+func (msg MsgRevokeFeeAllowance) ValidateBasic() sdk.Error {
+	return validateGranterGrantee(msg.Granter, msg.Grantee)
+}
+
+func validateGranterGrantee(granter, grantee sdk.Address) sdk.Error {
+	if granter == nil || grantee == nil {
+		return ErrInvalidAddress(DefaultCodespace, "granter and grantee cannot be nil")
+	}
+	if bytes.Equal(granter, grantee) {
+		return ErrInvalidAddress(DefaultCodespace, "granter cannot grant fees to itself")
+	}
+	return nil
+}
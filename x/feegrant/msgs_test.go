@@ -0,0 +1,56 @@
+package feegrant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestMsgGrantFeeAllowance(t *testing.T) {
+	tests := []struct {
+		granter, grantee sdk.Address
+		spendLimit       sdk.Coins
+		expectPass       bool
+	}{
+		{addrs[0], addrs[1], sdk.Coins{{"steak", 10}}, true},
+		{addrs[0], addrs[1], sdk.Coins{{"atom", 5}, {"steak", 10}}, true},
+		{nil, addrs[1], sdk.Coins{{"steak", 10}}, false},
+		{addrs[0], nil, sdk.Coins{{"steak", 10}}, false},
+		{addrs[0], addrs[0], sdk.Coins{{"steak", 10}}, false},
+		{addrs[0], addrs[1], nil, false},
+		{addrs[0], addrs[1], sdk.Coins{{"steak", 0}}, false},
+		{addrs[0], addrs[1], sdk.Coins{{"steak", 10}, {"atom", 5}}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgGrantFeeAllowance(tc.granter, tc.grantee, tc.spendLimit, 0)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
+func TestMsgRevokeFeeAllowance(t *testing.T) {
+	tests := []struct {
+		granter, grantee sdk.Address
+		expectPass       bool
+	}{
+		{addrs[0], addrs[1], true},
+		{nil, addrs[1], false},
+		{addrs[0], nil, false},
+		{addrs[0], addrs[0], false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgRevokeFeeAllowance(tc.granter, tc.grantee)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
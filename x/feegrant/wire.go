@@ -0,0 +1,11 @@
+package feegrant
+
+import (
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// Register concrete types on wire codec
+func RegisterWire(cdc *wire.Codec) {
+	cdc.RegisterConcrete(MsgGrantFeeAllowance{}, "cosmos-sdk/MsgGrantFeeAllowance", nil)
+	cdc.RegisterConcrete(MsgRevokeFeeAllowance{}, "cosmos-sdk/MsgRevokeFeeAllowance", nil)
+}
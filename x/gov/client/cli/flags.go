@@ -0,0 +1,14 @@
+package cli
+
+// nolint
+const (
+	FlagTitle        = "title"
+	FlagDescription  = "description"
+	FlagProposalType = "type"
+	FlagDeposit      = "deposit"
+	FlagProposalID   = "proposal-id"
+	FlagOption       = "option"
+	FlagVoter        = "voter"
+	FlagDepositer    = "depositer"
+	FlagParamChange  = "param-change"
+)
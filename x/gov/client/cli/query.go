@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tmlibs/cli"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+)
+
+// get the command to query a proposal
+func GetCmdQueryProposal(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proposal",
+		Short: "Query a proposal",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			proposalID := viper.GetInt64(FlagProposalID)
+			key := gov.GetProposalKey(proposalID)
+			ctx := context.NewCoreContextFromViper()
+			res, err := ctx.Query(key, storeName)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("no proposal found with ID %d", proposalID)
+			}
+			var proposal gov.Proposal
+			cdc.MustUnmarshalBinary(res, &proposal)
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				fmt.Println(proposal.HumanReadableString())
+
+			case "json":
+				output, err := wire.MarshalJSONIndent(cdc, proposal)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(output))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64(FlagProposalID, 0, "ID of the proposal")
+	return cmd
+}
+
+// get the command to query the vote of a voter on a proposal
+func GetCmdQueryVote(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote",
+		Short: "Query the vote of a voter on a proposal",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			proposalID := viper.GetInt64(FlagProposalID)
+			voter, err := sdk.GetAccAddressBech32(viper.GetString(FlagVoter))
+			if err != nil {
+				return err
+			}
+			key := gov.GetVoteKey(proposalID, voter)
+			ctx := context.NewCoreContextFromViper()
+			res, err := ctx.Query(key, storeName)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("no vote found for %s on proposal %d", viper.GetString(FlagVoter), proposalID)
+			}
+			var vote gov.Vote
+			cdc.MustUnmarshalBinary(res, &vote)
+
+			output, err := wire.MarshalJSONIndent(cdc, vote)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64(FlagProposalID, 0, "ID of the proposal")
+	cmd.Flags().String(FlagVoter, "", "bech32 address of the voter")
+	return cmd
+}
+
+// get the command to query all votes on a proposal
+func GetCmdQueryVotes(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "votes",
+		Short: "Query all votes on a proposal",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			key := gov.GetVotesKey(viper.GetInt64(FlagProposalID))
+			ctx := context.NewCoreContextFromViper()
+			resKVs, err := ctx.QuerySubspace(cdc, key, storeName)
+			if err != nil {
+				return err
+			}
+
+			// parse out the votes
+			var votes []gov.Vote
+			for _, KV := range resKVs {
+				var vote gov.Vote
+				cdc.MustUnmarshalBinary(KV.Value, &vote)
+				votes = append(votes, vote)
+			}
+
+			output, err := wire.MarshalJSONIndent(cdc, votes)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64(FlagProposalID, 0, "ID of the proposal")
+	return cmd
+}
+
+// get the command to query the deposit of a depositer on a proposal
+func GetCmdQueryDeposit(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deposit",
+		Short: "Query the deposit of a depositer on a proposal",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			proposalID := viper.GetInt64(FlagProposalID)
+			depositer, err := sdk.GetAccAddressBech32(viper.GetString(FlagDepositer))
+			if err != nil {
+				return err
+			}
+			key := gov.GetDepositKey(proposalID, depositer)
+			ctx := context.NewCoreContextFromViper()
+			res, err := ctx.Query(key, storeName)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("no deposit found for %s on proposal %d", viper.GetString(FlagDepositer), proposalID)
+			}
+			var deposit gov.Deposit
+			cdc.MustUnmarshalBinary(res, &deposit)
+
+			output, err := wire.MarshalJSONIndent(cdc, deposit)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64(FlagProposalID, 0, "ID of the proposal")
+	cmd.Flags().String(FlagDepositer, "", "bech32 address of the depositer")
+	return cmd
+}
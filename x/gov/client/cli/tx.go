@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+)
+
+// create submit proposal command
+func GetCmdSubmitProposal(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submit-proposal",
+		Short: "submit a proposal along with an initial deposit",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			proposer, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+			proposalType, err := gov.ProposalKindFromString(viper.GetString(FlagProposalType))
+			if err != nil {
+				return err
+			}
+			deposit, err := sdk.ParseCoins(viper.GetString(FlagDeposit))
+			if err != nil {
+				return err
+			}
+
+			rawChanges, err := cmd.Flags().GetStringArray(FlagParamChange)
+			if err != nil {
+				return err
+			}
+			paramChanges, err := parseParamChanges(rawChanges)
+			if err != nil {
+				return err
+			}
+
+			msg := gov.NewMsgSubmitProposal(viper.GetString(FlagTitle), viper.GetString(FlagDescription),
+				proposalType, proposer, deposit)
+			msg.ParamChanges = paramChanges
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().String(FlagTitle, "", "title of the proposal")
+	cmd.Flags().String(FlagDescription, "", "description of the proposal")
+	cmd.Flags().String(FlagProposalType, "Text", "type of the proposal, Text, SoftwareUpgrade or ParameterChange")
+	cmd.Flags().String(FlagDeposit, "", "initial deposit of the proposal")
+	cmd.Flags().StringArray(FlagParamChange, nil, "parameter changed by a ParameterChange proposal, as subspace/key=value with a JSON value, may be repeated")
+	return cmd
+}
+
+// parse parameter changes of the form subspace/key=value
+func parseParamChanges(rawChanges []string) ([]gov.ParamChange, error) {
+	var changes []gov.ParamChange
+	for _, raw := range rawChanges {
+		kv := strings.SplitN(raw, "=", 2)
+		path := strings.SplitN(kv[0], "/", 2)
+		if len(kv) != 2 || len(path) != 2 {
+			return nil, fmt.Errorf("parameter change %q is not of the form subspace/key=value", raw)
+		}
+		changes = append(changes, gov.ParamChange{Subspace: path[0], Key: path[1], Value: kv[1]})
+	}
+	return changes, nil
+}
+
+// create deposit command
+func GetCmdDeposit(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deposit",
+		Short: "deposit on a proposal in its deposit period",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			depositer, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoins(viper.GetString(FlagDeposit))
+			if err != nil {
+				return err
+			}
+
+			msg := gov.NewMsgDeposit(viper.GetInt64(FlagProposalID), depositer, amount)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64(FlagProposalID, 0, "ID of the proposal to deposit on")
+	cmd.Flags().String(FlagDeposit, "", "amount of the deposit")
+	return cmd
+}
+
+// create vote command
+func GetCmdVote(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote",
+		Short: "vote on a proposal in its voting period",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			voter, err := ctx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+			option, err := gov.VoteOptionFromString(viper.GetString(FlagOption))
+			if err != nil {
+				return err
+			}
+
+			msg := gov.NewMsgVote(viper.GetInt64(FlagProposalID), voter, option)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64(FlagProposalID, 0, "ID of the proposal to vote on")
+	cmd.Flags().String(FlagOption, "", "vote option, one of Yes, No, NoWithVeto or Abstain")
+	return cmd
+}
@@ -0,0 +1,103 @@
+//nolint
+package gov
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Local code type
+type CodeType = sdk.CodeType
+
+const (
+	// Default governance codespace
+	DefaultCodespace sdk.CodespaceType = 12
+
+	CodeUnknownProposal         CodeType = 1
+	CodeInactiveProposal        CodeType = 2
+	CodeAlreadyActiveProposal   CodeType = 3
+	CodeAlreadyFinishedProposal CodeType = 4
+	CodeInvalidTitle            CodeType = 5
+	CodeInvalidDescription      CodeType = 6
+	CodeInvalidProposalType     CodeType = 7
+	CodeInvalidVote             CodeType = 8
+	CodeInvalidAddress          CodeType = 9
+	CodeInvalidDeposit          CodeType = 10
+	CodeInvalidParamChange      CodeType = 11
+)
+
+func ErrUnknownProposal(codespace sdk.CodespaceType, proposalID int64) sdk.Error {
+	return newError(codespace, CodeUnknownProposal, fmt.Sprintf("Unknown proposal - %d", proposalID))
+}
+func ErrInactiveProposal(codespace sdk.CodespaceType, proposalID int64) sdk.Error {
+	return newError(codespace, CodeInactiveProposal, fmt.Sprintf("Proposal %d is not in its voting period", proposalID))
+}
+func ErrAlreadyActiveProposal(codespace sdk.CodespaceType, proposalID int64) sdk.Error {
+	return newError(codespace, CodeAlreadyActiveProposal, fmt.Sprintf("Proposal %d has already reached its minimum deposit", proposalID))
+}
+func ErrAlreadyFinishedProposal(codespace sdk.CodespaceType, proposalID int64) sdk.Error {
+	return newError(codespace, CodeAlreadyFinishedProposal, fmt.Sprintf("Proposal %d has already passed its voting period", proposalID))
+}
+func ErrInvalidTitle(codespace sdk.CodespaceType, title string) sdk.Error {
+	return newError(codespace, CodeInvalidTitle, fmt.Sprintf("Proposal title '%s' is not valid", title))
+}
+func ErrInvalidDescription(codespace sdk.CodespaceType, description string) sdk.Error {
+	return newError(codespace, CodeInvalidDescription, fmt.Sprintf("Proposal description '%s' is not valid", description))
+}
+func ErrInvalidProposalType(codespace sdk.CodespaceType, proposalType ProposalKind) sdk.Error {
+	return newError(codespace, CodeInvalidProposalType, fmt.Sprintf("Proposal type '%s' is not valid", proposalType))
+}
+func ErrInvalidVote(codespace sdk.CodespaceType, voteOption VoteOption) sdk.Error {
+	return newError(codespace, CodeInvalidVote, fmt.Sprintf("Vote option '%s' is not valid", voteOption))
+}
+func ErrInvalidAddress(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeInvalidAddress, "Address cannot be nil")
+}
+func ErrInvalidDeposit(codespace sdk.CodespaceType, deposit sdk.Coins) sdk.Error {
+	return newError(codespace, CodeInvalidDeposit, fmt.Sprintf("Deposit %v is not valid, it must be positive", deposit))
+}
+func ErrInvalidParamChange(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidParamChange, fmt.Sprintf("Parameter change is not valid: %s", msg))
+}
+
+func codeToDefaultMsg(code CodeType) string {
+	switch code {
+	case CodeUnknownProposal:
+		return "Unknown proposal"
+	case CodeInactiveProposal:
+		return "Inactive proposal"
+	case CodeAlreadyActiveProposal:
+		return "Proposal already active"
+	case CodeAlreadyFinishedProposal:
+		return "Proposal already finished"
+	case CodeInvalidTitle:
+		return "Invalid title"
+	case CodeInvalidDescription:
+		return "Invalid description"
+	case CodeInvalidProposalType:
+		return "Invalid proposal type"
+	case CodeInvalidVote:
+		return "Invalid vote"
+	case CodeInvalidAddress:
+		return "Invalid address"
+	case CodeInvalidDeposit:
+		return "Invalid deposit"
+	case CodeInvalidParamChange:
+		return "Invalid parameter change"
+	default:
+		return sdk.CodeToDefaultMsg(code)
+	}
+}
+
+func msgOrDefaultMsg(msg string, code CodeType) string {
+	if msg != "" {
+		return msg
+	}
+	return codeToDefaultMsg(code)
+}
+
+func newError(codespace sdk.CodespaceType, code CodeType, msg string) sdk.Error {
+	msg = msgOrDefaultMsg(msg, code)
+	return sdk.NewError(codespace, code, msg)
+}
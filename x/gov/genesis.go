@@ -0,0 +1,93 @@
+package gov
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all governance state that must be provided at genesis
+type GenesisState struct {
+	StartingProposalID int64             `json:"starting_proposal_id"`
+	DepositProcedure   DepositProcedure  `json:"deposit_procedure"`
+	VotingProcedure    VotingProcedure   `json:"voting_procedure"`
+	TallyingProcedure  TallyingProcedure `json:"tallying_procedure"`
+	Proposals          []Proposal        `json:"proposals"`
+	Deposits           []Deposit         `json:"deposits"`
+	Votes              []Vote            `json:"votes"`
+}
+
+func NewGenesisState(startingProposalID int64, dp DepositProcedure, vp VotingProcedure,
+	tp TallyingProcedure) GenesisState {
+
+	return GenesisState{
+		StartingProposalID: startingProposalID,
+		DepositProcedure:   dp,
+		VotingProcedure:    vp,
+		TallyingProcedure:  tp,
+	}
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		StartingProposalID: 1,
+		DepositProcedure:   DefaultDepositProcedure(),
+		VotingProcedure:    DefaultVotingProcedure(),
+		TallyingProcedure:  DefaultTallyingProcedure(),
+	}
+}
+
+// InitGenesis - store genesis parameters
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	keeper.setNewProposalID(ctx, data.StartingProposalID)
+	keeper.setDepositProcedure(ctx, data.DepositProcedure)
+	keeper.setVotingProcedure(ctx, data.VotingProcedure)
+	keeper.setTallyingProcedure(ctx, data.TallyingProcedure)
+	for _, proposal := range data.Proposals {
+		keeper.setProposal(ctx, proposal)
+		keeper.insertProposalQueue(ctx, proposal)
+	}
+	for _, deposit := range data.Deposits {
+		keeper.setDeposit(ctx, deposit)
+	}
+	for _, vote := range data.Votes {
+		keeper.setVote(ctx, vote)
+	}
+}
+
+// WriteGenesis - output genesis parameters
+func WriteGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	return GenesisState{
+		StartingProposalID: keeper.peekNewProposalID(ctx),
+		DepositProcedure:   keeper.GetDepositProcedure(ctx),
+		VotingProcedure:    keeper.GetVotingProcedure(ctx),
+		TallyingProcedure:  keeper.GetTallyingProcedure(ctx),
+		Proposals:          keeper.getAllProposals(ctx),
+		Deposits:           keeper.getAllDeposits(ctx),
+		Votes:              keeper.getAllVotes(ctx),
+	}
+}
+
+// ForZeroHeight - rebase the state exported at a height for a chain
+// restarting at height zero, the deposit and voting periods which have not
+// ended keep the blocks they have left
+func (data GenesisState) ForZeroHeight(height int64) GenesisState {
+	rebase := func(block int64) int64 {
+		if block == -1 {
+			return block
+		}
+		if block < height {
+			return 0
+		}
+		return block - height
+	}
+	proposals := make([]Proposal, len(data.Proposals))
+	for i, proposal := range data.Proposals {
+		proposal.SubmitBlock = rebase(proposal.SubmitBlock)
+		proposal.DepositEndBlock = rebase(proposal.DepositEndBlock)
+		proposal.VotingStartBlock = rebase(proposal.VotingStartBlock)
+		proposal.VotingEndBlock = rebase(proposal.VotingEndBlock)
+		proposals[i] = proposal
+	}
+	data.Proposals = proposals
+	return data
+}
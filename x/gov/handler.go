@@ -0,0 +1,155 @@
+package gov
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func NewHandler(keeper Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		// NOTE msg already has validate basic run
+		switch msg := msg.(type) {
+		case MsgSubmitProposal:
+			return handleMsgSubmitProposal(ctx, keeper, msg)
+		case MsgDeposit:
+			return handleMsgDeposit(ctx, keeper, msg)
+		case MsgVote:
+			return handleMsgVote(ctx, keeper, msg)
+		default:
+			return sdk.ErrTxDecode("invalid message parse in governance module").Result()
+		}
+	}
+}
+
+func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitProposal) sdk.Result {
+	proposal := keeper.NewProposal(ctx, msg.Title, msg.Description, msg.ProposalType)
+	if len(msg.ParamChanges) != 0 {
+		proposal.ParamChanges = msg.ParamChanges
+		keeper.setProposal(ctx, proposal)
+	}
+
+	activatedVotingPeriod, err := keeper.AddDeposit(ctx, proposal.ProposalID, msg.Proposer, msg.InitialDeposit)
+	if err != nil {
+		return err.Result()
+	}
+
+	proposalIDBytes := []byte(strconv.FormatInt(proposal.ProposalID, 10))
+	tags := sdk.NewTags(
+		"action", []byte("submitProposal"),
+		"proposer", msg.Proposer.Bytes(),
+		"proposal-id", proposalIDBytes,
+	)
+	if activatedVotingPeriod {
+		tags = tags.AppendTag("voting-period-start", proposalIDBytes)
+	}
+	return sdk.Result{
+		Data: proposalIDBytes,
+		Tags: tags,
+	}
+}
+
+func handleMsgDeposit(ctx sdk.Context, keeper Keeper, msg MsgDeposit) sdk.Result {
+	activatedVotingPeriod, err := keeper.AddDeposit(ctx, msg.ProposalID, msg.Depositer, msg.Amount)
+	if err != nil {
+		return err.Result()
+	}
+
+	proposalIDBytes := []byte(strconv.FormatInt(msg.ProposalID, 10))
+	tags := sdk.NewTags(
+		"action", []byte("deposit"),
+		"depositer", msg.Depositer.Bytes(),
+		"proposal-id", proposalIDBytes,
+	)
+	if activatedVotingPeriod {
+		tags = tags.AppendTag("voting-period-start", proposalIDBytes)
+	}
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+func handleMsgVote(ctx sdk.Context, keeper Keeper, msg MsgVote) sdk.Result {
+	err := keeper.AddVote(ctx, msg.ProposalID, msg.Voter, msg.Option)
+	if err != nil {
+		return err.Result()
+	}
+
+	tags := sdk.NewTags(
+		"action", []byte("vote"),
+		"voter", msg.Voter.Bytes(),
+		"proposal-id", []byte(strconv.FormatInt(msg.ProposalID, 10)),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+//_____________________________________________________________________
+
+// Called every block, closes the proposals whose deposit or voting period
+// has ended
+func EndBlocker(ctx sdk.Context, keeper Keeper) (tags sdk.Tags) {
+	logger := ctx.Logger().With("module", "x/gov")
+	tags = sdk.NewTags()
+
+	// proposals which did not reach the minimum deposit in time are dropped
+	// and their deposits burned
+	for _, proposal := range keeper.popInactiveProposalQueue(ctx, ctx.BlockHeight()) {
+		keeper.burnDeposits(ctx, proposal.ProposalID)
+		proposal.Status = StatusRejected
+		keeper.setProposal(ctx, proposal)
+
+		tags = tags.AppendTag("proposal-dropped", []byte(strconv.FormatInt(proposal.ProposalID, 10)))
+		logger.Info(fmt.Sprintf("Proposal %d - \"%s\" did not reach the minimum deposit, dropped",
+			proposal.ProposalID, proposal.Title))
+	}
+
+	// tally the proposals whose voting period has ended
+	for _, proposal := range keeper.popActiveProposalQueue(ctx, ctx.BlockHeight()) {
+		passes, vetoed, tallyResult := tally(ctx, keeper, proposal)
+		proposal.TallyResult = tallyResult
+
+		// vetoed proposals lose their deposits, all others get them back
+		if vetoed {
+			keeper.burnDeposits(ctx, proposal.ProposalID)
+		} else {
+			keeper.refundDeposits(ctx, proposal.ProposalID)
+		}
+
+		proposalIDBytes := []byte(strconv.FormatInt(proposal.ProposalID, 10))
+		if passes {
+			proposal.Status = StatusPassed
+			tags = tags.AppendTag("proposal-passed", proposalIDBytes)
+			keeper.executeProposal(ctx, proposal)
+		} else {
+			proposal.Status = StatusRejected
+			tags = tags.AppendTag("proposal-rejected", proposalIDBytes)
+		}
+		keeper.setProposal(ctx, proposal)
+
+		logger.Info(fmt.Sprintf("Proposal %d - \"%s\" tallied, status %s, result %s",
+			proposal.ProposalID, proposal.Title, proposal.Status, tallyResult))
+	}
+	return tags
+}
+
+// run the handler of a passed proposal, its state changes are only kept
+// if the handler succeeds
+func (keeper Keeper) executeProposal(ctx sdk.Context, proposal Proposal) {
+	handler, ok := keeper.proposalHandlers[proposal.ProposalType]
+	if !ok {
+		return
+	}
+
+	cacheCtx, writeCache := ctx.CacheContext()
+	err := handler(cacheCtx, proposal)
+	if err != nil {
+		ctx.Logger().With("module", "x/gov").Error(fmt.Sprintf(
+			"Proposal %d - \"%s\" passed but could not be executed: %s",
+			proposal.ProposalID, proposal.Title, err.ABCILog()))
+		return
+	}
+	writeCache()
+}
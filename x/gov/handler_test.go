@@ -0,0 +1,172 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestEndBlockerDropsProposal(t *testing.T) {
+	ctx, ck, _, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+
+	got := handler(ctx, NewMsgSubmitProposal("Test", "description", ProposalTypeText, addrs[0], sdk.Coins{{"steak", 5}}))
+	require.True(t, got.IsOK(), "%v", got)
+	require.Equal(t, initCoins-5, ck.GetCoins(ctx, addrs[0]).AmountOf("steak"))
+
+	proposal, found := keeper.GetProposal(ctx, 1)
+	require.True(t, found)
+
+	ctx = ctx.WithBlockHeight(proposal.DepositEndBlock - 1)
+	require.Equal(t, 0, len(EndBlocker(ctx, keeper)))
+
+	// the deposit period ends without reaching the minimum deposit
+	ctx = ctx.WithBlockHeight(proposal.DepositEndBlock)
+	tags := EndBlocker(ctx, keeper)
+	require.Equal(t, sdk.NewTags("proposal-dropped", []byte("1")), tags)
+	proposal, _ = keeper.GetProposal(ctx, 1)
+	require.Equal(t, StatusRejected, proposal.Status)
+	require.Equal(t, 0, len(keeper.GetDeposits(ctx, 1)))
+	require.Equal(t, initCoins-5, ck.GetCoins(ctx, addrs[0]).AmountOf("steak"))
+
+	// no more deposits on a dropped proposal
+	got = handler(ctx, NewMsgDeposit(1, addrs[1], sdk.Coins{{"steak", 5}}))
+	require.False(t, got.IsOK())
+}
+
+func TestEndBlockerPassesProposal(t *testing.T) {
+	ctx, ck, sk, keeper := createTestInput(t)
+	stakeHandler := stake.NewHandler(sk)
+	got := stakeHandler(ctx, newTestMsgCreateValidator(addrs[0], pks[0], 10))
+	require.True(t, got.IsOK(), "%v", got)
+
+	// the software upgrade handler records that it ran
+	executed := 0
+	keeper = keeper.AddProposalHandler(ProposalTypeSoftwareUpgrade, func(ctx sdk.Context, proposal Proposal) sdk.Error {
+		executed++
+		return nil
+	})
+	handler := NewHandler(keeper)
+
+	got = handler(ctx, NewMsgSubmitProposal("Upgrade", "description", ProposalTypeSoftwareUpgrade, addrs[1], sdk.Coins{{"steak", 5}}))
+	require.True(t, got.IsOK(), "%v", got)
+	require.Equal(t, []byte("1"), got.Data)
+	got = handler(ctx, NewMsgDeposit(1, addrs[2], sdk.Coins{{"steak", 5}}))
+	require.True(t, got.IsOK(), "%v", got)
+	got = handler(ctx, NewMsgVote(1, addrs[0], OptionYes))
+	require.True(t, got.IsOK(), "%v", got)
+
+	proposal, _ := keeper.GetProposal(ctx, 1)
+	require.Equal(t, StatusVotingPeriod, proposal.Status)
+	ctx = ctx.WithBlockHeight(proposal.VotingEndBlock)
+	tags := EndBlocker(ctx, keeper)
+	require.Equal(t, sdk.NewTags("proposal-passed", []byte("1")), tags)
+	require.Equal(t, 1, executed)
+
+	proposal, _ = keeper.GetProposal(ctx, 1)
+	require.Equal(t, StatusPassed, proposal.Status)
+	require.True(t, sdk.NewRat(10).Equal(proposal.TallyResult.Yes))
+
+	// deposits are refunded
+	require.Equal(t, initCoins, ck.GetCoins(ctx, addrs[1]).AmountOf("steak"))
+	require.Equal(t, initCoins, ck.GetCoins(ctx, addrs[2]).AmountOf("steak"))
+}
+
+func TestEndBlockerVetoedProposal(t *testing.T) {
+	ctx, ck, sk, keeper := createTestInput(t)
+	stakeHandler := stake.NewHandler(sk)
+	got := stakeHandler(ctx, newTestMsgCreateValidator(addrs[0], pks[0], 10))
+	require.True(t, got.IsOK(), "%v", got)
+
+	// a failing proposal handler must not be run for a rejected proposal
+	keeper = keeper.AddProposalHandler(ProposalTypeText, func(ctx sdk.Context, proposal Proposal) sdk.Error {
+		panic("should not be executed")
+	})
+	handler := NewHandler(keeper)
+
+	got = handler(ctx, NewMsgSubmitProposal("Test", "description", ProposalTypeText, addrs[1], sdk.Coins{{"steak", 10}}))
+	require.True(t, got.IsOK(), "%v", got)
+	got = handler(ctx, NewMsgVote(1, addrs[0], OptionNoWithVeto))
+	require.True(t, got.IsOK(), "%v", got)
+
+	proposal, _ := keeper.GetProposal(ctx, 1)
+	ctx = ctx.WithBlockHeight(proposal.VotingEndBlock)
+	tags := EndBlocker(ctx, keeper)
+	require.Equal(t, sdk.NewTags("proposal-rejected", []byte("1")), tags)
+
+	// the deposit of a vetoed proposal is burned
+	proposal, _ = keeper.GetProposal(ctx, 1)
+	require.Equal(t, StatusRejected, proposal.Status)
+	require.Equal(t, initCoins-10, ck.GetCoins(ctx, addrs[1]).AmountOf("steak"))
+}
+
+func TestFailedProposalHandlerDiscardsChanges(t *testing.T) {
+	ctx, ck, sk, keeper := createTestInput(t)
+	stakeHandler := stake.NewHandler(sk)
+	got := stakeHandler(ctx, newTestMsgCreateValidator(addrs[0], pks[0], 10))
+	require.True(t, got.IsOK(), "%v", got)
+
+	keeper = keeper.AddProposalHandler(ProposalTypeText, func(ctx sdk.Context, proposal Proposal) sdk.Error {
+		ck.MintCoins(ctx, addrs[3], sdk.Coins{{"steak", 1000}})
+		return sdk.ErrInternal("failed")
+	})
+	handler := NewHandler(keeper)
+
+	got = handler(ctx, NewMsgSubmitProposal("Test", "description", ProposalTypeText, addrs[1], sdk.Coins{{"steak", 10}}))
+	require.True(t, got.IsOK(), "%v", got)
+	got = handler(ctx, NewMsgVote(1, addrs[0], OptionYes))
+	require.True(t, got.IsOK(), "%v", got)
+
+	proposal, _ := keeper.GetProposal(ctx, 1)
+	ctx = ctx.WithBlockHeight(proposal.VotingEndBlock)
+	EndBlocker(ctx, keeper)
+
+	proposal, _ = keeper.GetProposal(ctx, 1)
+	require.Equal(t, StatusPassed, proposal.Status)
+	require.Equal(t, initCoins, ck.GetCoins(ctx, addrs[3]).AmountOf("steak"))
+}
+
+func TestEndBlockerAppliesParamChanges(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+	stakeHandler := stake.NewHandler(sk)
+	got := stakeHandler(ctx, newTestMsgCreateValidator(addrs[0], pks[0], 10))
+	require.True(t, got.IsOK(), "%v", got)
+	handler := NewHandler(keeper)
+
+	changes := []ParamChange{
+		{stake.DefaultParamspace, string(stake.KeyMaxValidators), "5"},
+		{DefaultParamspace, string(KeyVotingProcedure), `{"voting_period":"10"}`},
+	}
+	got = handler(ctx, NewMsgSubmitParamChangeProposal("Params", "description", addrs[1], sdk.Coins{{"steak", 10}}, changes))
+	require.True(t, got.IsOK(), "%v", got)
+	got = handler(ctx, NewMsgVote(1, addrs[0], OptionYes))
+	require.True(t, got.IsOK(), "%v", got)
+
+	proposal, _ := keeper.GetProposal(ctx, 1)
+	require.Equal(t, changes, proposal.ParamChanges)
+	ctx = ctx.WithBlockHeight(proposal.VotingEndBlock)
+	tags := EndBlocker(ctx, keeper)
+	require.Equal(t, sdk.NewTags("proposal-passed", []byte("1")), tags)
+	require.Equal(t, uint16(5), sk.GetParams(ctx).MaxValidators)
+	require.Equal(t, int64(10), keeper.GetVotingProcedure(ctx).VotingPeriod)
+
+	// a single invalid change discards all the changes of the proposal
+	changes = []ParamChange{
+		{stake.DefaultParamspace, string(stake.KeyMaxValidators), "7"},
+		{stake.DefaultParamspace, string(stake.KeyBondDenom), `""`},
+	}
+	got = handler(ctx, NewMsgSubmitParamChangeProposal("Params", "description", addrs[1], sdk.Coins{{"steak", 10}}, changes))
+	require.True(t, got.IsOK(), "%v", got)
+	got = handler(ctx, NewMsgVote(2, addrs[0], OptionYes))
+	require.True(t, got.IsOK(), "%v", got)
+
+	proposal, _ = keeper.GetProposal(ctx, 2)
+	ctx = ctx.WithBlockHeight(proposal.VotingEndBlock)
+	tags = EndBlocker(ctx, keeper)
+	require.Equal(t, sdk.NewTags("proposal-passed", []byte("2")), tags)
+	require.Equal(t, uint16(5), sk.GetParams(ctx).MaxValidators)
+	require.Equal(t, "steak", sk.GetParams(ctx).BondDenom)
+}
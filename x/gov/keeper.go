@@ -0,0 +1,420 @@
+package gov
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// ProposalHandler executes a proposal which has passed its vote
+type ProposalHandler func(ctx sdk.Context, proposal Proposal) sdk.Error
+
+// keeper of the governance store
+type Keeper struct {
+	storeKey     sdk.StoreKey
+	cdc          *wire.Codec
+	coinKeeper   bank.Keeper
+	validatorSet sdk.ValidatorSet
+	delegatorSet sdk.DelegationSet
+	paramSpace   params.Subspace
+
+	// handlers executing passed proposals, by proposal type
+	proposalHandlers map[ProposalKind]ProposalHandler
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, vs sdk.ValidatorSet,
+	ds sdk.DelegationSet, paramSpace params.Subspace, codespace sdk.CodespaceType) Keeper {
+
+	keeper := Keeper{
+		storeKey:         key,
+		cdc:              cdc,
+		coinKeeper:       ck,
+		validatorSet:     vs,
+		delegatorSet:     ds,
+		paramSpace:       paramSpace.WithParamSet(&procedures{}),
+		proposalHandlers: make(map[ProposalKind]ProposalHandler),
+		codespace:        codespace,
+	}
+	return keeper
+}
+
+// register the handler executing the passed proposals of a proposal type
+func (keeper Keeper) AddProposalHandler(proposalType ProposalKind, handler ProposalHandler) Keeper {
+	if !validProposalType(proposalType) {
+		panic(fmt.Sprintf("unknown proposal type %v", proposalType))
+	}
+	if _, ok := keeper.proposalHandlers[proposalType]; ok {
+		panic(fmt.Sprintf("proposal handler for %v already registered", proposalType))
+	}
+	keeper.proposalHandlers[proposalType] = handler
+	return keeper
+}
+
+//_________________________________________________________________________
+
+// create a new proposal in its deposit period
+func (keeper Keeper) NewProposal(ctx sdk.Context, title string, description string, proposalType ProposalKind) Proposal {
+	proposalID := keeper.getNewProposalID(ctx)
+	proposal := Proposal{
+		ProposalID:       proposalID,
+		Title:            title,
+		Description:      description,
+		ProposalType:     proposalType,
+		Status:           StatusDepositPeriod,
+		TallyResult:      EmptyTallyResult(),
+		SubmitBlock:      ctx.BlockHeight(),
+		DepositEndBlock:  ctx.BlockHeight() + keeper.GetDepositProcedure(ctx).MaxDepositPeriod,
+		TotalDeposit:     sdk.Coins{},
+		VotingStartBlock: -1,
+		VotingEndBlock:   -1,
+	}
+	keeper.setProposal(ctx, proposal)
+	keeper.insertProposalQueue(ctx, proposal)
+	return proposal
+}
+
+// get a proposal
+func (keeper Keeper) GetProposal(ctx sdk.Context, proposalID int64) (proposal Proposal, found bool) {
+	store := ctx.KVStore(keeper.storeKey)
+	b := store.Get(GetProposalKey(proposalID))
+	if b == nil {
+		return proposal, false
+	}
+	keeper.cdc.MustUnmarshalBinary(b, &proposal)
+	return proposal, true
+}
+
+// get the set of proposals, the most recent ones first, retrieve at most
+// maxRetrieve proposals
+func (keeper Keeper) GetProposals(ctx sdk.Context, maxRetrieve int16) (proposals []Proposal) {
+	store := ctx.KVStore(keeper.storeKey)
+	iterator := sdk.KVStoreReversePrefixIterator(store, ProposalsKey)
+
+	i := 0
+	for ; ; i++ {
+		if !iterator.Valid() || i > int(maxRetrieve-1) {
+			break
+		}
+		var proposal Proposal
+		keeper.cdc.MustUnmarshalBinary(iterator.Value(), &proposal)
+		proposals = append(proposals, proposal)
+		iterator.Next()
+	}
+	iterator.Close()
+	return proposals
+}
+
+// load all proposals used during genesis dump
+func (keeper Keeper) getAllProposals(ctx sdk.Context) (proposals []Proposal) {
+	store := ctx.KVStore(keeper.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, ProposalsKey)
+	for ; iterator.Valid(); iterator.Next() {
+		var proposal Proposal
+		keeper.cdc.MustUnmarshalBinary(iterator.Value(), &proposal)
+		proposals = append(proposals, proposal)
+	}
+	iterator.Close()
+	return proposals
+}
+
+func (keeper Keeper) setProposal(ctx sdk.Context, proposal Proposal) {
+	store := ctx.KVStore(keeper.storeKey)
+	b := keeper.cdc.MustMarshalBinary(proposal)
+	store.Set(GetProposalKey(proposal.ProposalID), b)
+}
+
+// get the next proposal ID and increment the counter
+func (keeper Keeper) getNewProposalID(ctx sdk.Context) (proposalID int64) {
+	proposalID = keeper.peekNewProposalID(ctx)
+	keeper.setNewProposalID(ctx, proposalID+1)
+	return proposalID
+}
+
+// get the next proposal ID without incrementing the counter
+func (keeper Keeper) peekNewProposalID(ctx sdk.Context) (proposalID int64) {
+	store := ctx.KVStore(keeper.storeKey)
+	b := store.Get(NewProposalIDKey)
+	if b == nil {
+		panic("Stored new proposal ID should not have been nil")
+	}
+	keeper.cdc.MustUnmarshalBinary(b, &proposalID)
+	return proposalID
+}
+
+func (keeper Keeper) setNewProposalID(ctx sdk.Context, proposalID int64) {
+	store := ctx.KVStore(keeper.storeKey)
+	b := keeper.cdc.MustMarshalBinary(proposalID)
+	store.Set(NewProposalIDKey, b)
+}
+
+// move a proposal from its deposit period into its voting period
+func (keeper Keeper) activateVotingPeriod(ctx sdk.Context, proposal Proposal) Proposal {
+	store := ctx.KVStore(keeper.storeKey)
+	store.Delete(GetInactiveProposalQueueKey(proposal.DepositEndBlock, proposal.ProposalID))
+
+	proposal.Status = StatusVotingPeriod
+	proposal.VotingStartBlock = ctx.BlockHeight()
+	proposal.VotingEndBlock = proposal.VotingStartBlock + keeper.GetVotingProcedure(ctx).VotingPeriod
+	keeper.setProposal(ctx, proposal)
+	keeper.insertProposalQueue(ctx, proposal)
+	return proposal
+}
+
+// insert a proposal in its deposit or voting period into the queue of that
+// period, keyed by the height at which the period ends
+func (keeper Keeper) insertProposalQueue(ctx sdk.Context, proposal Proposal) {
+	store := ctx.KVStore(keeper.storeKey)
+	switch proposal.Status {
+	case StatusDepositPeriod:
+		store.Set(GetInactiveProposalQueueKey(proposal.DepositEndBlock, proposal.ProposalID), bigEndianBytes(proposal.ProposalID))
+	case StatusVotingPeriod:
+		store.Set(GetActiveProposalQueueKey(proposal.VotingEndBlock, proposal.ProposalID), bigEndianBytes(proposal.ProposalID))
+	}
+}
+
+// get the proposals of a proposal queue whose period ends at or before
+// the provided height and remove them from the queue
+func (keeper Keeper) popProposalQueue(ctx sdk.Context, queueKey, endKey []byte) (proposals []Proposal) {
+	store := ctx.KVStore(keeper.storeKey)
+	iterator := store.Iterator(queueKey, endKey)
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+		proposalID := int64(binary.BigEndian.Uint64(iterator.Value()))
+		proposal, found := keeper.GetProposal(ctx, proposalID)
+		if !found {
+			panic(fmt.Sprintf("proposal record not found for queue key: %v\n", iterator.Key()))
+		}
+		proposals = append(proposals, proposal)
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+	return proposals
+}
+
+// pop the proposals whose deposit period has ended by the provided height
+func (keeper Keeper) popInactiveProposalQueue(ctx sdk.Context, height int64) []Proposal {
+	return keeper.popProposalQueue(ctx, InactiveProposalQueueKey, GetInactiveProposalQueueHeightKey(height+1))
+}
+
+// pop the proposals whose voting period has ended by the provided height
+func (keeper Keeper) popActiveProposalQueue(ctx sdk.Context, height int64) []Proposal {
+	return keeper.popProposalQueue(ctx, ActiveProposalQueueKey, GetActiveProposalQueueHeightKey(height+1))
+}
+
+//_________________________________________________________________________
+
+// load/save the governance procedures
+func (keeper Keeper) GetDepositProcedure(ctx sdk.Context) (procedure DepositProcedure) {
+	keeper.paramSpace.Get(ctx, KeyDepositProcedure, &procedure)
+	return
+}
+
+func (keeper Keeper) GetVotingProcedure(ctx sdk.Context) (procedure VotingProcedure) {
+	keeper.paramSpace.Get(ctx, KeyVotingProcedure, &procedure)
+	return
+}
+
+func (keeper Keeper) GetTallyingProcedure(ctx sdk.Context) (procedure TallyingProcedure) {
+	keeper.paramSpace.Get(ctx, KeyTallyingProcedure, &procedure)
+	return
+}
+
+func (keeper Keeper) setDepositProcedure(ctx sdk.Context, procedure DepositProcedure) {
+	keeper.paramSpace.Set(ctx, KeyDepositProcedure, procedure)
+}
+
+func (keeper Keeper) setVotingProcedure(ctx sdk.Context, procedure VotingProcedure) {
+	keeper.paramSpace.Set(ctx, KeyVotingProcedure, procedure)
+}
+
+func (keeper Keeper) setTallyingProcedure(ctx sdk.Context, procedure TallyingProcedure) {
+	keeper.paramSpace.Set(ctx, KeyTallyingProcedure, procedure)
+}
+
+//_________________________________________________________________________
+
+// get the deposit of a depositer on a proposal
+func (keeper Keeper) GetDeposit(ctx sdk.Context, proposalID int64, depositerAddr sdk.Address) (deposit Deposit, found bool) {
+	store := ctx.KVStore(keeper.storeKey)
+	b := store.Get(GetDepositKey(proposalID, depositerAddr))
+	if b == nil {
+		return deposit, false
+	}
+	keeper.cdc.MustUnmarshalBinary(b, &deposit)
+	return deposit, true
+}
+
+// get all deposits on a proposal
+func (keeper Keeper) GetDeposits(ctx sdk.Context, proposalID int64) (deposits []Deposit) {
+	return keeper.getDeposits(ctx, GetDepositsKey(proposalID))
+}
+
+// load all deposits used during genesis dump
+func (keeper Keeper) getAllDeposits(ctx sdk.Context) (deposits []Deposit) {
+	return keeper.getDeposits(ctx, DepositsKey)
+}
+
+func (keeper Keeper) getDeposits(ctx sdk.Context, prefix []byte) (deposits []Deposit) {
+	store := ctx.KVStore(keeper.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	for ; iterator.Valid(); iterator.Next() {
+		var deposit Deposit
+		keeper.cdc.MustUnmarshalBinary(iterator.Value(), &deposit)
+		deposits = append(deposits, deposit)
+	}
+	iterator.Close()
+	return deposits
+}
+
+func (keeper Keeper) setDeposit(ctx sdk.Context, deposit Deposit) {
+	store := ctx.KVStore(keeper.storeKey)
+	b := keeper.cdc.MustMarshalBinary(deposit)
+	store.Set(GetDepositKey(deposit.ProposalID, deposit.Depositer), b)
+}
+
+func (keeper Keeper) removeDeposit(ctx sdk.Context, deposit Deposit) {
+	store := ctx.KVStore(keeper.storeKey)
+	store.Delete(GetDepositKey(deposit.ProposalID, deposit.Depositer))
+}
+
+// add a deposit on a proposal in its deposit period, the deposited coins are
+// taken from the depositer and the proposal enters its voting period once the
+// minimum deposit has been reached
+func (keeper Keeper) AddDeposit(ctx sdk.Context, proposalID int64, depositerAddr sdk.Address,
+	depositAmount sdk.Coins) (activatedVotingPeriod bool, err sdk.Error) {
+
+	proposal, found := keeper.GetProposal(ctx, proposalID)
+	if !found {
+		return false, ErrUnknownProposal(keeper.codespace, proposalID)
+	}
+	if proposal.Status != StatusDepositPeriod {
+		return false, ErrAlreadyActiveProposal(keeper.codespace, proposalID)
+	}
+
+	_, _, err = keeper.coinKeeper.SubtractCoins(ctx, depositerAddr, depositAmount)
+	if err != nil {
+		return false, err
+	}
+
+	deposit, found := keeper.GetDeposit(ctx, proposalID, depositerAddr)
+	if !found {
+		deposit = Deposit{
+			Depositer:  depositerAddr,
+			ProposalID: proposalID,
+			Amount:     sdk.Coins{},
+		}
+	}
+	deposit.Amount = deposit.Amount.Plus(depositAmount)
+	keeper.setDeposit(ctx, deposit)
+
+	proposal.TotalDeposit = proposal.TotalDeposit.Plus(depositAmount)
+	keeper.setProposal(ctx, proposal)
+
+	if proposal.TotalDeposit.IsGTE(keeper.GetDepositProcedure(ctx).MinDeposit) {
+		keeper.activateVotingPeriod(ctx, proposal)
+		return true, nil
+	}
+	return false, nil
+}
+
+// return all deposits on a proposal to their depositers
+func (keeper Keeper) refundDeposits(ctx sdk.Context, proposalID int64) {
+	for _, deposit := range keeper.GetDeposits(ctx, proposalID) {
+		_, _, err := keeper.coinKeeper.AddCoins(ctx, deposit.Depositer, deposit.Amount)
+		if err != nil {
+			panic(err) // adding coins to an account cannot fail
+		}
+		keeper.removeDeposit(ctx, deposit)
+	}
+}
+
+// burn all deposits on a proposal, the coins were already taken from the
+// depositers so removing the deposits from the supply is all that is needed
+func (keeper Keeper) burnDeposits(ctx sdk.Context, proposalID int64) {
+	for _, deposit := range keeper.GetDeposits(ctx, proposalID) {
+		keeper.coinKeeper.DeflateSupply(ctx, deposit.Amount)
+		keeper.removeDeposit(ctx, deposit)
+	}
+}
+
+// HeldCoins returns the coins held in the deposits of all proposals
+func (keeper Keeper) HeldCoins(ctx sdk.Context) (held sdk.Coins) {
+	for _, deposit := range keeper.getAllDeposits(ctx) {
+		held = held.Plus(deposit.Amount)
+	}
+	return held
+}
+
+//_________________________________________________________________________
+
+// get the vote of a voter on a proposal
+func (keeper Keeper) GetVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Address) (vote Vote, found bool) {
+	store := ctx.KVStore(keeper.storeKey)
+	b := store.Get(GetVoteKey(proposalID, voterAddr))
+	if b == nil {
+		return vote, false
+	}
+	keeper.cdc.MustUnmarshalBinary(b, &vote)
+	return vote, true
+}
+
+// get all votes on a proposal
+func (keeper Keeper) GetVotes(ctx sdk.Context, proposalID int64) (votes []Vote) {
+	return keeper.getVotes(ctx, GetVotesKey(proposalID))
+}
+
+// load all votes used during genesis dump
+func (keeper Keeper) getAllVotes(ctx sdk.Context) (votes []Vote) {
+	return keeper.getVotes(ctx, VotesKey)
+}
+
+func (keeper Keeper) getVotes(ctx sdk.Context, prefix []byte) (votes []Vote) {
+	store := ctx.KVStore(keeper.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	for ; iterator.Valid(); iterator.Next() {
+		var vote Vote
+		keeper.cdc.MustUnmarshalBinary(iterator.Value(), &vote)
+		votes = append(votes, vote)
+	}
+	iterator.Close()
+	return votes
+}
+
+func (keeper Keeper) setVote(ctx sdk.Context, vote Vote) {
+	store := ctx.KVStore(keeper.storeKey)
+	b := keeper.cdc.MustMarshalBinary(vote)
+	store.Set(GetVoteKey(vote.ProposalID, vote.Voter), b)
+}
+
+// cast the vote of a voter on a proposal in its voting period, a later vote
+// replaces an earlier one
+func (keeper Keeper) AddVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Address, option VoteOption) sdk.Error {
+	proposal, found := keeper.GetProposal(ctx, proposalID)
+	if !found {
+		return ErrUnknownProposal(keeper.codespace, proposalID)
+	}
+	if proposal.Status != StatusVotingPeriod {
+		return ErrInactiveProposal(keeper.codespace, proposalID)
+	}
+	if !validVoteOption(option) {
+		return ErrInvalidVote(keeper.codespace, option)
+	}
+
+	keeper.setVote(ctx, Vote{
+		Voter:      voterAddr,
+		ProposalID: proposalID,
+		Option:     option,
+	})
+	return nil
+}
@@ -0,0 +1,70 @@
+package gov
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+//nolint
+var (
+	// Keys for store prefixes
+	NewProposalIDKey         = []byte{0x00} // key for the next proposal ID
+	ProposalsKey             = []byte{0x01} // prefix for each key to a proposal
+	DepositsKey              = []byte{0x02} // prefix for each key to a deposit
+	VotesKey                 = []byte{0x03} // prefix for each key to a vote
+	InactiveProposalQueueKey = []byte{0x04} // prefix for the proposals in their deposit period, by end height
+	ActiveProposalQueueKey   = []byte{0x05} // prefix for the proposals in their voting period, by end height
+)
+
+// get the key for a proposal
+func GetProposalKey(proposalID int64) []byte {
+	return append(ProposalsKey, bigEndianBytes(proposalID)...)
+}
+
+// get the key for a deposit on a proposal
+func GetDepositKey(proposalID int64, depositerAddr sdk.Address) []byte {
+	return append(GetDepositsKey(proposalID), depositerAddr.Bytes()...)
+}
+
+// get the prefix for all deposits on a proposal
+func GetDepositsKey(proposalID int64) []byte {
+	return append(DepositsKey, bigEndianBytes(proposalID)...)
+}
+
+// get the key for a vote on a proposal
+func GetVoteKey(proposalID int64, voterAddr sdk.Address) []byte {
+	return append(GetVotesKey(proposalID), voterAddr.Bytes()...)
+}
+
+// get the prefix for all votes on a proposal
+func GetVotesKey(proposalID int64) []byte {
+	return append(VotesKey, bigEndianBytes(proposalID)...)
+}
+
+// get the key for a proposal within the inactive proposal queue
+func GetInactiveProposalQueueKey(endHeight, proposalID int64) []byte {
+	return append(GetInactiveProposalQueueHeightKey(endHeight), bigEndianBytes(proposalID)...)
+}
+
+// get the prefix for all inactive proposals whose deposit period ends at a height
+func GetInactiveProposalQueueHeightKey(endHeight int64) []byte {
+	return append(InactiveProposalQueueKey, bigEndianBytes(endHeight)...)
+}
+
+// get the key for a proposal within the active proposal queue
+func GetActiveProposalQueueKey(endHeight, proposalID int64) []byte {
+	return append(GetActiveProposalQueueHeightKey(endHeight), bigEndianBytes(proposalID)...)
+}
+
+// get the prefix for all active proposals whose voting period ends at a height
+func GetActiveProposalQueueHeightKey(endHeight int64) []byte {
+	return append(ActiveProposalQueueKey, bigEndianBytes(endHeight)...)
+}
+
+// big-endian encoding of a non-negative int64 so keys sort in order
+func bigEndianBytes(i int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(i))
+	return b
+}
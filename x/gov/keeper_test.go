@@ -0,0 +1,164 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestGetSetProposal(t *testing.T) {
+	ctx, _, _, keeper := createTestInput(t)
+
+	proposal := keeper.NewProposal(ctx, "Test", "description", ProposalTypeText)
+	require.Equal(t, int64(1), proposal.ProposalID)
+	require.Equal(t, StatusDepositPeriod, proposal.Status)
+
+	gotProposal, found := keeper.GetProposal(ctx, proposal.ProposalID)
+	require.True(t, found)
+	require.Equal(t, proposal.Title, gotProposal.Title)
+	require.Equal(t, proposal.DepositEndBlock, gotProposal.DepositEndBlock)
+
+	proposal2 := keeper.NewProposal(ctx, "Test2", "description", ProposalTypeSoftwareUpgrade)
+	require.Equal(t, int64(2), proposal2.ProposalID)
+
+	proposals := keeper.GetProposals(ctx, 5)
+	require.Equal(t, 2, len(proposals))
+	require.Equal(t, proposal2.ProposalID, proposals[0].ProposalID)
+
+	_, found = keeper.GetProposal(ctx, 3)
+	require.False(t, found)
+}
+
+func TestDeposits(t *testing.T) {
+	ctx, ck, _, keeper := createTestInput(t)
+
+	proposal := keeper.NewProposal(ctx, "Test", "description", ProposalTypeText)
+	proposalID := proposal.ProposalID
+
+	fourSteak := sdk.Coins{{"steak", 4}}
+	fiveSteak := sdk.Coins{{"steak", 5}}
+
+	_, found := keeper.GetDeposit(ctx, proposalID, addrs[0])
+	require.False(t, found)
+
+	// first deposit, not enough to start the voting period
+	votingStarted, err := keeper.AddDeposit(ctx, proposalID, addrs[0], fourSteak)
+	require.Nil(t, err)
+	require.False(t, votingStarted)
+	deposit, found := keeper.GetDeposit(ctx, proposalID, addrs[0])
+	require.True(t, found)
+	require.Equal(t, fourSteak, deposit.Amount)
+	require.Equal(t, initCoins-4, ck.GetCoins(ctx, addrs[0]).AmountOf("steak"))
+
+	// second deposit from the same depositer adds up
+	votingStarted, err = keeper.AddDeposit(ctx, proposalID, addrs[0], fiveSteak)
+	require.Nil(t, err)
+	require.False(t, votingStarted)
+	deposit, _ = keeper.GetDeposit(ctx, proposalID, addrs[0])
+	require.Equal(t, sdk.Coins{{"steak", 9}}, deposit.Amount)
+
+	// cannot deposit more than owned
+	_, err = keeper.AddDeposit(ctx, proposalID, addrs[1], sdk.Coins{{"steak", initCoins + 1}})
+	require.NotNil(t, err)
+
+	// the minimum deposit is reached
+	votingStarted, err = keeper.AddDeposit(ctx, proposalID, addrs[1], fourSteak)
+	require.Nil(t, err)
+	require.True(t, votingStarted)
+	proposal, _ = keeper.GetProposal(ctx, proposalID)
+	require.Equal(t, StatusVotingPeriod, proposal.Status)
+	require.Equal(t, sdk.Coins{{"steak", 13}}, proposal.TotalDeposit)
+	require.Equal(t, 2, len(keeper.GetDeposits(ctx, proposalID)))
+
+	// no deposits once the voting period has started
+	_, err = keeper.AddDeposit(ctx, proposalID, addrs[1], fourSteak)
+	require.NotNil(t, err)
+
+	// refunding returns the deposits
+	keeper.refundDeposits(ctx, proposalID)
+	require.Equal(t, 0, len(keeper.GetDeposits(ctx, proposalID)))
+	require.Equal(t, initCoins, ck.GetCoins(ctx, addrs[0]).AmountOf("steak"))
+	require.Equal(t, initCoins, ck.GetCoins(ctx, addrs[1]).AmountOf("steak"))
+}
+
+func TestVotes(t *testing.T) {
+	ctx, _, _, keeper := createTestInput(t)
+
+	proposal := keeper.NewProposal(ctx, "Test", "description", ProposalTypeText)
+	proposalID := proposal.ProposalID
+
+	// no votes during the deposit period
+	err := keeper.AddVote(ctx, proposalID, addrs[0], OptionYes)
+	require.NotNil(t, err)
+
+	_, err = keeper.AddDeposit(ctx, proposalID, addrs[0], sdk.Coins{{"steak", 10}})
+	require.Nil(t, err)
+
+	err = keeper.AddVote(ctx, proposalID, addrs[0], OptionAbstain)
+	require.Nil(t, err)
+	vote, found := keeper.GetVote(ctx, proposalID, addrs[0])
+	require.True(t, found)
+	require.Equal(t, OptionAbstain, vote.Option)
+
+	// a later vote replaces an earlier one
+	err = keeper.AddVote(ctx, proposalID, addrs[0], OptionYes)
+	require.Nil(t, err)
+	vote, _ = keeper.GetVote(ctx, proposalID, addrs[0])
+	require.Equal(t, OptionYes, vote.Option)
+
+	err = keeper.AddVote(ctx, proposalID, addrs[1], OptionNoWithVeto)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(keeper.GetVotes(ctx, proposalID)))
+
+	// invalid options and unknown proposals are refused
+	err = keeper.AddVote(ctx, proposalID, addrs[2], OptionEmpty)
+	require.NotNil(t, err)
+	err = keeper.AddVote(ctx, proposalID+1, addrs[2], OptionYes)
+	require.NotNil(t, err)
+}
+
+func TestProposalQueues(t *testing.T) {
+	ctx, _, _, keeper := createTestInput(t)
+
+	proposal := keeper.NewProposal(ctx, "Test", "description", ProposalTypeText)
+	require.Equal(t, 0, len(keeper.popInactiveProposalQueue(ctx, proposal.DepositEndBlock-1)))
+
+	_, err := keeper.AddDeposit(ctx, proposal.ProposalID, addrs[0], sdk.Coins{{"steak", 10}})
+	require.Nil(t, err)
+	proposal, _ = keeper.GetProposal(ctx, proposal.ProposalID)
+
+	// the proposal has moved from the inactive to the active queue
+	require.Equal(t, 0, len(keeper.popInactiveProposalQueue(ctx, proposal.DepositEndBlock)))
+	require.Equal(t, 0, len(keeper.popActiveProposalQueue(ctx, proposal.VotingEndBlock-1)))
+	popped := keeper.popActiveProposalQueue(ctx, proposal.VotingEndBlock)
+	require.Equal(t, 1, len(popped))
+	require.Equal(t, proposal.ProposalID, popped[0].ProposalID)
+	require.Equal(t, 0, len(keeper.popActiveProposalQueue(ctx, proposal.VotingEndBlock)))
+}
+
+func TestGenesis(t *testing.T) {
+	ctx, _, _, keeper := createTestInput(t)
+
+	proposal := keeper.NewProposal(ctx, "Test", "description", ProposalTypeText)
+	_, err := keeper.AddDeposit(ctx, proposal.ProposalID, addrs[0], sdk.Coins{{"steak", 10}})
+	require.Nil(t, err)
+	err = keeper.AddVote(ctx, proposal.ProposalID, addrs[0], OptionYes)
+	require.Nil(t, err)
+	keeper.NewProposal(ctx, "Test2", "description", ProposalTypeText)
+
+	genesis := WriteGenesis(ctx, keeper)
+	require.Equal(t, int64(3), genesis.StartingProposalID)
+	require.Equal(t, 2, len(genesis.Proposals))
+	require.Equal(t, 1, len(genesis.Deposits))
+	require.Equal(t, 1, len(genesis.Votes))
+
+	// import into a fresh store, the proposal queues are rebuilt
+	ctx2, _, _, keeper2 := createTestInput(t)
+	InitGenesis(ctx2, keeper2, genesis)
+	require.Equal(t, genesis, WriteGenesis(ctx2, keeper2))
+	proposal, _ = keeper2.GetProposal(ctx2, proposal.ProposalID)
+	require.Equal(t, 1, len(keeper2.popActiveProposalQueue(ctx2, proposal.VotingEndBlock)))
+	require.Equal(t, 1, len(keeper2.popInactiveProposalQueue(ctx2, proposal.DepositEndBlock)))
+}
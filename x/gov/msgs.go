@@ -0,0 +1,213 @@
+package gov
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+var msgCdc = wire.NewCodec()
+
+// name to identify transaction types
+const MsgType = "gov"
+
+// verify interface at compile time
+var _, _, _ sdk.Msg = MsgSubmitProposal{}, MsgDeposit{}, MsgVote{}
+
+//______________________________________________________________________
+
+// MsgSubmitProposal - submit a new proposal along with an initial deposit
+type MsgSubmitProposal struct {
+	Title          string        `json:"title"`           //  Title of the proposal
+	Description    string        `json:"description"`     //  Description of the proposal
+	ProposalType   ProposalKind  `json:"proposal_type"`   //  Type of proposal
+	Proposer       sdk.Address   `json:"proposer"`        //  Address of the proposer
+	InitialDeposit sdk.Coins     `json:"initial_deposit"` //  Initial deposit paid by the proposer, must be strictly positive
+	ParamChanges   []ParamChange `json:"param_changes"`   //  Parameters changed once passed, for parameter change proposals
+}
+
+func NewMsgSubmitProposal(title string, description string, proposalType ProposalKind,
+	proposer sdk.Address, initialDeposit sdk.Coins) MsgSubmitProposal {
+
+	return MsgSubmitProposal{
+		Title:          title,
+		Description:    description,
+		ProposalType:   proposalType,
+		Proposer:       proposer,
+		InitialDeposit: initialDeposit,
+	}
+}
+
+func NewMsgSubmitParamChangeProposal(title string, description string, proposer sdk.Address,
+	initialDeposit sdk.Coins, paramChanges []ParamChange) MsgSubmitProposal {
+
+	return MsgSubmitProposal{
+		Title:          title,
+		Description:    description,
+		ProposalType:   ProposalTypeParameterChange,
+		Proposer:       proposer,
+		InitialDeposit: initialDeposit,
+		ParamChanges:   paramChanges,
+	}
+}
+
+//nolint
+func (msg MsgSubmitProposal) Type() string              { return MsgType }
+func (msg MsgSubmitProposal) GetSigners() []sdk.Address { return []sdk.Address{msg.Proposer} }
+
+// get the bytes for the message signer to sign on
+func (msg MsgSubmitProposal) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		Title          string        `json:"title"`
+		Description    string        `json:"description"`
+		ProposalType   ProposalKind  `json:"proposal_type"`
+		Proposer       string        `json:"proposer"`
+		InitialDeposit sdk.Coins     `json:"initial_deposit"`
+		ParamChanges   []ParamChange `json:"param_changes"`
+	}{
+		Title:          msg.Title,
+		Description:    msg.Description,
+		ProposalType:   msg.ProposalType,
+		Proposer:       sdk.MustBech32ifyAcc(msg.Proposer),
+		InitialDeposit: msg.InitialDeposit,
+		ParamChanges:   msg.ParamChanges,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check
+func (msg MsgSubmitProposal) ValidateBasic() sdk.Error {
+	if len(msg.Title) == 0 {
+		return ErrInvalidTitle(DefaultCodespace, msg.Title)
+	}
+	if len(msg.Description) == 0 {
+		return ErrInvalidDescription(DefaultCodespace, msg.Description)
+	}
+	if !validProposalType(msg.ProposalType) {
+		return ErrInvalidProposalType(DefaultCodespace, msg.ProposalType)
+	}
+	if msg.Proposer == nil {
+		return ErrInvalidAddress(DefaultCodespace)
+	}
+	if !msg.InitialDeposit.IsValid() || !msg.InitialDeposit.IsPositive() {
+		return ErrInvalidDeposit(DefaultCodespace, msg.InitialDeposit)
+	}
+	if msg.ProposalType == ProposalTypeParameterChange && len(msg.ParamChanges) == 0 {
+		return ErrInvalidParamChange(DefaultCodespace, "a parameter change proposal must change parameters")
+	}
+	if msg.ProposalType != ProposalTypeParameterChange && len(msg.ParamChanges) != 0 {
+		return ErrInvalidParamChange(DefaultCodespace, "only parameter change proposals may change parameters")
+	}
+	for _, change := range msg.ParamChanges {
+		if len(change.Subspace) == 0 || len(change.Key) == 0 || len(change.Value) == 0 {
+			return ErrInvalidParamChange(DefaultCodespace, change.String())
+		}
+	}
+	return nil
+}
+
+//______________________________________________________________________
+
+// MsgDeposit - add to the deposit of a proposal in its deposit period
+type MsgDeposit struct {
+	ProposalID int64       `json:"proposal_id"` //  ID of the proposal
+	Depositer  sdk.Address `json:"depositer"`   //  Address of the depositer
+	Amount     sdk.Coins   `json:"amount"`      //  Coins to add to the proposal's deposit
+}
+
+func NewMsgDeposit(proposalID int64, depositer sdk.Address, amount sdk.Coins) MsgDeposit {
+	return MsgDeposit{
+		ProposalID: proposalID,
+		Depositer:  depositer,
+		Amount:     amount,
+	}
+}
+
+//nolint
+func (msg MsgDeposit) Type() string              { return MsgType }
+func (msg MsgDeposit) GetSigners() []sdk.Address { return []sdk.Address{msg.Depositer} }
+
+// get the bytes for the message signer to sign on
+func (msg MsgDeposit) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		ProposalID int64     `json:"proposal_id"`
+		Depositer  string    `json:"depositer"`
+		Amount     sdk.Coins `json:"amount"`
+	}{
+		ProposalID: msg.ProposalID,
+		Depositer:  sdk.MustBech32ifyAcc(msg.Depositer),
+		Amount:     msg.Amount,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check
+func (msg MsgDeposit) ValidateBasic() sdk.Error {
+	if msg.ProposalID < 0 {
+		return ErrUnknownProposal(DefaultCodespace, msg.ProposalID)
+	}
+	if msg.Depositer == nil {
+		return ErrInvalidAddress(DefaultCodespace)
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return ErrInvalidDeposit(DefaultCodespace, msg.Amount)
+	}
+	return nil
+}
+
+//______________________________________________________________________
+
+// MsgVote - cast a vote on a proposal in its voting period
+type MsgVote struct {
+	ProposalID int64       `json:"proposal_id"` //  ID of the proposal
+	Voter      sdk.Address `json:"voter"`       //  Address of the voter
+	Option     VoteOption  `json:"option"`      //  Option chosen by the voter
+}
+
+func NewMsgVote(proposalID int64, voter sdk.Address, option VoteOption) MsgVote {
+	return MsgVote{
+		ProposalID: proposalID,
+		Voter:      voter,
+		Option:     option,
+	}
+}
+
+//nolint
+func (msg MsgVote) Type() string              { return MsgType }
+func (msg MsgVote) GetSigners() []sdk.Address { return []sdk.Address{msg.Voter} }
+
+// get the bytes for the message signer to sign on
+func (msg MsgVote) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		ProposalID int64      `json:"proposal_id"`
+		Voter      string     `json:"voter"`
+		Option     VoteOption `json:"option"`
+	}{
+		ProposalID: msg.ProposalID,
+		Voter:      sdk.MustBech32ifyAcc(msg.Voter),
+		Option:     msg.Option,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check
+func (msg MsgVote) ValidateBasic() sdk.Error {
+	if msg.ProposalID < 0 {
+		return ErrUnknownProposal(DefaultCodespace, msg.ProposalID)
+	}
+	if msg.Voter == nil {
+		return ErrInvalidAddress(DefaultCodespace)
+	}
+	if !validVoteOption(msg.Option) {
+		return ErrInvalidVote(DefaultCodespace, msg.Option)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	coinsPos         = sdk.Coins{{"steak", 1000}}
+	coinsZero        = sdk.Coins{{"steak", 0}}
+	coinsNeg         = sdk.Coins{{"steak", -10000}}
+	coinsPosNotAtoms = sdk.Coins{{"foo", 10000}}
+	coinsMulti       = sdk.Coins{{"foo", 10000}, {"steak", 1000}}
+)
+
+func TestMsgSubmitProposal(t *testing.T) {
+	tests := []struct {
+		title, description string
+		proposalType       ProposalKind
+		proposerAddr       sdk.Address
+		initialDeposit     sdk.Coins
+		expectPass         bool
+	}{
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos, true},
+		{"", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos, false},
+		{"Test Proposal", "", ProposalTypeText, addrs[0], coinsPos, false},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeSoftwareUpgrade, addrs[0], coinsPos, true},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeParameterChange, addrs[0], coinsPos, false},
+		{"Test Proposal", "the purpose of this proposal is to test", 0x05, addrs[0], coinsPos, false},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, nil, coinsPos, false},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsZero, false},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsNeg, false},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsMulti, true},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitProposal(tc.title, tc.description, tc.proposalType, tc.proposerAddr, tc.initialDeposit)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
+func TestMsgSubmitParamChangeProposal(t *testing.T) {
+	tests := []struct {
+		paramChanges []ParamChange
+		expectPass   bool
+	}{
+		{[]ParamChange{{"stake", "MaxValidators", "5"}}, true},
+		{[]ParamChange{{"stake", "MaxValidators", "5"}, {"gov", "VotingProcedure", `{"voting_period":"10"}`}}, true},
+		{nil, false},
+		{[]ParamChange{{"", "MaxValidators", "5"}}, false},
+		{[]ParamChange{{"stake", "", "5"}}, false},
+		{[]ParamChange{{"stake", "MaxValidators", ""}}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitParamChangeProposal("Test Proposal", "the purpose of this proposal is to test", addrs[0], coinsPos, tc.paramChanges)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+
+	// only parameter change proposals may change parameters
+	msg := NewMsgSubmitProposal("Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos)
+	msg.ParamChanges = []ParamChange{{"stake", "MaxValidators", "5"}}
+	require.NotNil(t, msg.ValidateBasic())
+}
+
+func TestMsgDeposit(t *testing.T) {
+	tests := []struct {
+		proposalID    int64
+		depositerAddr sdk.Address
+		depositAmount sdk.Coins
+		expectPass    bool
+	}{
+		{0, addrs[0], coinsPos, true},
+		{-1, addrs[0], coinsPos, false},
+		{1, nil, coinsPos, false},
+		{1, addrs[0], coinsZero, false},
+		{1, addrs[0], coinsNeg, false},
+		{1, addrs[0], coinsPosNotAtoms, true},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgDeposit(tc.proposalID, tc.depositerAddr, tc.depositAmount)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
+func TestMsgVote(t *testing.T) {
+	tests := []struct {
+		proposalID int64
+		voterAddr  sdk.Address
+		option     VoteOption
+		expectPass bool
+	}{
+		{0, addrs[0], OptionYes, true},
+		{-1, addrs[0], OptionYes, false},
+		{0, nil, OptionYes, false},
+		{0, addrs[0], OptionNo, true},
+		{0, addrs[0], OptionNoWithVeto, true},
+		{0, addrs[0], OptionAbstain, true},
+		{0, addrs[0], OptionEmpty, false},
+		{0, addrs[0], VoteOption(0x13), false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgVote(tc.proposalID, tc.voterAddr, tc.option)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
+func TestVoteOptionFromString(t *testing.T) {
+	for _, option := range []VoteOption{OptionYes, OptionAbstain, OptionNo, OptionNoWithVeto} {
+		got, err := VoteOptionFromString(option.String())
+		require.Nil(t, err)
+		require.Equal(t, option, got)
+	}
+	_, err := VoteOptionFromString("Maybe")
+	require.NotNil(t, err)
+}
@@ -0,0 +1,25 @@
+package gov
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// NewParamChangeProposalHandler creates the handler applying the parameter
+// changes of passed parameter change proposals to the params store, none of
+// the changes are kept if any of them fails
+func NewParamChangeProposalHandler(pk params.Keeper) ProposalHandler {
+	return func(ctx sdk.Context, proposal Proposal) sdk.Error {
+		for _, change := range proposal.ParamChanges {
+			space, ok := pk.GetSubspace(change.Subspace)
+			if !ok {
+				return ErrInvalidParamChange(DefaultCodespace, "unknown subspace "+change.Subspace)
+			}
+			err := space.Update(ctx, []byte(change.Key), []byte(change.Value))
+			if err != nil {
+				return ErrInvalidParamChange(DefaultCodespace, err.Error())
+			}
+		}
+		return nil
+	}
+}
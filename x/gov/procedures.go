@@ -0,0 +1,104 @@
+package gov
+
+import (
+	"errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// name of the governance subspace in the params store
+const DefaultParamspace = "gov"
+
+// keys of the governance procedures in the params store
+var (
+	KeyDepositProcedure  = []byte("DepositProcedure")
+	KeyVotingProcedure   = []byte("VotingProcedure")
+	KeyTallyingProcedure = []byte("TallyingProcedure")
+)
+
+// Procedure around deposits for governance
+type DepositProcedure struct {
+	MinDeposit       sdk.Coins `json:"min_deposit"`        //  Minimum deposit for a proposal to enter voting period
+	MaxDepositPeriod int64     `json:"max_deposit_period"` //  Maximum number of blocks for holders to deposit on a proposal
+}
+
+// Procedure around voting in governance
+type VotingProcedure struct {
+	VotingPeriod int64 `json:"voting_period"` //  Length of the voting period in blocks
+}
+
+// Procedure around tallying the votes in governance
+type TallyingProcedure struct {
+	Threshold sdk.Rat `json:"threshold"` //  Minimum proportion of Yes votes for a proposal to pass
+	Veto      sdk.Rat `json:"veto"`      //  Minimum proportion of NoWithVeto votes for a proposal to be vetoed
+}
+
+// default deposit procedure
+func DefaultDepositProcedure() DepositProcedure {
+	return DepositProcedure{
+		MinDeposit:       sdk.Coins{{"steak", 10}},
+		MaxDepositPeriod: 200,
+	}
+}
+
+// default voting procedure
+func DefaultVotingProcedure() VotingProcedure {
+	return VotingProcedure{
+		VotingPeriod: 200,
+	}
+}
+
+// default tallying procedure
+func DefaultTallyingProcedure() TallyingProcedure {
+	return TallyingProcedure{
+		Threshold: sdk.NewRat(1, 2),
+		Veto:      sdk.NewRat(1, 3),
+	}
+}
+
+//_______________________________________________________________________
+
+// all governance procedures, registers them in the governance subspace
+type procedures struct {
+	deposit  DepositProcedure
+	voting   VotingProcedure
+	tallying TallyingProcedure
+}
+
+// implements params.ParamSet
+func (p *procedures) ParamSetPairs() []params.ParamSetPair {
+	return []params.ParamSetPair{
+		params.NewParamSetPair(KeyDepositProcedure, &p.deposit, validateDepositProcedure),
+		params.NewParamSetPair(KeyVotingProcedure, &p.voting, validateVotingProcedure),
+		params.NewParamSetPair(KeyTallyingProcedure, &p.tallying, validateTallyingProcedure),
+	}
+}
+
+func validateDepositProcedure(value interface{}) error {
+	procedure := value.(DepositProcedure)
+	if !procedure.MinDeposit.IsValid() || !procedure.MinDeposit.IsPositive() {
+		return errors.New("minimum deposit must be positive")
+	}
+	if procedure.MaxDepositPeriod <= 0 {
+		return errors.New("maximum deposit period must be positive")
+	}
+	return nil
+}
+
+func validateVotingProcedure(value interface{}) error {
+	if value.(VotingProcedure).VotingPeriod <= 0 {
+		return errors.New("voting period must be positive")
+	}
+	return nil
+}
+
+func validateTallyingProcedure(value interface{}) error {
+	procedure := value.(TallyingProcedure)
+	for _, r := range []sdk.Rat{procedure.Threshold, procedure.Veto} {
+		if !r.GT(sdk.ZeroRat()) || r.GT(sdk.OneRat()) {
+			return errors.New("threshold and veto must be greater than 0 and at most 1")
+		}
+	}
+	return nil
+}
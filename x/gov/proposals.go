@@ -0,0 +1,234 @@
+package gov
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Type of a proposal, determines which proposal handler is run once the
+// proposal has passed
+type ProposalKind byte
+
+//nolint
+const (
+	ProposalTypeText            ProposalKind = 0x01
+	ProposalTypeSoftwareUpgrade ProposalKind = 0x02
+	ProposalTypeParameterChange ProposalKind = 0x03
+)
+
+// string to ProposalKind
+func ProposalKindFromString(str string) (ProposalKind, error) {
+	switch str {
+	case "Text":
+		return ProposalTypeText, nil
+	case "SoftwareUpgrade":
+		return ProposalTypeSoftwareUpgrade, nil
+	case "ParameterChange":
+		return ProposalTypeParameterChange, nil
+	default:
+		return ProposalKind(0xff), fmt.Errorf("'%s' is not a valid proposal type", str)
+	}
+}
+
+// is the proposal type one of the known types
+func validProposalType(pt ProposalKind) bool {
+	return pt == ProposalTypeText || pt == ProposalTypeSoftwareUpgrade ||
+		pt == ProposalTypeParameterChange
+}
+
+// human readable proposal type
+func (pt ProposalKind) String() string {
+	switch pt {
+	case ProposalTypeText:
+		return "Text"
+	case ProposalTypeSoftwareUpgrade:
+		return "SoftwareUpgrade"
+	case ProposalTypeParameterChange:
+		return "ParameterChange"
+	default:
+		return ""
+	}
+}
+
+//_______________________________________________________________________
+
+// Option of a vote
+type VoteOption byte
+
+//nolint
+const (
+	OptionEmpty      VoteOption = 0x00
+	OptionYes        VoteOption = 0x01
+	OptionAbstain    VoteOption = 0x02
+	OptionNo         VoteOption = 0x03
+	OptionNoWithVeto VoteOption = 0x04
+)
+
+// string to VoteOption
+func VoteOptionFromString(str string) (VoteOption, error) {
+	switch str {
+	case "Yes":
+		return OptionYes, nil
+	case "Abstain":
+		return OptionAbstain, nil
+	case "No":
+		return OptionNo, nil
+	case "NoWithVeto":
+		return OptionNoWithVeto, nil
+	default:
+		return VoteOption(0xff), fmt.Errorf("'%s' is not a valid vote option", str)
+	}
+}
+
+// is the vote option one of the options a voter can choose
+func validVoteOption(option VoteOption) bool {
+	return option == OptionYes || option == OptionAbstain ||
+		option == OptionNo || option == OptionNoWithVeto
+}
+
+// human readable vote option
+func (vo VoteOption) String() string {
+	switch vo {
+	case OptionYes:
+		return "Yes"
+	case OptionAbstain:
+		return "Abstain"
+	case OptionNo:
+		return "No"
+	case OptionNoWithVeto:
+		return "NoWithVeto"
+	default:
+		return ""
+	}
+}
+
+//_______________________________________________________________________
+
+// Status of a proposal
+type ProposalStatus byte
+
+//nolint
+const (
+	StatusDepositPeriod ProposalStatus = 0x01
+	StatusVotingPeriod  ProposalStatus = 0x02
+	StatusPassed        ProposalStatus = 0x03
+	StatusRejected      ProposalStatus = 0x04
+)
+
+// human readable proposal status
+func (status ProposalStatus) String() string {
+	switch status {
+	case StatusDepositPeriod:
+		return "DepositPeriod"
+	case StatusVotingPeriod:
+		return "VotingPeriod"
+	case StatusPassed:
+		return "Passed"
+	case StatusRejected:
+		return "Rejected"
+	default:
+		return ""
+	}
+}
+
+//_______________________________________________________________________
+
+// Proposal - an item to be voted on, it enters its voting period once its
+// deposit has reached the minimum deposit
+type Proposal struct {
+	ProposalID   int64         `json:"proposal_id"`   //  ID of the proposal
+	Title        string        `json:"title"`         //  Title of the proposal
+	Description  string        `json:"description"`   //  Description of the proposal
+	ProposalType ProposalKind  `json:"proposal_type"` //  Type of proposal
+	ParamChanges []ParamChange `json:"param_changes"` //  Parameters changed once passed, for parameter change proposals
+
+	Status      ProposalStatus `json:"proposal_status"` //  Status of the proposal
+	TallyResult TallyResult    `json:"tally_result"`    //  Result of the tally, set once the voting period has ended
+
+	SubmitBlock     int64     `json:"submit_block"`      //  Height of the block where the proposal was submitted
+	DepositEndBlock int64     `json:"deposit_end_block"` //  Height of the block at which the deposit period ends
+	TotalDeposit    sdk.Coins `json:"total_deposit"`     //  Current deposit on this proposal
+
+	VotingStartBlock int64 `json:"voting_start_block"` //  Height of the block where the minimum deposit was reached, -1 before
+	VotingEndBlock   int64 `json:"voting_end_block"`   //  Height of the block at which the voting period ends, -1 before
+}
+
+// human readable proposal, for use with the cli
+func (p Proposal) HumanReadableString() string {
+	resp := "Proposal \n"
+	resp += fmt.Sprintf("ID: %d\n", p.ProposalID)
+	resp += fmt.Sprintf("Title: %s\n", p.Title)
+	resp += fmt.Sprintf("Description: %s\n", p.Description)
+	resp += fmt.Sprintf("Type: %s\n", p.ProposalType)
+	for _, change := range p.ParamChanges {
+		resp += fmt.Sprintf("Parameter Change: %s\n", change)
+	}
+	resp += fmt.Sprintf("Status: %s\n", p.Status)
+	resp += fmt.Sprintf("Submit Block: %d\n", p.SubmitBlock)
+	resp += fmt.Sprintf("Total Deposit: %s\n", p.TotalDeposit)
+	resp += fmt.Sprintf("Deposit End Block: %d\n", p.DepositEndBlock)
+	resp += fmt.Sprintf("Voting Start Block: %d\n", p.VotingStartBlock)
+	resp += fmt.Sprintf("Voting End Block: %d\n", p.VotingEndBlock)
+	if p.Status == StatusPassed || p.Status == StatusRejected {
+		resp += fmt.Sprintf("Tally Result: %s\n", p.TallyResult)
+	}
+	return resp
+}
+
+//_______________________________________________________________________
+
+// ParamChange - new value of a parameter of the params store, the value is
+// JSON encoded
+type ParamChange struct {
+	Subspace string `json:"subspace"` //  Subspace of the parameter
+	Key      string `json:"key"`      //  Key of the parameter in its subspace
+	Value    string `json:"value"`    //  JSON encoded new value of the parameter
+}
+
+// nolint
+func (pc ParamChange) String() string {
+	return fmt.Sprintf("%s/%s=%s", pc.Subspace, pc.Key, pc.Value)
+}
+
+//_______________________________________________________________________
+
+// Tally results, the voting power behind each option
+type TallyResult struct {
+	Yes        sdk.Rat `json:"yes"`
+	Abstain    sdk.Rat `json:"abstain"`
+	No         sdk.Rat `json:"no"`
+	NoWithVeto sdk.Rat `json:"no_with_veto"`
+}
+
+// empty tally result
+func EmptyTallyResult() TallyResult {
+	return TallyResult{
+		Yes:        sdk.ZeroRat(),
+		Abstain:    sdk.ZeroRat(),
+		No:         sdk.ZeroRat(),
+		NoWithVeto: sdk.ZeroRat(),
+	}
+}
+
+// nolint
+func (tr TallyResult) String() string {
+	return fmt.Sprintf("Yes: %v, Abstain: %v, No: %v, NoWithVeto: %v",
+		tr.Yes, tr.Abstain, tr.No, tr.NoWithVeto)
+}
+
+//_______________________________________________________________________
+
+// Deposit of a depositer on a proposal
+type Deposit struct {
+	Depositer  sdk.Address `json:"depositer"`   //  Address of the depositer
+	ProposalID int64       `json:"proposal_id"` //  proposalID of the proposal
+	Amount     sdk.Coins   `json:"amount"`      //  Deposit amount
+}
+
+// Vote of a voter on a proposal
+type Vote struct {
+	Voter      sdk.Address `json:"voter"`       //  address of the voter
+	ProposalID int64       `json:"proposal_id"` //  proposalID of the proposal
+	Option     VoteOption  `json:"option"`      //  option from OptionSet chosen by the voter
+}
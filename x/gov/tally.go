@@ -0,0 +1,109 @@
+package gov
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// validator voting information used while tallying
+type validatorGovInfo struct {
+	Address         sdk.Address // address of the validator owner
+	Power           sdk.Rat     // voting power of the validator
+	DelegatorShares sdk.Rat     // total outstanding delegator shares
+	Minus           sdk.Rat     // delegator shares which voted themselves
+	Vote            VoteOption  // vote of the validator
+}
+
+// tally the votes on a proposal, delegators who did not vote inherit the vote
+// of their validator and only bonded validators and their delegators count
+func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, vetoed bool, tallyResult TallyResult) {
+	results := make(map[VoteOption]sdk.Rat)
+	results[OptionYes] = sdk.ZeroRat()
+	results[OptionAbstain] = sdk.ZeroRat()
+	results[OptionNo] = sdk.ZeroRat()
+	results[OptionNoWithVeto] = sdk.ZeroRat()
+
+	totalVotingPower := sdk.ZeroRat()
+	currValidators := make(map[string]validatorGovInfo)
+
+	keeper.validatorSet.IterateValidatorsBonded(ctx, func(index int64, validator sdk.Validator) (stop bool) {
+		currValidators[validator.GetOwner().String()] = validatorGovInfo{
+			Address:         validator.GetOwner(),
+			Power:           validator.GetPower(),
+			DelegatorShares: validator.GetDelegatorShares(),
+			Minus:           sdk.ZeroRat(),
+			Vote:            OptionEmpty,
+		}
+		return false
+	})
+
+	// iterate over all the votes
+	for _, vote := range keeper.GetVotes(ctx, proposal.ProposalID) {
+
+		// if the voter is a bonded validator record its vote, the voting power
+		// behind it is counted once all delegator votes are known
+		if val, ok := currValidators[vote.Voter.String()]; ok {
+			val.Vote = vote.Option
+			currValidators[vote.Voter.String()] = val
+		}
+
+		// the voter votes with its delegations to bonded validators, overriding
+		// the vote of those validators, validators included
+		keeper.delegatorSet.IterateDelegators(ctx, vote.Voter, func(index int64, delegation sdk.Delegation) (stop bool) {
+			val, ok := currValidators[delegation.GetValidator().String()]
+			if !ok || !val.DelegatorShares.GT(sdk.ZeroRat()) {
+				return false
+			}
+			val.Minus = val.Minus.Add(delegation.GetBondShares())
+			currValidators[delegation.GetValidator().String()] = val
+
+			votingPower := val.Power.Mul(delegation.GetBondShares()).Quo(val.DelegatorShares)
+			results[vote.Option] = results[vote.Option].Add(votingPower)
+			totalVotingPower = totalVotingPower.Add(votingPower)
+			return false
+		})
+	}
+
+	// count the votes of the validators on behalf of their remaining delegators
+	for _, val := range currValidators {
+		if val.Vote == OptionEmpty {
+			continue
+		}
+		votingPower := val.Power
+		if val.DelegatorShares.GT(sdk.ZeroRat()) {
+			sharesAfterMinus := val.DelegatorShares.Sub(val.Minus)
+			votingPower = val.Power.Mul(sharesAfterMinus).Quo(val.DelegatorShares)
+		}
+		results[val.Vote] = results[val.Vote].Add(votingPower)
+		totalVotingPower = totalVotingPower.Add(votingPower)
+	}
+
+	tallyResult = TallyResult{
+		Yes:        results[OptionYes],
+		Abstain:    results[OptionAbstain],
+		No:         results[OptionNo],
+		NoWithVeto: results[OptionNoWithVeto],
+	}
+
+	// nobody voted
+	if totalVotingPower.IsZero() {
+		return false, false, tallyResult
+	}
+
+	// more than the veto threshold of the votes are NoWithVeto
+	tallyingProcedure := keeper.GetTallyingProcedure(ctx)
+	if results[OptionNoWithVeto].Quo(totalVotingPower).GT(tallyingProcedure.Veto) {
+		return false, true, tallyResult
+	}
+
+	// everybody abstained
+	nonAbstaining := totalVotingPower.Sub(results[OptionAbstain])
+	if nonAbstaining.IsZero() {
+		return false, false, tallyResult
+	}
+
+	// more than the threshold of the non-abstaining votes are Yes
+	if results[OptionYes].Quo(nonAbstaining).GT(tallyingProcedure.Threshold) {
+		return true, false, tallyResult
+	}
+	return false, false, tallyResult
+}
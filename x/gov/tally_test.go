@@ -0,0 +1,139 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+// create the bonded validators and return a proposal in its voting period
+func setupTally(t *testing.T, ctx sdk.Context, sk stake.Keeper, keeper Keeper, amts []int64) Proposal {
+	stakeHandler := stake.NewHandler(sk)
+	for i, amt := range amts {
+		got := stakeHandler(ctx, newTestMsgCreateValidator(addrs[i], pks[i], amt))
+		require.True(t, got.IsOK(), "%v", got)
+	}
+
+	proposal := keeper.NewProposal(ctx, "Test", "description", ProposalTypeText)
+	votingStarted, err := keeper.AddDeposit(ctx, proposal.ProposalID, addrs[4], sdk.Coins{{"steak", 10}})
+	require.Nil(t, err)
+	require.True(t, votingStarted)
+	proposal, _ = keeper.GetProposal(ctx, proposal.ProposalID)
+	return proposal
+}
+
+func TestTallyNoOneVotes(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+	proposal := setupTally(t, ctx, sk, keeper, []int64{5, 5})
+
+	passes, vetoed, _ := tally(ctx, keeper, proposal)
+	require.False(t, passes)
+	require.False(t, vetoed)
+}
+
+func TestTallyOnlyValidators(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+	proposal := setupTally(t, ctx, sk, keeper, []int64{5, 6, 7})
+
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[0], OptionNo))
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[1], OptionYes))
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[2], OptionYes))
+
+	passes, vetoed, tallyResult := tally(ctx, keeper, proposal)
+	require.True(t, passes)
+	require.False(t, vetoed)
+	require.True(t, sdk.NewRat(13).Equal(tallyResult.Yes), "%v", tallyResult)
+	require.True(t, sdk.NewRat(5).Equal(tallyResult.No), "%v", tallyResult)
+
+	// abstaining votes do not count towards the threshold
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[1], OptionAbstain))
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[2], OptionAbstain))
+	passes, _, _ = tally(ctx, keeper, proposal)
+	require.False(t, passes)
+}
+
+func TestTallyVeto(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+	proposal := setupTally(t, ctx, sk, keeper, []int64{5, 6, 7})
+
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[0], OptionYes))
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[1], OptionYes))
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[2], OptionNoWithVeto))
+
+	passes, vetoed, _ := tally(ctx, keeper, proposal)
+	require.False(t, passes)
+	require.True(t, vetoed)
+}
+
+func TestTallyDelegatorInheritsValidatorVote(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+	proposal := setupTally(t, ctx, sk, keeper, []int64{10, 10})
+	stakeHandler := stake.NewHandler(sk)
+	got := stakeHandler(ctx, newTestMsgDelegate(addrs[3], addrs[0], 30))
+	require.True(t, got.IsOK(), "%v", got)
+
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[0], OptionYes))
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[1], OptionNo))
+
+	// the delegator did not vote and follows its validator
+	passes, _, tallyResult := tally(ctx, keeper, proposal)
+	require.True(t, passes)
+	require.True(t, sdk.NewRat(40).Equal(tallyResult.Yes), "%v", tallyResult)
+	require.True(t, sdk.NewRat(10).Equal(tallyResult.No), "%v", tallyResult)
+}
+
+func TestTallyDelegatorOverridesValidatorVote(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+	proposal := setupTally(t, ctx, sk, keeper, []int64{10, 10})
+	stakeHandler := stake.NewHandler(sk)
+	got := stakeHandler(ctx, newTestMsgDelegate(addrs[3], addrs[0], 30))
+	require.True(t, got.IsOK(), "%v", got)
+
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[0], OptionYes))
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[1], OptionNo))
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[3], OptionNo))
+
+	passes, _, tallyResult := tally(ctx, keeper, proposal)
+	require.False(t, passes)
+	require.True(t, sdk.NewRat(10).Equal(tallyResult.Yes), "%v", tallyResult)
+	require.True(t, sdk.NewRat(40).Equal(tallyResult.No), "%v", tallyResult)
+}
+
+func TestTallyDelegatorOfSilentValidator(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+	proposal := setupTally(t, ctx, sk, keeper, []int64{10, 10})
+	stakeHandler := stake.NewHandler(sk)
+	got := stakeHandler(ctx, newTestMsgDelegate(addrs[3], addrs[0], 30))
+	require.True(t, got.IsOK(), "%v", got)
+
+	// the validator does not vote, only the delegated stake counts
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[1], OptionNo))
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[3], OptionYes))
+
+	passes, _, tallyResult := tally(ctx, keeper, proposal)
+	require.True(t, passes)
+	require.True(t, sdk.NewRat(30).Equal(tallyResult.Yes), "%v", tallyResult)
+	require.True(t, sdk.NewRat(10).Equal(tallyResult.No), "%v", tallyResult)
+}
+
+func TestTallyValidatorsDelegatingToEachOther(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+	proposal := setupTally(t, ctx, sk, keeper, []int64{10, 10})
+	stakeHandler := stake.NewHandler(sk)
+	got := stakeHandler(ctx, newTestMsgDelegate(addrs[0], addrs[1], 20))
+	require.True(t, got.IsOK(), "%v", got)
+	got = stakeHandler(ctx, newTestMsgDelegate(addrs[1], addrs[0], 30))
+	require.True(t, got.IsOK(), "%v", got)
+
+	// each validator overrides the vote of the other one with its delegation
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[0], OptionYes))
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[1], OptionNo))
+
+	passes, _, tallyResult := tally(ctx, keeper, proposal)
+	require.False(t, passes)
+	require.True(t, sdk.NewRat(30).Equal(tallyResult.Yes), "%v", tallyResult)
+	require.True(t, sdk.NewRat(40).Equal(tallyResult.No), "%v", tallyResult)
+}
@@ -0,0 +1,17 @@
+package gov
+
+import (
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// Register concrete types on wire codec
+func RegisterWire(cdc *wire.Codec) {
+	cdc.RegisterConcrete(MsgSubmitProposal{}, "cosmos-sdk/MsgSubmitProposal", nil)
+	cdc.RegisterConcrete(MsgDeposit{}, "cosmos-sdk/MsgDeposit", nil)
+	cdc.RegisterConcrete(MsgVote{}, "cosmos-sdk/MsgVote", nil)
+}
+
+func init() {
+	RegisterWire(msgCdc)
+	wire.RegisterCrypto(msgCdc)
+}
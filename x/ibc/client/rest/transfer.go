@@ -28,6 +28,8 @@ type transferBody struct {
 	AccountNumber    int64     `json:"account_number"`
 	Sequence         int64     `json:"sequence"`
 	Gas              int64     `json:"gas"`
+	Memo             string    `json:"memo"`
+	TimeoutHeight    int64     `json:"timeout_height"`
 }
 
 // TransferRequestHandler - http request handler to transfer coins to a address
@@ -79,13 +81,15 @@ func TransferRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx context.Core
 		packet := ibc.NewIBCPacket(info.PubKey.Address(), to, m.Amount, m.SrcChainID, destChainID)
 		msg := ibc.IBCTransferMsg{packet}
 
-		// add gas to context
+		// add gas, memo and timeout height to context
 		ctx = ctx.WithGas(m.Gas)
+		ctx = ctx.WithMemo(m.Memo)
+		ctx = ctx.WithTimeoutHeight(m.TimeoutHeight)
 
 		// sign
 		ctx = ctx.WithAccountNumber(m.AccountNumber)
 		ctx = ctx.WithSequence(m.Sequence)
-		txBytes, err := ctx.SignAndBuild(m.LocalAccountName, m.Password, msg, cdc)
+		txBytes, err := ctx.SignAndBuild(m.LocalAccountName, m.Password, []sdk.Msg{msg}, cdc)
 		if err != nil {
 			w.WriteHeader(http.StatusUnauthorized)
 			w.Write([]byte(err.Error()))
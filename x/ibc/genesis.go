@@ -0,0 +1,85 @@
+package ibc
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - the IBC state kept for every chain
+type GenesisState struct {
+	Chains []ChainState `json:"chains"`
+}
+
+// ChainState - the packets exchanged with a chain and the coins sent to it
+type ChainState struct {
+	ChainID         string      `json:"chain_id"`
+	IngressSequence int64       `json:"ingress_sequence"` // sequence of the next packet received from the chain
+	EgressPackets   []IBCPacket `json:"egress_packets"`   // packets sent to the chain
+	EscrowedCoins   sdk.Coins   `json:"escrowed_coins"`   // coins held in escrow until sent back
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// InitGenesis - store the IBC state of every chain
+func InitGenesis(ctx sdk.Context, ibcm Mapper, data GenesisState) {
+	store := ctx.KVStore(ibcm.key)
+	for _, chain := range data.Chains {
+		ibcm.SetIngressSequence(ctx, chain.ChainID, chain.IngressSequence)
+		for i, packet := range chain.EgressPackets {
+			store.Set(EgressKey(chain.ChainID, int64(i)), marshalBinaryPanic(ibcm.cdc, packet))
+		}
+		if len(chain.EgressPackets) > 0 {
+			store.Set(EgressLengthKey(chain.ChainID), marshalBinaryPanic(ibcm.cdc, int64(len(chain.EgressPackets))))
+		}
+		if !chain.EscrowedCoins.IsZero() {
+			ibcm.setEscrowedCoins(ctx, chain.ChainID, chain.EscrowedCoins)
+		}
+	}
+}
+
+// WriteGenesis - output the IBC state of every chain, sorted by chain ID
+func WriteGenesis(ctx sdk.Context, ibcm Mapper) GenesisState {
+	store := ctx.KVStore(ibcm.key)
+	chainIDs := make(map[string]bool)
+	for _, prefix := range []string{"ingress/", "escrow/", "egress/"} {
+		iter := sdk.KVStorePrefixIterator(store, []byte(prefix))
+		for ; iter.Valid(); iter.Next() {
+			chainID := string(iter.Key()[len(prefix):])
+			// skip the packets stored under "egress/chain_id/index"
+			if i := strings.LastIndex(chainID, "/"); prefix == "egress/" && i >= 0 {
+				if _, err := strconv.ParseInt(chainID[i+1:], 10, 64); err == nil {
+					continue
+				}
+			}
+			chainIDs[chainID] = true
+		}
+		iter.Close()
+	}
+
+	var chains []ChainState
+	for chainID := range chainIDs {
+		var packets []IBCPacket
+		length := ibcm.getEgressLength(store, chainID)
+		for i := int64(0); i < length; i++ {
+			var packet IBCPacket
+			unmarshalBinaryPanic(ibcm.cdc, store.Get(EgressKey(chainID, i)), &packet)
+			packets = append(packets, packet)
+		}
+		chains = append(chains, ChainState{
+			ChainID:         chainID,
+			IngressSequence: ibcm.GetIngressSequence(ctx, chainID),
+			EgressPackets:   packets,
+			EscrowedCoins:   ibcm.GetEscrowedCoins(ctx, chainID),
+		})
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i].ChainID < chains[j].ChainID })
+	return GenesisState{
+		Chains: chains,
+	}
+}
@@ -21,7 +21,8 @@ func NewHandler(ibcm Mapper, ck bank.Keeper) sdk.Handler {
 	}
 }
 
-// IBCTransferMsg deducts coins from the account and creates an egress IBC packet.
+// IBCTransferMsg deducts coins from the account, escrows them until they are
+// sent back and creates an egress IBC packet.
 func handleIBCTransferMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCTransferMsg) sdk.Result {
 	packet := msg.IBCPacket
 
@@ -29,6 +30,8 @@ func handleIBCTransferMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCT
 	if err != nil {
 		return err.Result()
 	}
+	escrowed := ibcm.GetEscrowedCoins(ctx, packet.DestChain)
+	ibcm.setEscrowedCoins(ctx, packet.DestChain, escrowed.Plus(packet.Coins))
 
 	err = ibcm.PostIBCPacket(ctx, packet)
 	if err != nil {
@@ -39,6 +42,7 @@ func handleIBCTransferMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCT
 }
 
 // IBCReceiveMsg adds coins to the destination address and creates an ingress IBC packet.
+// Coins returning from the source chain are released from escrow, any others are minted.
 func handleIBCReceiveMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCReceiveMsg) sdk.Result {
 	packet := msg.IBCPacket
 
@@ -47,7 +51,27 @@ func handleIBCReceiveMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCRe
 		return ErrInvalidSequence(ibcm.codespace).Result()
 	}
 
-	_, _, err := ck.AddCoins(ctx, packet.DestAddr, packet.Coins)
+	escrowed := ibcm.GetEscrowedCoins(ctx, packet.SrcChain)
+	var released, minted sdk.Coins
+	for _, coin := range packet.Coins {
+		amount := escrowed.AmountOf(coin.Denom)
+		if amount > coin.Amount {
+			amount = coin.Amount
+		}
+		if amount > 0 {
+			released = append(released, sdk.Coin{coin.Denom, amount})
+		}
+		if coin.Amount > amount {
+			minted = append(minted, sdk.Coin{coin.Denom, coin.Amount - amount})
+		}
+	}
+	ibcm.setEscrowedCoins(ctx, packet.SrcChain, escrowed.Minus(released))
+
+	_, _, err := ck.AddCoins(ctx, packet.DestAddr, released)
+	if err != nil {
+		return err.Result()
+	}
+	_, _, err = ck.MintCoins(ctx, packet.DestAddr, minted)
 	if err != nil {
 		return err.Result()
 	}
@@ -63,7 +63,7 @@ func TestIBC(t *testing.T) {
 	ctx := defaultContext(key)
 
 	am := auth.NewAccountMapper(cdc, key, &auth.BaseAccount{})
-	ck := bank.NewKeeper(am)
+	ck := bank.NewKeeper(cdc, key, am, bank.DefaultCodespace)
 
 	src := newAddress()
 	dest := newAddress()
@@ -71,12 +71,13 @@ func TestIBC(t *testing.T) {
 	zero := sdk.Coins(nil)
 	mycoins := sdk.Coins{sdk.Coin{"mycoin", 10}}
 
-	coins, _, err := ck.AddCoins(ctx, src, mycoins)
+	coins, _, err := ck.MintCoins(ctx, src, mycoins)
 	assert.Nil(t, err)
 	assert.Equal(t, mycoins, coins)
 
 	ibcm := NewMapper(cdc, key, DefaultCodespace)
 	h := NewHandler(ibcm, ck)
+	invariant := bank.SupplyInvariant(ck, ibcm.HeldCoins)
 	packet := IBCPacket{
 		SrcAddr:   src,
 		DestAddr:  dest,
@@ -108,6 +109,10 @@ func TestIBC(t *testing.T) {
 	egl = ibcm.getEgressLength(store, chainid)
 	assert.Equal(t, egl, int64(1))
 
+	// the sent coins are escrowed until they are sent back
+	assert.Equal(t, mycoins, ibcm.GetEscrowedCoins(ctx, chainid))
+	assert.Nil(t, invariant(ctx))
+
 	igs = ibcm.GetIngressSequence(ctx, chainid)
 	assert.Equal(t, igs, int64(0))
 
@@ -123,6 +128,11 @@ func TestIBC(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, mycoins, coins)
 
+	// the returning coins are released from escrow
+	assert.True(t, ibcm.GetEscrowedCoins(ctx, chainid).IsZero())
+	assert.Equal(t, mycoins, ck.GetSupply(ctx))
+	assert.Nil(t, invariant(ctx))
+
 	igs = ibcm.GetIngressSequence(ctx, chainid)
 	assert.Equal(t, igs, int64(1))
 
@@ -131,4 +141,19 @@ func TestIBC(t *testing.T) {
 
 	igs = ibcm.GetIngressSequence(ctx, chainid)
 	assert.Equal(t, igs, int64(1))
+
+	// coins which were never escrowed are minted
+	msg = IBCReceiveMsg{
+		IBCPacket: packet,
+		Relayer:   src,
+		Sequence:  1,
+	}
+	res = h(ctx, msg)
+	assert.True(t, res.IsOK())
+
+	coins, err = getCoins(ck, ctx, dest)
+	assert.Nil(t, err)
+	assert.Equal(t, mycoins.Plus(mycoins), coins)
+	assert.Equal(t, mycoins.Plus(mycoins), ck.GetSupply(ctx))
+	assert.Nil(t, invariant(ctx))
 }
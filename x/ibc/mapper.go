@@ -101,6 +101,37 @@ func (ibcm Mapper) SetIngressSequence(ctx sdk.Context, srcChain string, sequence
 	store.Set(key, bz)
 }
 
+// GetEscrowedCoins returns the coins sent to a chain which are held in escrow
+// until they are sent back
+func (ibcm Mapper) GetEscrowedCoins(ctx sdk.Context, chain string) sdk.Coins {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(EscrowKey(chain))
+	if bz == nil {
+		return sdk.Coins{}
+	}
+	var res sdk.Coins
+	unmarshalBinaryPanic(ibcm.cdc, bz, &res)
+	return res
+}
+
+func (ibcm Mapper) setEscrowedCoins(ctx sdk.Context, chain string, coins sdk.Coins) {
+	store := ctx.KVStore(ibcm.key)
+	store.Set(EscrowKey(chain), marshalBinaryPanic(ibcm.cdc, coins))
+}
+
+// HeldCoins returns the coins held in escrow for all chains
+func (ibcm Mapper) HeldCoins(ctx sdk.Context) (held sdk.Coins) {
+	store := ctx.KVStore(ibcm.key)
+	iter := sdk.KVStorePrefixIterator(store, []byte("escrow/"))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var coins sdk.Coins
+		unmarshalBinaryPanic(ibcm.cdc, iter.Value(), &coins)
+		held = held.Plus(coins)
+	}
+	return held
+}
+
 // Retrieves the index of the currently stored outgoing IBC packets.
 func (ibcm Mapper) getEgressLength(store sdk.KVStore, destChain string) int64 {
 	bz := store.Get(EgressLengthKey(destChain))
@@ -124,6 +155,11 @@ func EgressLengthKey(destChain string) []byte {
 	return []byte(fmt.Sprintf("egress/%s", destChain))
 }
 
+// Stores the coins escrowed for a chain under "escrow/chain_id".
+func EscrowKey(chain string) []byte {
+	return []byte(fmt.Sprintf("escrow/%s", chain))
+}
+
 // Stores the sequence number of incoming IBC packet under "ingress/index".
 func IngressSequenceKey(srcChain string) []byte {
 	return []byte(fmt.Sprintf("ingress/%s", srcChain))
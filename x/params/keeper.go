@@ -0,0 +1,57 @@
+package params
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// keeper of the params store, hands out one subspace per module
+type Keeper struct {
+	cdc    *wire.Codec
+	key    sdk.StoreKey
+	spaces map[string]Subspace
+}
+
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey) Keeper {
+	keeper := Keeper{
+		cdc:    cdc,
+		key:    key,
+		spaces: make(map[string]Subspace),
+	}
+	return keeper
+}
+
+// allocate a new subspace, panics if the name is invalid or already taken
+func (k Keeper) Subspace(name string) Subspace {
+	if !validSubspaceName(name) {
+		panic(fmt.Sprintf("invalid subspace name %q", name))
+	}
+	if _, ok := k.spaces[name]; ok {
+		panic(fmt.Sprintf("subspace %s already allocated", name))
+	}
+	space := newSubspace(k.cdc, k.key, name)
+	k.spaces[name] = space
+	return space
+}
+
+// get an allocated subspace by name
+func (k Keeper) GetSubspace(name string) (Subspace, bool) {
+	space, ok := k.spaces[name]
+	return space, ok
+}
+
+// subspace names are the prefix of all their keys, as such they may not
+// contain the separator
+func validSubspaceName(name string) bool {
+	if len(name) == 0 {
+		return false
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
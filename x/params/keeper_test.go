@@ -0,0 +1,124 @@
+package params
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/abci/types"
+	dbm "github.com/tendermint/tmlibs/db"
+	"github.com/tendermint/tmlibs/log"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+var (
+	keyCount = []byte("Count")
+	keyRatio = []byte("Ratio")
+)
+
+type testParams struct {
+	Count int64
+	Ratio sdk.Rat
+}
+
+func (p *testParams) ParamSetPairs() []ParamSetPair {
+	return []ParamSetPair{
+		{keyCount, &p.Count, func(value interface{}) error {
+			if value.(int64) < 0 {
+				return errors.New("must not be negative")
+			}
+			return nil
+		}},
+		{keyRatio, &p.Ratio, nil},
+	}
+}
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper) {
+	keyParams := sdk.NewKVStoreKey("params")
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+	ctx := sdk.NewContext(ms, abci.Header{}, false, nil, log.NewNopLogger())
+	return ctx, NewKeeper(wire.NewCodec(), keyParams)
+}
+
+func TestSubspaceAllocation(t *testing.T) {
+	_, keeper := createTestInput(t)
+
+	space := keeper.Subspace("test")
+	require.Equal(t, "test", space.Name())
+	got, found := keeper.GetSubspace("test")
+	require.True(t, found)
+	require.Equal(t, "test", got.Name())
+	_, found = keeper.GetSubspace("other")
+	require.False(t, found)
+
+	// names are unique and may not contain the key separator
+	require.Panics(t, func() { keeper.Subspace("test") })
+	require.Panics(t, func() { keeper.Subspace("") })
+	require.Panics(t, func() { keeper.Subspace("te/st") })
+}
+
+func TestSubspaceGetSet(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	space := keeper.Subspace("test").WithParamSet(&testParams{})
+	other := keeper.Subspace("other").WithParamSet(&testParams{})
+
+	var count int64
+	require.False(t, space.Has(ctx, keyCount))
+	require.False(t, space.GetIfExists(ctx, keyCount, &count))
+	require.Panics(t, func() { space.Get(ctx, keyCount, &count) })
+
+	space.Set(ctx, keyCount, int64(5))
+	require.True(t, space.Has(ctx, keyCount))
+	space.Get(ctx, keyCount, &count)
+	require.Equal(t, int64(5), count)
+
+	// subspaces do not share keys
+	require.False(t, other.Has(ctx, keyCount))
+
+	// unregistered keys, wrong types and invalid values are rejected
+	require.Panics(t, func() { space.Set(ctx, []byte("Unknown"), int64(1)) })
+	require.Panics(t, func() { space.Set(ctx, keyCount, "5") })
+	require.Panics(t, func() { space.Set(ctx, keyCount, int64(-1)) })
+
+	// parameter sets are loaded and stored as a whole
+	params := testParams{7, sdk.NewRat(1, 3)}
+	space.SetParamSet(ctx, &params)
+	var loaded testParams
+	space.GetParamSet(ctx, &loaded)
+	require.Equal(t, int64(7), loaded.Count)
+	require.True(t, sdk.NewRat(1, 3).Equal(loaded.Ratio))
+}
+
+func TestSubspaceUpdate(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	keeper.Subspace("test").WithParamSet(&testParams{})
+
+	// updates go through the subspace handed out by the keeper
+	space, _ := keeper.GetSubspace("test")
+	err := space.Update(ctx, keyCount, []byte(`"9"`))
+	require.Nil(t, err)
+	var count int64
+	space.Get(ctx, keyCount, &count)
+	require.Equal(t, int64(9), count)
+
+	err = space.Update(ctx, keyRatio, []byte(`"1/4"`))
+	require.Nil(t, err)
+	var ratio sdk.Rat
+	space.Get(ctx, keyRatio, &ratio)
+	require.True(t, sdk.NewRat(1, 4).Equal(ratio))
+
+	// failed updates leave the parameter unchanged
+	require.NotNil(t, space.Update(ctx, []byte("Unknown"), []byte(`"1"`)))
+	require.NotNil(t, space.Update(ctx, keyCount, []byte(`"-1"`)))
+	require.NotNil(t, space.Update(ctx, keyCount, []byte(`not json`)))
+	space.Get(ctx, keyCount, &count)
+	require.Equal(t, int64(9), count)
+}
@@ -0,0 +1,20 @@
+package params
+
+// ParamSetPair binds a parameter key to a pointer to the field holding its
+// value, along with an optional function validating new values of it
+type ParamSetPair struct {
+	Key         []byte
+	Value       interface{}
+	ValidatorFn func(value interface{}) error
+}
+
+// NewParamSetPair creates a ParamSetPair
+func NewParamSetPair(key []byte, value interface{}, validatorFn func(value interface{}) error) ParamSetPair {
+	return ParamSetPair{key, value, validatorFn}
+}
+
+// ParamSet is implemented by the (pointer to the) parameter struct of a
+// module, it lists the parameters stored in the module's subspace
+type ParamSet interface {
+	ParamSetPairs() []ParamSetPair
+}
@@ -0,0 +1,149 @@
+package params
+
+import (
+	"fmt"
+	"reflect"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// type and validation function of a registered parameter
+type attribute struct {
+	ty          reflect.Type
+	validatorFn func(value interface{}) error
+}
+
+// Subspace is the section of the params store owned by a single module, only
+// parameters registered through WithParamSet may be stored in it
+type Subspace struct {
+	cdc    *wire.Codec
+	key    sdk.StoreKey
+	name   []byte
+	prefix []byte
+
+	// registered parameters by key, shared by all copies of the subspace
+	table map[string]attribute
+}
+
+func newSubspace(cdc *wire.Codec, key sdk.StoreKey, name string) Subspace {
+	return Subspace{
+		cdc:    cdc,
+		key:    key,
+		name:   []byte(name),
+		prefix: append([]byte(name), '/'),
+		table:  make(map[string]attribute),
+	}
+}
+
+// name of the subspace
+func (s Subspace) Name() string {
+	return string(s.name)
+}
+
+// register the parameters of a parameter set, registering a key again with a
+// different type panics
+func (s Subspace) WithParamSet(ps ParamSet) Subspace {
+	for _, pair := range ps.ParamSetPairs() {
+		ty := reflect.TypeOf(pair.Value)
+		if ty.Kind() != reflect.Ptr {
+			panic(fmt.Sprintf("value of parameter %s must be a pointer", pair.Key))
+		}
+		if attr, ok := s.table[string(pair.Key)]; ok && attr.ty != ty.Elem() {
+			panic(fmt.Sprintf("parameter %s already registered with type %v", pair.Key, attr.ty))
+		}
+		s.table[string(pair.Key)] = attribute{ty.Elem(), pair.ValidatorFn}
+	}
+	return s
+}
+
+// full store key of a parameter
+func (s Subspace) storeKey(key []byte) []byte {
+	return append(append([]byte{}, s.prefix...), key...)
+}
+
+// load a parameter into ptr, panics if it has not been set
+func (s Subspace) Get(ctx sdk.Context, key []byte, ptr interface{}) {
+	if !s.GetIfExists(ctx, key, ptr) {
+		panic(fmt.Sprintf("parameter %s/%s should not have been nil", s.name, key))
+	}
+}
+
+// load a parameter into ptr if it has been set, returns whether it was
+func (s Subspace) GetIfExists(ctx sdk.Context, key []byte, ptr interface{}) bool {
+	store := ctx.KVStore(s.key)
+	bz := store.Get(s.storeKey(key))
+	if bz == nil {
+		return false
+	}
+	s.cdc.MustUnmarshalBinary(bz, ptr)
+	return true
+}
+
+// whether a parameter has been set
+func (s Subspace) Has(ctx sdk.Context, key []byte) bool {
+	store := ctx.KVStore(s.key)
+	return store.Has(s.storeKey(key))
+}
+
+// store a parameter, panics if the parameter is unregistered or invalid as
+// module code is expected to only ever store valid parameters
+func (s Subspace) Set(ctx sdk.Context, key []byte, value interface{}) {
+	if err := s.checkValue(key, value); err != nil {
+		panic(err)
+	}
+	store := ctx.KVStore(s.key)
+	store.Set(s.storeKey(key), s.cdc.MustMarshalBinary(value))
+}
+
+// store a parameter from its JSON encoding, this is the entry point for
+// changes submitted from outside the module such as governance proposals
+func (s Subspace) Update(ctx sdk.Context, key []byte, valueJSON []byte) error {
+	attr, ok := s.table[string(key)]
+	if !ok {
+		return fmt.Errorf("parameter %s/%s is not registered", s.name, key)
+	}
+	ptr := reflect.New(attr.ty)
+	if err := s.cdc.UnmarshalJSON(valueJSON, ptr.Interface()); err != nil {
+		return fmt.Errorf("invalid value for parameter %s/%s: %v", s.name, key, err)
+	}
+	value := ptr.Elem().Interface()
+	if err := s.checkValue(key, value); err != nil {
+		return err
+	}
+	store := ctx.KVStore(s.key)
+	store.Set(s.storeKey(key), s.cdc.MustMarshalBinary(value))
+	return nil
+}
+
+// check a value is of the registered type of a parameter and passes its
+// validation function
+func (s Subspace) checkValue(key []byte, value interface{}) error {
+	attr, ok := s.table[string(key)]
+	if !ok {
+		return fmt.Errorf("parameter %s/%s is not registered", s.name, key)
+	}
+	if ty := reflect.TypeOf(value); ty != attr.ty {
+		return fmt.Errorf("parameter %s/%s expects type %v, got %v", s.name, key, attr.ty, ty)
+	}
+	if attr.validatorFn != nil {
+		if err := attr.validatorFn(value); err != nil {
+			return fmt.Errorf("invalid value for parameter %s/%s: %v", s.name, key, err)
+		}
+	}
+	return nil
+}
+
+// load all parameters of a parameter set
+func (s Subspace) GetParamSet(ctx sdk.Context, ps ParamSet) {
+	for _, pair := range ps.ParamSetPairs() {
+		s.Get(ctx, pair.Key, pair.Value)
+	}
+}
+
+// store all parameters of a parameter set
+func (s Subspace) SetParamSet(ctx sdk.Context, ps ParamSet) {
+	for _, pair := range ps.ParamSetPairs() {
+		s.Set(ctx, pair.Key, reflect.ValueOf(pair.Value).Elem().Interface())
+	}
+}
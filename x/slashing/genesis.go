@@ -0,0 +1,104 @@
+package slashing
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all slashing state that must be provided at genesis
+type GenesisState struct {
+	Params       Params               `json:"params"`
+	SigningInfos []GenesisSigningInfo `json:"signing_infos"`
+}
+
+// GenesisSigningInfo - the signing info of a validator and the blocks it
+// signed within its signed blocks window
+type GenesisSigningInfo struct {
+	Address      sdk.Address          `json:"address"` // validator address (not owner address)
+	SigningInfo  ValidatorSigningInfo `json:"signing_info"`
+	SignedBlocks []int64              `json:"signed_blocks"` // indexes of the signed blocks in the signed blocks window
+}
+
+func NewGenesisState(params Params) GenesisState {
+	return GenesisState{
+		Params: params,
+	}
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Params: DefaultParams(),
+	}
+}
+
+// InitGenesis - store genesis parameters
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	k.setParams(ctx, data.Params)
+	k.setSigningWindow(ctx, data.Params.SignedBlocksWindow)
+	for _, info := range data.SigningInfos {
+		k.setValidatorSigningInfo(ctx, info.Address, info.SigningInfo)
+		for _, index := range info.SignedBlocks {
+			k.setValidatorSigningBitArray(ctx, info.Address, index, true)
+		}
+	}
+}
+
+// WriteGenesis - output genesis parameters
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	return GenesisState{
+		Params:       k.GetParams(ctx),
+		SigningInfos: k.getAllSigningInfos(ctx),
+	}
+}
+
+// ForZeroHeight - rebase the state exported at a height for a chain
+// restarting at height zero
+func (data GenesisState) ForZeroHeight(height int64) GenesisState {
+	infos := make([]GenesisSigningInfo, len(data.SigningInfos))
+	for i, info := range data.SigningInfos {
+		info.SigningInfo.StartHeight -= height
+		if info.SigningInfo.StartHeight < 0 {
+			info.SigningInfo.StartHeight = 0
+		}
+		infos[i] = info
+	}
+	data.SigningInfos = infos
+	return data
+}
+
+// load the signing infos of all validators and their signed blocks, used
+// during genesis dump
+func (k Keeper) getAllSigningInfos(ctx sdk.Context) (infos []GenesisSigningInfo) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, []byte{0x01})
+	for ; iterator.Valid(); iterator.Next() {
+		address := sdk.Address(iterator.Key()[1:])
+		var info ValidatorSigningInfo
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &info)
+		infos = append(infos, GenesisSigningInfo{
+			Address:      address,
+			SigningInfo:  info,
+			SignedBlocks: k.getSignedBlocks(ctx, address),
+		})
+	}
+	iterator.Close()
+	return infos
+}
+
+// indexes of the blocks signed by a validator within its signed blocks window
+func (k Keeper) getSignedBlocks(ctx sdk.Context, address sdk.Address) (indexes []int64) {
+	store := ctx.KVStore(k.storeKey)
+	prefix := append([]byte{0x02}, address.Bytes()...)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	for ; iterator.Valid(); iterator.Next() {
+		var signed bool
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &signed)
+		if signed {
+			indexes = append(indexes, int64(binary.LittleEndian.Uint64(iterator.Key()[len(prefix):])))
+		}
+	}
+	iterator.Close()
+	return indexes
+}
@@ -5,6 +5,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	crypto "github.com/tendermint/go-crypto"
 )
 
@@ -13,40 +14,51 @@ type Keeper struct {
 	storeKey     sdk.StoreKey
 	cdc          *wire.Codec
 	validatorSet sdk.ValidatorSet
+	paramSpace   params.Subspace
 
 	// codespace
 	codespace sdk.CodespaceType
 }
 
 // NewKeeper creates a slashing keeper
-func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, vs sdk.ValidatorSet, codespace sdk.CodespaceType) Keeper {
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, vs sdk.ValidatorSet, paramSpace params.Subspace,
+	codespace sdk.CodespaceType) Keeper {
+
 	keeper := Keeper{
 		storeKey:     key,
 		cdc:          cdc,
 		validatorSet: vs,
+		paramSpace:   paramSpace.WithParamSet(&Params{}),
 		codespace:    codespace,
 	}
 	return keeper
 }
 
 // handle a validator signing two blocks at the same height
-func (k Keeper) handleDoubleSign(ctx sdk.Context, height int64, timestamp int64, pubkey crypto.PubKey) {
+// power: power of the double-signing validator at the height of infraction
+func (k Keeper) handleDoubleSign(ctx sdk.Context, height int64, timestamp int64,
+	pubkey crypto.PubKey, power int64) (tags sdk.Tags) {
+
 	logger := ctx.Logger().With("module", "x/slashing")
 	age := ctx.BlockHeader().Time - timestamp
+	maxEvidenceAge := k.MaxEvidenceAge(ctx)
 
 	// Double sign too old
-	if age > MaxEvidenceAge {
-		logger.Info(fmt.Sprintf("Ignored double sign from %s at height %d, age of %d past max age of %d", pubkey.Address(), height, age, MaxEvidenceAge))
-		return
+	if age > maxEvidenceAge {
+		logger.Info(fmt.Sprintf("Ignored double sign from %s at height %d, age of %d past max age of %d", pubkey.Address(), height, age, maxEvidenceAge))
+		return tags
 	}
 
 	// Double sign confirmed
-	logger.Info(fmt.Sprintf("Confirmed double sign from %s at height %d, age of %d less than max age of %d", pubkey.Address(), height, age, MaxEvidenceAge))
-	k.validatorSet.Slash(ctx, pubkey, height, SlashFractionDoubleSign)
+	logger.Info(fmt.Sprintf("Confirmed double sign from %s at height %d, age of %d less than max age of %d", pubkey.Address(), height, age, maxEvidenceAge))
+	return k.validatorSet.Slash(ctx, pubkey, height, power, k.SlashFractionDoubleSign(ctx))
 }
 
 // handle a validator signature, must be called once per validator per block
-func (k Keeper) handleValidatorSignature(ctx sdk.Context, pubkey crypto.PubKey, signed bool) {
+// power: current power of the validator
+func (k Keeper) handleValidatorSignature(ctx sdk.Context, pubkey crypto.PubKey,
+	power int64, signed bool) (tags sdk.Tags) {
+
 	logger := ctx.Logger().With("module", "x/slashing")
 	height := ctx.BlockHeight()
 	if !signed {
@@ -61,7 +73,8 @@ func (k Keeper) handleValidatorSignature(ctx sdk.Context, pubkey crypto.PubKey,
 		// If this validator has never been seen before, construct a new SigningInfo with the correct start height
 		signInfo = NewValidatorSigningInfo(height, 0, 0, 0)
 	}
-	index := signInfo.IndexOffset % SignedBlocksWindow
+	signedBlocksWindow := k.SignedBlocksWindow(ctx)
+	index := signInfo.IndexOffset % signedBlocksWindow
 	signInfo.IndexOffset++
 
 	// Update signed block bit array & counter
@@ -80,15 +93,17 @@ func (k Keeper) handleValidatorSignature(ctx sdk.Context, pubkey crypto.PubKey,
 		signInfo.SignedBlocksCounter++
 	}
 
-	minHeight := signInfo.StartHeight + SignedBlocksWindow
-	if height > minHeight && signInfo.SignedBlocksCounter < MinSignedPerWindow {
+	minHeight := signInfo.StartHeight + signedBlocksWindow
+	minSignedPerWindow := k.MinSignedPerWindow(ctx)
+	if height > minHeight && signInfo.SignedBlocksCounter < minSignedPerWindow {
 		// Downtime confirmed, slash, revoke, and jail the validator
-		logger.Info(fmt.Sprintf("Validator %s past min height of %d and below signed blocks threshold of %d", pubkey.Address(), minHeight, MinSignedPerWindow))
-		k.validatorSet.Slash(ctx, pubkey, height, SlashFractionDowntime)
+		logger.Info(fmt.Sprintf("Validator %s past min height of %d and below signed blocks threshold of %d", pubkey.Address(), minHeight, minSignedPerWindow))
+		tags = k.validatorSet.Slash(ctx, pubkey, height, power, k.SlashFractionDowntime(ctx))
 		k.validatorSet.Revoke(ctx, pubkey)
-		signInfo.JailedUntil = ctx.BlockHeader().Time + DowntimeUnbondDuration
+		signInfo.JailedUntil = ctx.BlockHeader().Time + k.DowntimeUnbondDuration(ctx)
 	}
 
 	// Set the updated signing info
 	k.setValidatorSigningInfo(ctx, address, signInfo)
+	return tags
 }
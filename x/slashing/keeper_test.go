@@ -25,12 +25,12 @@ func TestHandleDoubleSign(t *testing.T) {
 	require.Equal(t, sdk.NewRat(amt), sk.Validator(ctx, addr).GetPower())
 
 	// double sign less than max age
-	keeper.handleDoubleSign(ctx, 0, 0, val)
+	keeper.handleDoubleSign(ctx, 0, 0, val, amt)
 	require.Equal(t, sdk.NewRat(amt).Mul(sdk.NewRat(19).Quo(sdk.NewRat(20))), sk.Validator(ctx, addr).GetPower())
 	ctx = ctx.WithBlockHeader(abci.Header{Time: 300})
 
 	// double sign past max age
-	keeper.handleDoubleSign(ctx, 0, 0, val)
+	keeper.handleDoubleSign(ctx, 0, 0, val, amt)
 	require.Equal(t, sdk.NewRat(amt).Mul(sdk.NewRat(19).Quo(sdk.NewRat(20))), sk.Validator(ctx, addr).GetPower())
 }
 
@@ -59,22 +59,22 @@ func TestHandleAbsentValidator(t *testing.T) {
 	// 1000 first blocks OK
 	for ; height < 1000; height++ {
 		ctx = ctx.WithBlockHeight(height)
-		keeper.handleValidatorSignature(ctx, val, true)
+		keeper.handleValidatorSignature(ctx, val, amt, true)
 	}
 	info, found = keeper.getValidatorSigningInfo(ctx, val.Address())
 	require.True(t, found)
 	require.Equal(t, int64(0), info.StartHeight)
-	require.Equal(t, SignedBlocksWindow, info.SignedBlocksCounter)
+	require.Equal(t, keeper.SignedBlocksWindow(ctx), info.SignedBlocksCounter)
 
 	// 50 blocks missed
 	for ; height < 1050; height++ {
 		ctx = ctx.WithBlockHeight(height)
-		keeper.handleValidatorSignature(ctx, val, false)
+		keeper.handleValidatorSignature(ctx, val, amt, false)
 	}
 	info, found = keeper.getValidatorSigningInfo(ctx, val.Address())
 	require.True(t, found)
 	require.Equal(t, int64(0), info.StartHeight)
-	require.Equal(t, SignedBlocksWindow-50, info.SignedBlocksCounter)
+	require.Equal(t, keeper.SignedBlocksWindow(ctx)-50, info.SignedBlocksCounter)
 
 	// validator should be bonded still
 	validator, _ := sk.GetValidatorByPubKey(ctx, val)
@@ -84,11 +84,11 @@ func TestHandleAbsentValidator(t *testing.T) {
 
 	// 51st block missed
 	ctx = ctx.WithBlockHeight(height)
-	keeper.handleValidatorSignature(ctx, val, false)
+	keeper.handleValidatorSignature(ctx, val, amt, false)
 	info, found = keeper.getValidatorSigningInfo(ctx, val.Address())
 	require.True(t, found)
 	require.Equal(t, int64(0), info.StartHeight)
-	require.Equal(t, SignedBlocksWindow-51, info.SignedBlocksCounter)
+	require.Equal(t, keeper.SignedBlocksWindow(ctx)-51, info.SignedBlocksCounter)
 
 	// validator should have been revoked
 	validator, _ = sk.GetValidatorByPubKey(ctx, val)
@@ -115,12 +115,12 @@ func TestHandleAbsentValidator(t *testing.T) {
 	info, found = keeper.getValidatorSigningInfo(ctx, val.Address())
 	require.True(t, found)
 	require.Equal(t, height, info.StartHeight)
-	require.Equal(t, SignedBlocksWindow-51, info.SignedBlocksCounter)
+	require.Equal(t, keeper.SignedBlocksWindow(ctx)-51, info.SignedBlocksCounter)
 
 	// validator should not be immediately revoked again
 	height++
 	ctx = ctx.WithBlockHeight(height)
-	keeper.handleValidatorSignature(ctx, val, false)
+	keeper.handleValidatorSignature(ctx, val, amt, false)
 	validator, _ = sk.GetValidatorByPubKey(ctx, val)
 	require.Equal(t, sdk.Bonded, validator.GetStatus())
 
@@ -128,7 +128,7 @@ func TestHandleAbsentValidator(t *testing.T) {
 	nextHeight := height + 100
 	for ; height <= nextHeight; height++ {
 		ctx = ctx.WithBlockHeight(height)
-		keeper.handleValidatorSignature(ctx, val, false)
+		keeper.handleValidatorSignature(ctx, val, amt, false)
 	}
 	validator, _ = sk.GetValidatorByPubKey(ctx, val)
 	require.Equal(t, sdk.Unbonded, validator.GetStatus())
@@ -152,9 +152,9 @@ func TestHandleNewValidator(t *testing.T) {
 	ctx = ctx.WithBlockHeight(1001)
 
 	// Now a validator, for two blocks
-	keeper.handleValidatorSignature(ctx, val, true)
+	keeper.handleValidatorSignature(ctx, val, amt, true)
 	ctx = ctx.WithBlockHeight(1002)
-	keeper.handleValidatorSignature(ctx, val, false)
+	keeper.handleValidatorSignature(ctx, val, amt, false)
 
 	info, found := keeper.getValidatorSigningInfo(ctx, val.Address())
 	require.True(t, found)
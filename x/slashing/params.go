@@ -1,37 +1,130 @@
 package slashing
 
 import (
+	"errors"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
-const (
-	// MaxEvidenceAge - Max age for evidence - 21 days (3 weeks)
-	// TODO Should this be a governance parameter or just modifiable with SoftwareUpgradeProposals?
-	// MaxEvidenceAge = 60 * 60 * 24 * 7 * 3
-	// TODO Temporarily set to 2 minutes for testnets.
-	MaxEvidenceAge int64 = 60 * 2
-
-	// SignedBlocksWindow - sliding window for downtime slashing
-	// TODO Governance parameter?
-	// TODO Temporarily set to 100 blocks for testnets
-	SignedBlocksWindow int64 = 100
-
-	// Downtime slashing threshold - 50%
-	// TODO Governance parameter?
-	MinSignedPerWindow int64 = SignedBlocksWindow / 2
-
-	// Downtime unbond duration
-	// TODO Governance parameter?
-	// TODO Temporarily set to 10 minutes for testnets
-	DowntimeUnbondDuration int64 = 60 * 10
-)
+// name of the slashing subspace in the params store
+const DefaultParamspace = "slashing"
 
+// keys of the slashing parameters in the params store
 var (
-	// SlashFractionDoubleSign - currently 5%
-	// TODO Governance parameter?
-	SlashFractionDoubleSign = sdk.NewRat(1).Quo(sdk.NewRat(20))
-
-	// SlashFractionDowntime - currently 1%
-	// TODO Governance parameter?
-	SlashFractionDowntime = sdk.NewRat(1).Quo(sdk.NewRat(100))
+	KeyMaxEvidenceAge          = []byte("MaxEvidenceAge")
+	KeySignedBlocksWindow      = []byte("SignedBlocksWindow")
+	KeyMinSignedPerWindow      = []byte("MinSignedPerWindow")
+	KeyDowntimeUnbondDuration  = []byte("DowntimeUnbondDuration")
+	KeySlashFractionDoubleSign = []byte("SlashFractionDoubleSign")
+	KeySlashFractionDowntime   = []byte("SlashFractionDowntime")
 )
+
+// Params defines the high level settings for slashing
+type Params struct {
+	MaxEvidenceAge          int64   `json:"max_evidence_age"`           // max age in seconds of evidence of equivocation
+	SignedBlocksWindow      int64   `json:"signed_blocks_window"`       // sliding window in blocks for downtime slashing
+	MinSignedPerWindow      sdk.Rat `json:"min_signed_per_window"`      // fraction of the window a validator must have signed
+	DowntimeUnbondDuration  int64   `json:"downtime_unbond_duration"`   // seconds a validator is jailed for downtime
+	SlashFractionDoubleSign sdk.Rat `json:"slash_fraction_double_sign"` // fraction of stake slashed for equivocation
+	SlashFractionDowntime   sdk.Rat `json:"slash_fraction_downtime"`    // fraction of stake slashed for downtime
+}
+
+// implements params.ParamSet
+func (p *Params) ParamSetPairs() []params.ParamSetPair {
+	return []params.ParamSetPair{
+		params.NewParamSetPair(KeyMaxEvidenceAge, &p.MaxEvidenceAge, validatePositive),
+		params.NewParamSetPair(KeySignedBlocksWindow, &p.SignedBlocksWindow, validatePositive),
+		params.NewParamSetPair(KeyMinSignedPerWindow, &p.MinSignedPerWindow, validateFraction),
+		params.NewParamSetPair(KeyDowntimeUnbondDuration, &p.DowntimeUnbondDuration, validatePositive),
+		params.NewParamSetPair(KeySlashFractionDoubleSign, &p.SlashFractionDoubleSign, validateFraction),
+		params.NewParamSetPair(KeySlashFractionDowntime, &p.SlashFractionDowntime, validateFraction),
+	}
+}
+
+func validatePositive(value interface{}) error {
+	if value.(int64) <= 0 {
+		return errors.New("must be positive")
+	}
+	return nil
+}
+
+func validateFraction(value interface{}) error {
+	r := value.(sdk.Rat)
+	if r.LT(sdk.ZeroRat()) || r.GT(sdk.OneRat()) {
+		return errors.New("must be between 0 and 1")
+	}
+	return nil
+}
+
+// default params
+func DefaultParams() Params {
+	return Params{
+		// TODO Temporarily set to 2 minutes for testnets, should be 21 days (3 weeks)
+		MaxEvidenceAge: 60 * 2,
+
+		// TODO Temporarily set to 100 blocks for testnets
+		SignedBlocksWindow: 100,
+
+		// Downtime slashing threshold - 50%
+		MinSignedPerWindow: sdk.NewRat(1, 2),
+
+		// TODO Temporarily set to 10 minutes for testnets
+		DowntimeUnbondDuration: 60 * 10,
+
+		// currently 5%
+		SlashFractionDoubleSign: sdk.NewRat(1).Quo(sdk.NewRat(20)),
+
+		// currently 1%
+		SlashFractionDowntime: sdk.NewRat(1).Quo(sdk.NewRat(100)),
+	}
+}
+
+//_______________________________________________________________________
+
+// load/save the global slashing params
+func (k Keeper) GetParams(ctx sdk.Context) (params Params) {
+	k.paramSpace.GetParamSet(ctx, &params)
+	return
+}
+
+func (k Keeper) setParams(ctx sdk.Context, params Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
+// max age in seconds of evidence of equivocation
+func (k Keeper) MaxEvidenceAge(ctx sdk.Context) (age int64) {
+	k.paramSpace.Get(ctx, KeyMaxEvidenceAge, &age)
+	return
+}
+
+// sliding window in blocks for downtime slashing
+func (k Keeper) SignedBlocksWindow(ctx sdk.Context) (window int64) {
+	k.paramSpace.Get(ctx, KeySignedBlocksWindow, &window)
+	return
+}
+
+// minimum number of blocks of the window a validator must have signed
+func (k Keeper) MinSignedPerWindow(ctx sdk.Context) int64 {
+	var fraction sdk.Rat
+	k.paramSpace.Get(ctx, KeyMinSignedPerWindow, &fraction)
+	return sdk.NewRat(k.SignedBlocksWindow(ctx)).Mul(fraction).Evaluate()
+}
+
+// seconds a validator is jailed for downtime
+func (k Keeper) DowntimeUnbondDuration(ctx sdk.Context) (duration int64) {
+	k.paramSpace.Get(ctx, KeyDowntimeUnbondDuration, &duration)
+	return
+}
+
+// fraction of stake slashed for equivocation
+func (k Keeper) SlashFractionDoubleSign(ctx sdk.Context) (fraction sdk.Rat) {
+	k.paramSpace.Get(ctx, KeySlashFractionDoubleSign, &fraction)
+	return
+}
+
+// fraction of stake slashed for downtime
+func (k Keeper) SlashFractionDowntime(ctx sdk.Context) (fraction sdk.Rat) {
+	k.paramSpace.Get(ctx, KeySlashFractionDowntime, &fraction)
+	return
+}
@@ -47,6 +47,57 @@ func (k Keeper) setValidatorSigningBitArray(ctx sdk.Context, address sdk.Address
 	store.Set(GetValidatorSigningBitArrayKey(address, index), bz)
 }
 
+// The signed blocks window the signing infos and bit arrays were kept for,
+// zero if it was never stored
+func (k Keeper) getSigningWindow(ctx sdk.Context) (window int64) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(SigningWindowKey)
+	if bz == nil {
+		return 0
+	}
+	k.cdc.MustUnmarshalBinary(bz, &window)
+	return
+}
+
+func (k Keeper) setSigningWindow(ctx sdk.Context, window int64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(SigningWindowKey, k.cdc.MustMarshalBinary(window))
+}
+
+// Restart the signing infos of all validators when the signed blocks window
+// parameter changed, the bit arrays kept for the previous window cannot be
+// read with the new one. Every validator starts a new window at the height,
+// jailed validators stay jailed.
+func (k Keeper) resetSigningInfosOnWindowChange(ctx sdk.Context) {
+	window := k.SignedBlocksWindow(ctx)
+	previous := k.getSigningWindow(ctx)
+	if previous == window {
+		return
+	}
+	k.setSigningWindow(ctx, window)
+	if previous == 0 {
+		return
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	var keys [][]byte
+	iterator := sdk.KVStorePrefixIterator(store, []byte{0x02})
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+
+	for _, info := range k.getAllSigningInfos(ctx) {
+		signInfo := NewValidatorSigningInfo(ctx.BlockHeight(), 0, info.SigningInfo.JailedUntil, 0)
+		k.setValidatorSigningInfo(ctx, info.Address, signInfo)
+	}
+	ctx.Logger().With("module", "x/slashing").Info(fmt.Sprintf(
+		"Restarted the signing infos of validators for the signed blocks window of %d instead of %d", window, previous))
+}
+
 // Construct a new `ValidatorSigningInfo` struct
 func NewValidatorSigningInfo(startHeight int64, indexOffset int64, jailedUntil int64, signedBlocksCounter int64) ValidatorSigningInfo {
 	return ValidatorSigningInfo{
@@ -71,6 +122,9 @@ func (i ValidatorSigningInfo) HumanReadableString() string {
 		i.StartHeight, i.IndexOffset, i.JailedUntil, i.SignedBlocksCounter)
 }
 
+// Key of the signed blocks window the signing infos were kept for
+var SigningWindowKey = []byte{0x03}
+
 // Stored by *validator* address (not owner address)
 func GetValidatorSigningInfoKey(v sdk.Address) []byte {
 	return append([]byte{0x01}, v.Bytes()...)
@@ -17,6 +17,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 )
 
@@ -46,29 +47,35 @@ func createTestCodec() *wire.Codec {
 
 func createTestInput(t *testing.T) (sdk.Context, bank.Keeper, stake.Keeper, Keeper) {
 	keyAcc := sdk.NewKVStoreKey("acc")
+	keyBank := sdk.NewKVStoreKey("bank")
 	keyStake := sdk.NewKVStoreKey("stake")
 	keySlashing := sdk.NewKVStoreKey("slashing")
+	keyParams := sdk.NewKVStoreKey("params")
 	db := dbm.NewMemDB()
 	ms := store.NewCommitMultiStore(db)
 	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyBank, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keyStake, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keySlashing, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
 	err := ms.LoadLatestVersion()
 	require.Nil(t, err)
 	ctx := sdk.NewContext(ms, abci.Header{}, false, nil, log.NewTMLogger(os.Stdout))
 	cdc := createTestCodec()
 	accountMapper := auth.NewAccountMapper(cdc, keyAcc, &auth.BaseAccount{})
-	ck := bank.NewKeeper(accountMapper)
-	sk := stake.NewKeeper(cdc, keyStake, ck, stake.DefaultCodespace)
+	ck := bank.NewKeeper(cdc, keyBank, accountMapper, bank.DefaultCodespace)
+	pk := params.NewKeeper(cdc, keyParams)
+	sk := stake.NewKeeper(cdc, keyStake, ck, pk.Subspace(stake.DefaultParamspace), stake.DefaultCodespace)
 	genesis := stake.DefaultGenesisState()
 	genesis.Pool.LooseUnbondedTokens = initCoins * int64(len(addrs))
 	stake.InitGenesis(ctx, sk, genesis)
 	for _, addr := range addrs {
-		ck.AddCoins(ctx, addr, sdk.Coins{
+		ck.MintCoins(ctx, addr, sdk.Coins{
 			{sk.GetParams(ctx).BondDenom, initCoins},
 		})
 	}
-	keeper := NewKeeper(cdc, keySlashing, sk, DefaultCodespace)
+	keeper := NewKeeper(cdc, keySlashing, sk, pk.Subspace(DefaultParamspace), DefaultCodespace)
+	InitGenesis(ctx, keeper, DefaultGenesisState())
 	return ctx, ck, sk, keeper
 }
 
@@ -24,12 +24,16 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, sk Keeper) (tags
 		}
 		switch string(evidence.Type) {
 		case tmtypes.ABCIEvidenceTypeDuplicateVote:
-			sk.handleDoubleSign(ctx, evidence.Height, evidence.Time, pk)
+			slashTags := sk.handleDoubleSign(ctx, evidence.Height, evidence.Time, pk, evidence.Validator.Power)
+			tags = tags.AppendTags(slashTags)
 		default:
 			ctx.Logger().With("module", "x/slashing").Error(fmt.Sprintf("Ignored unknown evidence type: %s", string(evidence.Type)))
 		}
 	}
 
+	// Restart the signing windows if governance changed their size
+	sk.resetSigningInfosOnWindowChange(ctx)
+
 	// Iterate over all the validators  which *should* have signed this block
 	for _, validator := range req.Validators {
 		present := validator.SignedLastBlock
@@ -37,7 +41,8 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, sk Keeper) (tags
 		if err != nil {
 			panic(err)
 		}
-		sk.handleValidatorSignature(ctx, pubkey, present)
+		slashTags := sk.handleValidatorSignature(ctx, pubkey, validator.Validator.Power, present)
+		tags = tags.AppendTags(slashTags)
 	}
 
 	return
@@ -75,3 +75,62 @@ func TestBeginBlocker(t *testing.T) {
 	require.True(t, found)
 	require.Equal(t, sdk.Unbonded, validator.GetStatus())
 }
+
+// Test that the signing windows restart when governance changes their size
+func TestBeginBlockerWindowChange(t *testing.T) {
+	ctx, _, sk, keeper := createTestInput(t)
+	addr, pk, amt := addrs[2], pks[2], int64(100)
+
+	// bond the validator
+	got := stake.NewHandler(sk)(ctx, newTestMsgCreateValidator(addr, pk, amt))
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+	val := abci.Validator{
+		PubKey: tmtypes.TM2PB.PubKey(pk),
+		Power:  amt,
+	}
+	beginBlock := func(height int64, signed bool) {
+		ctx = ctx.WithBlockHeight(height)
+		req := abci.RequestBeginBlock{
+			Validators: []abci.SigningValidator{{
+				Validator:       val,
+				SignedLastBlock: signed,
+			}},
+		}
+		BeginBlocker(ctx, req, keeper)
+	}
+
+	// sign a whole window, then miss 40 blocks
+	height := int64(0)
+	for ; height < 100; height++ {
+		beginBlock(height, true)
+	}
+	for ; height < 140; height++ {
+		beginBlock(height, false)
+	}
+	info, found := keeper.getValidatorSigningInfo(ctx, pk.Address())
+	require.True(t, found)
+	require.Equal(t, int64(60), info.SignedBlocksCounter)
+
+	// governance halves the window
+	keeper.paramSpace.Set(ctx, KeySignedBlocksWindow, int64(50))
+	beginBlock(height, true)
+	info, found = keeper.getValidatorSigningInfo(ctx, pk.Address())
+	require.True(t, found)
+	require.Equal(t, height, info.StartHeight)
+	require.Equal(t, int64(1), info.IndexOffset)
+	require.Equal(t, int64(1), info.SignedBlocksCounter)
+	require.Equal(t, []int64{0}, keeper.getSignedBlocks(ctx, pk.Address()))
+	height++
+
+	// the counter only counts the blocks of the new window
+	for ; height < 240; height++ {
+		beginBlock(height, true)
+	}
+	info, found = keeper.getValidatorSigningInfo(ctx, pk.Address())
+	require.True(t, found)
+	require.Equal(t, int64(50), info.SignedBlocksCounter)
+	validator, found := sk.GetValidatorByPubKey(ctx, pk)
+	require.True(t, found)
+	require.Equal(t, sdk.Bonded, validator.GetStatus())
+}
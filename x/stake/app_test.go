@@ -7,6 +7,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/auth/mock"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -24,8 +25,8 @@ var (
 	addr4 = priv4.PubKey().Address()
 	coins = sdk.Coins{{"foocoin", 10}}
 	fee   = auth.StdFee{
-		sdk.Coins{{"foocoin", 0}},
-		100000,
+		Amount: sdk.Coins{{"foocoin", 0}},
+		Gas:    100000,
 	}
 )
 
@@ -35,14 +36,18 @@ func getMockApp(t *testing.T) (*mock.App, Keeper) {
 
 	RegisterWire(mapp.Cdc)
 	keyStake := sdk.NewKVStoreKey("stake")
-	coinKeeper := bank.NewKeeper(mapp.AccountMapper)
-	keeper := NewKeeper(mapp.Cdc, keyStake, coinKeeper, mapp.RegisterCodespace(DefaultCodespace))
+	keyParams := sdk.NewKVStoreKey("params")
+	keyBank := sdk.NewKVStoreKey("bank")
+	coinKeeper := bank.NewKeeper(mapp.Cdc, keyBank, mapp.AccountMapper, mapp.RegisterCodespace(bank.DefaultCodespace))
+	paramsKeeper := params.NewKeeper(mapp.Cdc, keyParams)
+	keeper := NewKeeper(mapp.Cdc, keyStake, coinKeeper, paramsKeeper.Subspace(DefaultParamspace),
+		mapp.RegisterCodespace(DefaultCodespace))
 	mapp.Router().AddRoute("stake", NewHandler(keeper))
 
 	mapp.SetEndBlocker(getEndBlocker(keeper))
 	mapp.SetInitChainer(getInitChainer(mapp, keeper))
 
-	mapp.CompleteSetup(t, []*sdk.KVStoreKey{keyStake})
+	mapp.CompleteSetup(t, []*sdk.KVStoreKey{keyBank, keyStake, keyParams})
 	return mapp, keeper
 }
 
@@ -117,7 +122,7 @@ func TestStakeMsgs(t *testing.T) {
 	createValidatorMsg := NewMsgCreateValidator(
 		addr1, priv1.PubKey(), bondCoin, description,
 	)
-	mock.SignCheckDeliver(t, mapp.BaseApp, createValidatorMsg, []int64{0}, []int64{0}, true, priv1)
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{createValidatorMsg}, []int64{0}, []int64{0}, true, priv1)
 	mock.CheckBalance(t, mapp, addr1, sdk.Coins{genCoin.Minus(bondCoin)})
 	mapp.BeginBlock(abci.RequestBeginBlock{})
 
@@ -134,7 +139,7 @@ func TestStakeMsgs(t *testing.T) {
 
 	description = NewDescription("bar_moniker", "", "", "")
 	editValidatorMsg := NewMsgEditValidator(addr1, description)
-	mock.SignCheckDeliver(t, mapp.BaseApp, editValidatorMsg, []int64{0}, []int64{1}, true, priv1)
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{editValidatorMsg}, []int64{0}, []int64{1}, true, priv1)
 	validator = checkValidator(t, mapp, keeper, addr1, true)
 	require.Equal(t, description, validator.Description)
 
@@ -143,7 +148,7 @@ func TestStakeMsgs(t *testing.T) {
 
 	mock.CheckBalance(t, mapp, addr2, sdk.Coins{genCoin})
 	delegateMsg := NewMsgDelegate(addr2, addr1, bondCoin)
-	mock.SignCheckDeliver(t, mapp.BaseApp, delegateMsg, []int64{1}, []int64{0}, true, priv2)
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{delegateMsg}, []int64{1}, []int64{0}, true, priv2)
 	mock.CheckBalance(t, mapp, addr2, sdk.Coins{genCoin.Minus(bondCoin)})
 	checkDelegation(t, mapp, keeper, addr2, addr1, true, sdk.NewRat(10))
 
@@ -151,7 +156,14 @@ func TestStakeMsgs(t *testing.T) {
 	// Unbond
 
 	unbondMsg := NewMsgUnbond(addr2, addr1, "MAX")
-	mock.SignCheckDeliver(t, mapp.BaseApp, unbondMsg, []int64{1}, []int64{1}, true, priv2)
-	mock.CheckBalance(t, mapp, addr2, sdk.Coins{genCoin})
+	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{unbondMsg}, []int64{1}, []int64{1}, true, priv2)
+	mock.CheckBalance(t, mapp, addr2, sdk.Coins{genCoin.Minus(bondCoin)})
 	checkDelegation(t, mapp, keeper, addr2, addr1, false, sdk.Rat{})
+
+	// pass the unbonding period
+	header := abci.Header{Time: DefaultParams().UnbondingTime}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	mapp.EndBlock(abci.RequestEndBlock{})
+	mapp.Commit()
+	mock.CheckBalance(t, mapp, addr2, sdk.Coins{genCoin})
 }
@@ -6,11 +6,13 @@ import (
 
 // nolint
 const (
-	FlagAddressDelegator = "address-delegator"
-	FlagAddressValidator = "address-validator"
-	FlagPubKey           = "pubkey"
-	FlagAmount           = "amount"
-	FlagShares           = "shares"
+	FlagAddressDelegator    = "address-delegator"
+	FlagAddressValidator    = "address-validator"
+	FlagAddressValidatorSrc = "address-validator-source"
+	FlagAddressValidatorDst = "address-validator-dest"
+	FlagPubKey              = "pubkey"
+	FlagAmount              = "amount"
+	FlagShares              = "shares"
 
 	FlagMoniker  = "moniker"
 	FlagIdentity = "keybase-sig"
@@ -20,12 +22,13 @@ const (
 
 // common flagsets to add to various functions
 var (
-	fsPk          = flag.NewFlagSet("", flag.ContinueOnError)
-	fsAmount      = flag.NewFlagSet("", flag.ContinueOnError)
-	fsShares      = flag.NewFlagSet("", flag.ContinueOnError)
-	fsDescription = flag.NewFlagSet("", flag.ContinueOnError)
-	fsValidator   = flag.NewFlagSet("", flag.ContinueOnError)
-	fsDelegator   = flag.NewFlagSet("", flag.ContinueOnError)
+	fsPk           = flag.NewFlagSet("", flag.ContinueOnError)
+	fsAmount       = flag.NewFlagSet("", flag.ContinueOnError)
+	fsShares       = flag.NewFlagSet("", flag.ContinueOnError)
+	fsDescription  = flag.NewFlagSet("", flag.ContinueOnError)
+	fsValidator    = flag.NewFlagSet("", flag.ContinueOnError)
+	fsDelegator    = flag.NewFlagSet("", flag.ContinueOnError)
+	fsRedelegation = flag.NewFlagSet("", flag.ContinueOnError)
 )
 
 func init() {
@@ -38,4 +41,6 @@ func init() {
 	fsDescription.String(FlagDetails, "", "optional details")
 	fsValidator.String(FlagAddressValidator, "", "hex address of the validator")
 	fsDelegator.String(FlagAddressDelegator, "", "hex address of the delegator")
+	fsRedelegation.String(FlagAddressValidatorSrc, "", "hex address of the source validator")
+	fsRedelegation.String(FlagAddressValidatorDst, "", "hex address of the destination validator")
 }
@@ -196,3 +196,263 @@ func GetCmdQueryDelegations(storeName string, cdc *wire.Codec) *cobra.Command {
 	}
 	return cmd
 }
+
+// get the command to query the unbonding delegations between a delegator and a validator
+func GetCmdQueryUnbondingDelegation(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unbonding-delegation",
+		Short: "Query the pending unbonding delegations based on delegator address and validator address",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			valAddr, err := sdk.GetAccAddressBech32(viper.GetString(FlagAddressValidator))
+			if err != nil {
+				return err
+			}
+
+			delAddr, err := sdk.GetAccAddressBech32(viper.GetString(FlagAddressDelegator))
+			if err != nil {
+				return err
+			}
+
+			key := stake.GetUBDKeyPrefix(delAddr, valAddr, cdc)
+			ctx := context.NewCoreContextFromViper()
+			resKVs, err := ctx.QuerySubspace(cdc, key, storeName)
+			if err != nil {
+				return err
+			}
+
+			// parse out the unbonding delegations
+			var ubds []stake.UnbondingDelegation
+			for _, KV := range resKVs {
+				var ubd stake.UnbondingDelegation
+				cdc.MustUnmarshalBinary(KV.Value, &ubd)
+				ubds = append(ubds, ubd)
+			}
+			return printUnbondingDelegations(cdc, ubds)
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsValidator)
+	cmd.Flags().AddFlagSet(fsDelegator)
+	return cmd
+}
+
+// get the command to query all the pending unbonding delegations of a delegator
+func GetCmdQueryUnbondingDelegations(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unbonding-delegations [delegator-addr]",
+		Short: "Query all pending unbonding delegations made from one delegator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			delegatorAddr, err := sdk.GetAccAddressBech32(args[0])
+			if err != nil {
+				return err
+			}
+			key := stake.GetUBDsKey(delegatorAddr, cdc)
+			ctx := context.NewCoreContextFromViper()
+			resKVs, err := ctx.QuerySubspace(cdc, key, storeName)
+			if err != nil {
+				return err
+			}
+
+			// parse out the unbonding delegations
+			var ubds []stake.UnbondingDelegation
+			for _, KV := range resKVs {
+				var ubd stake.UnbondingDelegation
+				cdc.MustUnmarshalBinary(KV.Value, &ubd)
+				ubds = append(ubds, ubd)
+			}
+			return printUnbondingDelegations(cdc, ubds)
+		},
+	}
+	return cmd
+}
+
+// get the command to query all the pending unbonding delegations from a validator
+func GetCmdQueryValidatorUnbondingDelegations(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unbonding-delegations-from [owner-addr]",
+		Short: "Query all pending unbonding delegations from one validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			validatorAddr, err := sdk.GetAccAddressBech32(args[0])
+			if err != nil {
+				return err
+			}
+			key := stake.GetUBDsByValIndexKey(validatorAddr, cdc)
+			ctx := context.NewCoreContextFromViper()
+			resKVs, err := ctx.QuerySubspace(cdc, key, storeName)
+			if err != nil {
+				return err
+			}
+
+			// the index holds the keys of the unbonding delegations
+			var ubds []stake.UnbondingDelegation
+			for _, KV := range resKVs {
+				res, err := ctx.Query(KV.Value, storeName)
+				if err != nil {
+					return err
+				}
+				var ubd stake.UnbondingDelegation
+				cdc.MustUnmarshalBinary(res, &ubd)
+				ubds = append(ubds, ubd)
+			}
+			return printUnbondingDelegations(cdc, ubds)
+		},
+	}
+	return cmd
+}
+
+func printUnbondingDelegations(cdc *wire.Codec, ubds []stake.UnbondingDelegation) error {
+	switch viper.Get(cli.OutputFlag) {
+	case "text":
+		for _, ubd := range ubds {
+			resp, err := ubd.HumanReadableString()
+			if err != nil {
+				return err
+			}
+			fmt.Println(resp)
+		}
+	case "json":
+		output, err := wire.MarshalJSONIndent(cdc, ubds)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+	}
+	return nil
+
+	// TODO output with proofs / machine parseable etc.
+}
+
+// get the command to query a redelegation
+func GetCmdQueryRedelegation(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "redelegation",
+		Short: "Query a redelegation record based on delegator and a source and destination validator address",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			valSrcAddr, err := sdk.GetAccAddressBech32(viper.GetString(FlagAddressValidatorSrc))
+			if err != nil {
+				return err
+			}
+			valDstAddr, err := sdk.GetAccAddressBech32(viper.GetString(FlagAddressValidatorDst))
+			if err != nil {
+				return err
+			}
+			delAddr, err := sdk.GetAccAddressBech32(viper.GetString(FlagAddressDelegator))
+			if err != nil {
+				return err
+			}
+
+			key := stake.GetREDKey(delAddr, valSrcAddr, valDstAddr, cdc)
+			ctx := context.NewCoreContextFromViper()
+			res, err := ctx.Query(key, storeName)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("no redelegation found")
+			}
+
+			// parse out the redelegation
+			var red stake.Redelegation
+			cdc.MustUnmarshalBinary(res, &red)
+			return printRedelegations(cdc, []stake.Redelegation{red})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsRedelegation)
+	cmd.Flags().AddFlagSet(fsDelegator)
+	return cmd
+}
+
+// get the command to query all the pending redelegations of a delegator
+func GetCmdQueryRedelegations(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "redelegations [delegator-addr]",
+		Short: "Query all pending redelegations made from one delegator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			delegatorAddr, err := sdk.GetAccAddressBech32(args[0])
+			if err != nil {
+				return err
+			}
+			key := stake.GetREDsKey(delegatorAddr, cdc)
+			ctx := context.NewCoreContextFromViper()
+			resKVs, err := ctx.QuerySubspace(cdc, key, storeName)
+			if err != nil {
+				return err
+			}
+
+			// parse out the redelegations
+			var reds []stake.Redelegation
+			for _, KV := range resKVs {
+				var red stake.Redelegation
+				cdc.MustUnmarshalBinary(KV.Value, &red)
+				reds = append(reds, red)
+			}
+			return printRedelegations(cdc, reds)
+		},
+	}
+	return cmd
+}
+
+// get the command to query all the pending redelegations away from a validator
+func GetCmdQueryValidatorRedelegations(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "redelegations-from [owner-addr]",
+		Short: "Query all pending redelegations away from one validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			validatorAddr, err := sdk.GetAccAddressBech32(args[0])
+			if err != nil {
+				return err
+			}
+			key := stake.GetREDsFromValSrcIndexKey(validatorAddr, cdc)
+			ctx := context.NewCoreContextFromViper()
+			resKVs, err := ctx.QuerySubspace(cdc, key, storeName)
+			if err != nil {
+				return err
+			}
+
+			// the index holds the keys of the redelegations
+			var reds []stake.Redelegation
+			for _, KV := range resKVs {
+				res, err := ctx.Query(KV.Value, storeName)
+				if err != nil {
+					return err
+				}
+				var red stake.Redelegation
+				cdc.MustUnmarshalBinary(res, &red)
+				reds = append(reds, red)
+			}
+			return printRedelegations(cdc, reds)
+		},
+	}
+	return cmd
+}
+
+func printRedelegations(cdc *wire.Codec, reds []stake.Redelegation) error {
+	switch viper.Get(cli.OutputFlag) {
+	case "text":
+		for _, red := range reds {
+			resp, err := red.HumanReadableString()
+			if err != nil {
+				return err
+			}
+			fmt.Println(resp)
+		}
+	case "json":
+		output, err := wire.MarshalJSONIndent(cdc, reds)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+	}
+	return nil
+}
@@ -50,7 +50,7 @@ func GetCmdCreateValidator(cdc *wire.Codec) *cobra.Command {
 			msg := stake.NewMsgCreateValidator(validatorAddr, pk, amount, description)
 
 			// build and sign the transaction, then broadcast to Tendermint
-			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, msg, cdc)
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
 			if err != nil {
 				return err
 			}
@@ -89,7 +89,7 @@ func GetCmdEditValidator(cdc *wire.Codec) *cobra.Command {
 			// build and sign the transaction, then broadcast to Tendermint
 			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
 
-			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, msg, cdc)
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
 			if err != nil {
 				return err
 			}
@@ -126,7 +126,7 @@ func GetCmdDelegate(cdc *wire.Codec) *cobra.Command {
 			// build and sign the transaction, then broadcast to Tendermint
 			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
 
-			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, msg, cdc)
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
 			if err != nil {
 				return err
 			}
@@ -151,16 +151,8 @@ func GetCmdUnbond(cdc *wire.Codec) *cobra.Command {
 
 			// check the shares before broadcasting
 			sharesStr := viper.GetString(FlagShares)
-			var shares sdk.Rat
-			if sharesStr != "MAX" {
-				var err error
-				shares, err = sdk.NewRatFromDecimal(sharesStr)
-				if err != nil {
-					return err
-				}
-				if !shares.GT(sdk.ZeroRat()) {
-					return fmt.Errorf("shares must be positive integer or decimal (ex. 123, 1.23456789)")
-				}
+			if err := checkShares(sharesStr); err != nil {
+				return err
 			}
 
 			delegatorAddr, err := sdk.GetAccAddressBech32(viper.GetString(FlagAddressDelegator))
@@ -174,7 +166,7 @@ func GetCmdUnbond(cdc *wire.Codec) *cobra.Command {
 			// build and sign the transaction, then broadcast to Tendermint
 			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
 
-			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, msg, cdc)
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
 			if err != nil {
 				return err
 			}
@@ -189,3 +181,105 @@ func GetCmdUnbond(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().AddFlagSet(fsValidator)
 	return cmd
 }
+
+// create begin redelegate command
+func GetCmdBeginRedelegate(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "begin-redelegate",
+		Short: "begin redelegating shares from one validator to another",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			// check the shares before broadcasting
+			sharesStr := viper.GetString(FlagShares)
+			if err := checkShares(sharesStr); err != nil {
+				return err
+			}
+
+			delegatorAddr, err := sdk.GetAccAddressBech32(viper.GetString(FlagAddressDelegator))
+			if err != nil {
+				return err
+			}
+			validatorSrcAddr, err := sdk.GetAccAddressBech32(viper.GetString(FlagAddressValidatorSrc))
+			if err != nil {
+				return err
+			}
+			validatorDstAddr, err := sdk.GetAccAddressBech32(viper.GetString(FlagAddressValidatorDst))
+			if err != nil {
+				return err
+			}
+
+			msg := stake.NewMsgBeginRedelegate(delegatorAddr, validatorSrcAddr, validatorDstAddr, sharesStr)
+
+			// build and sign the transaction, then broadcast to Tendermint
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsShares)
+	cmd.Flags().AddFlagSet(fsDelegator)
+	cmd.Flags().AddFlagSet(fsRedelegation)
+	return cmd
+}
+
+// create complete redelegate command
+func GetCmdCompleteRedelegate(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "complete-redelegate",
+		Short: "complete a redelegation once its unbonding period has passed",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			delegatorAddr, err := sdk.GetAccAddressBech32(viper.GetString(FlagAddressDelegator))
+			if err != nil {
+				return err
+			}
+			validatorSrcAddr, err := sdk.GetAccAddressBech32(viper.GetString(FlagAddressValidatorSrc))
+			if err != nil {
+				return err
+			}
+			validatorDstAddr, err := sdk.GetAccAddressBech32(viper.GetString(FlagAddressValidatorDst))
+			if err != nil {
+				return err
+			}
+
+			msg := stake.NewMsgCompleteRedelegate(delegatorAddr, validatorSrcAddr, validatorDstAddr)
+
+			// build and sign the transaction, then broadcast to Tendermint
+			ctx := context.NewCoreContextFromViper().WithDecoder(authcmd.GetAccountDecoder(cdc))
+
+			res, err := ctx.EnsureSignBuildBroadcast(ctx.FromAddressName, []sdk.Msg{msg}, cdc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsDelegator)
+	cmd.Flags().AddFlagSet(fsRedelegation)
+	return cmd
+}
+
+// shares must either be the keyword MAX or a positive decimal
+func checkShares(sharesStr string) error {
+	if sharesStr == "MAX" {
+		return nil
+	}
+	shares, err := sdk.NewRatFromDecimal(sharesStr)
+	if err != nil {
+		return err
+	}
+	if !shares.GT(sdk.ZeroRat()) {
+		return fmt.Errorf("shares must be positive integer or decimal (ex. 123, 1.23456789)")
+	}
+	return nil
+}
@@ -21,6 +21,18 @@ func registerQueryRoutes(ctx context.CoreContext, r *mux.Router, cdc *wire.Codec
 		"/stake/validators",
 		validatorsHandlerFn(ctx, "stake", cdc),
 	).Methods("GET")
+	r.HandleFunc(
+		"/stake/{delegator}/unbonding_delegations",
+		delegatorUnbondingDelegationsHandlerFn(ctx, "stake", cdc),
+	).Methods("GET")
+	r.HandleFunc(
+		"/stake/validators/{validator}/unbonding_delegations",
+		validatorUnbondingDelegationsHandlerFn(ctx, "stake", cdc),
+	).Methods("GET")
+	r.HandleFunc(
+		"/stake/{delegator}/redelegations",
+		delegatorRedelegationsHandlerFn(ctx, "stake", cdc),
+	).Methods("GET")
 }
 
 // http request handler to query delegator bonding status
@@ -179,3 +191,141 @@ func validatorsHandlerFn(ctx context.CoreContext, storeName string, cdc *wire.Co
 		w.Write(output)
 	}
 }
+
+// http request handler to query the pending unbonding delegations of a delegator
+func delegatorUnbondingDelegationsHandlerFn(ctx context.CoreContext, storeName string, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// read parameters
+		vars := mux.Vars(r)
+		bech32delegator := vars["delegator"]
+
+		delegatorAddr, err := sdk.GetAccAddressBech32(bech32delegator)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		kvs, err := ctx.QuerySubspace(cdc, stake.GetUBDsKey(delegatorAddr, cdc), storeName)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Couldn't query unbonding delegations. Error: %s", err.Error())))
+			return
+		}
+
+		// parse out the unbonding delegations
+		ubds := make([]stake.UnbondingDelegation, len(kvs))
+		for i, kv := range kvs {
+			err = cdc.UnmarshalBinary(kv.Value, &ubds[i])
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(fmt.Sprintf("Couldn't decode unbonding delegation. Error: %s", err.Error())))
+				return
+			}
+		}
+
+		output, err := cdc.MarshalJSON(ubds)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
+
+// http request handler to query the pending unbonding delegations from a validator
+func validatorUnbondingDelegationsHandlerFn(ctx context.CoreContext, storeName string, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// read parameters
+		vars := mux.Vars(r)
+		bech32validator := vars["validator"]
+
+		validatorAddr, err := sdk.GetValAddressBech32(bech32validator)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		kvs, err := ctx.QuerySubspace(cdc, stake.GetUBDsByValIndexKey(validatorAddr, cdc), storeName)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Couldn't query unbonding delegations. Error: %s", err.Error())))
+			return
+		}
+
+		// the index holds the keys of the unbonding delegations
+		ubds := make([]stake.UnbondingDelegation, len(kvs))
+		for i, kv := range kvs {
+			res, err := ctx.Query(kv.Value, storeName)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(fmt.Sprintf("Couldn't query unbonding delegation. Error: %s", err.Error())))
+				return
+			}
+			err = cdc.UnmarshalBinary(res, &ubds[i])
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(fmt.Sprintf("Couldn't decode unbonding delegation. Error: %s", err.Error())))
+				return
+			}
+		}
+
+		output, err := cdc.MarshalJSON(ubds)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
+
+// http request handler to query the pending redelegations of a delegator
+func delegatorRedelegationsHandlerFn(ctx context.CoreContext, storeName string, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// read parameters
+		vars := mux.Vars(r)
+		bech32delegator := vars["delegator"]
+
+		delegatorAddr, err := sdk.GetAccAddressBech32(bech32delegator)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		kvs, err := ctx.QuerySubspace(cdc, stake.GetREDsKey(delegatorAddr, cdc), storeName)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Couldn't query redelegations. Error: %s", err.Error())))
+			return
+		}
+
+		// parse out the redelegations
+		reds := make([]stake.Redelegation, len(kvs))
+		for i, kv := range kvs {
+			err = cdc.UnmarshalBinary(kv.Value, &reds[i])
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(fmt.Sprintf("Couldn't decode redelegation. Error: %s", err.Error())))
+				return
+			}
+		}
+
+		output, err := cdc.MarshalJSON(reds)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
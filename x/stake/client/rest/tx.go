@@ -35,15 +35,31 @@ type msgUnbondInput struct {
 	Shares        string `json:"shares"`
 }
 
+type msgBeginRedelegateInput struct {
+	DelegatorAddr    string `json:"delegator_addr"`     // in bech32
+	ValidatorSrcAddr string `json:"validator_src_addr"` // in bech32
+	ValidatorDstAddr string `json:"validator_dst_addr"` // in bech32
+	Shares           string `json:"shares"`
+}
+type msgCompleteRedelegateInput struct {
+	DelegatorAddr    string `json:"delegator_addr"`     // in bech32
+	ValidatorSrcAddr string `json:"validator_src_addr"` // in bech32
+	ValidatorDstAddr string `json:"validator_dst_addr"` // in bech32
+}
+
 type editDelegationsBody struct {
-	LocalAccountName string             `json:"name"`
-	Password         string             `json:"password"`
-	ChainID          string             `json:"chain_id"`
-	AccountNumber    int64              `json:"account_number"`
-	Sequence         int64              `json:"sequence"`
-	Gas              int64              `json:"gas"`
-	Delegate         []msgDelegateInput `json:"delegate"`
-	Unbond           []msgUnbondInput   `json:"unbond"`
+	LocalAccountName    string                       `json:"name"`
+	Password            string                       `json:"password"`
+	ChainID             string                       `json:"chain_id"`
+	AccountNumber       int64                        `json:"account_number"`
+	Sequence            int64                        `json:"sequence"`
+	Gas                 int64                        `json:"gas"`
+	Memo                string                       `json:"memo"`
+	TimeoutHeight       int64                        `json:"timeout_height"`
+	Delegate            []msgDelegateInput           `json:"delegate"`
+	Unbond              []msgUnbondInput             `json:"unbond"`
+	BeginRedelegates    []msgBeginRedelegateInput    `json:"begin_redelegates"`
+	CompleteRedelegates []msgCompleteRedelegateInput `json:"complete_redelegates"`
 }
 
 func editDelegationsRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx context.CoreContext) http.HandlerFunc {
@@ -70,7 +86,8 @@ func editDelegationsRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx conte
 		}
 
 		// build messages
-		messages := make([]sdk.Msg, len(m.Delegate)+len(m.Unbond))
+		messages := make([]sdk.Msg, len(m.Delegate)+len(m.Unbond)+
+			len(m.BeginRedelegates)+len(m.CompleteRedelegates))
 		i := 0
 		for _, msg := range m.Delegate {
 			delegatorAddr, err := sdk.GetAccAddressBech32(msg.DelegatorAddr)
@@ -122,9 +139,74 @@ func editDelegationsRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx conte
 			}
 			i++
 		}
+		for _, msg := range m.BeginRedelegates {
+			delegatorAddr, err := sdk.GetAccAddressBech32(msg.DelegatorAddr)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(fmt.Sprintf("Couldn't decode delegator. Error: %s", err.Error())))
+				return
+			}
+			validatorSrcAddr, err := sdk.GetValAddressBech32(msg.ValidatorSrcAddr)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(fmt.Sprintf("Couldn't decode source validator. Error: %s", err.Error())))
+				return
+			}
+			validatorDstAddr, err := sdk.GetValAddressBech32(msg.ValidatorDstAddr)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(fmt.Sprintf("Couldn't decode destination validator. Error: %s", err.Error())))
+				return
+			}
+			if !bytes.Equal(info.Address(), delegatorAddr) {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte("Must use own delegator address"))
+				return
+			}
+			messages[i] = stake.MsgBeginRedelegate{
+				DelegatorAddr:    delegatorAddr,
+				ValidatorSrcAddr: validatorSrcAddr,
+				ValidatorDstAddr: validatorDstAddr,
+				Shares:           msg.Shares,
+			}
+			i++
+		}
+		for _, msg := range m.CompleteRedelegates {
+			delegatorAddr, err := sdk.GetAccAddressBech32(msg.DelegatorAddr)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(fmt.Sprintf("Couldn't decode delegator. Error: %s", err.Error())))
+				return
+			}
+			validatorSrcAddr, err := sdk.GetValAddressBech32(msg.ValidatorSrcAddr)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(fmt.Sprintf("Couldn't decode source validator. Error: %s", err.Error())))
+				return
+			}
+			validatorDstAddr, err := sdk.GetValAddressBech32(msg.ValidatorDstAddr)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(fmt.Sprintf("Couldn't decode destination validator. Error: %s", err.Error())))
+				return
+			}
+			if !bytes.Equal(info.Address(), delegatorAddr) {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte("Must use own delegator address"))
+				return
+			}
+			messages[i] = stake.MsgCompleteRedelegate{
+				DelegatorAddr:    delegatorAddr,
+				ValidatorSrcAddr: validatorSrcAddr,
+				ValidatorDstAddr: validatorDstAddr,
+			}
+			i++
+		}
 
-		// add gas to context
+		// add gas, memo and timeout height to context
 		ctx = ctx.WithGas(m.Gas)
+		ctx = ctx.WithMemo(m.Memo)
+		ctx = ctx.WithTimeoutHeight(m.TimeoutHeight)
 
 		// sign messages
 		signedTxs := make([][]byte, len(messages[:]))
@@ -134,7 +216,7 @@ func editDelegationsRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, ctx conte
 			ctx = ctx.WithSequence(m.Sequence)
 			m.Sequence++
 
-			txBytes, err := ctx.SignAndBuild(m.LocalAccountName, m.Password, msg, cdc)
+			txBytes, err := ctx.SignAndBuild(m.LocalAccountName, m.Password, []sdk.Msg{msg}, cdc)
 			if err != nil {
 				w.WriteHeader(http.StatusUnauthorized)
 				w.Write([]byte(err.Error()))
@@ -52,3 +52,106 @@ func (b Delegation) HumanReadableString() (string, error) {
 	return resp, nil
 
 }
+
+//__________________________________________________________________
+
+// UnbondingDelegation reserves tokens which have been unbonded from a
+// validator until the unbonding period has passed. While the entry exists
+// the tokens remain slashable for infractions committed by the validator
+// before the unbonding began.
+type UnbondingDelegation struct {
+	DelegatorAddr  sdk.Address `json:"delegator_addr"`  // delegator
+	ValidatorAddr  sdk.Address `json:"validator_addr"`  // validator unbonding from owner addr
+	CreationHeight int64       `json:"creation_height"` // height which the unbonding took place
+	CompletionTime int64       `json:"completion_time"` // unix time at which the unbonding will complete
+	InitialBalance sdk.Coin    `json:"initial_balance"` // atoms initially scheduled to receive at completion
+	Balance        sdk.Coin    `json:"balance"`         // atoms to receive at completion
+}
+
+func (d UnbondingDelegation) equal(d2 UnbondingDelegation) bool {
+	return bytes.Equal(d.DelegatorAddr, d2.DelegatorAddr) &&
+		bytes.Equal(d.ValidatorAddr, d2.ValidatorAddr) &&
+		d.CreationHeight == d2.CreationHeight &&
+		d.CompletionTime == d2.CompletionTime &&
+		d.InitialBalance.IsEqual(d2.InitialBalance) &&
+		d.Balance.IsEqual(d2.Balance)
+}
+
+//Human Friendly pretty printer
+func (d UnbondingDelegation) HumanReadableString() (string, error) {
+	bechAcc, err := sdk.Bech32ifyAcc(d.DelegatorAddr)
+	if err != nil {
+		return "", err
+	}
+	bechVal, err := sdk.Bech32ifyAcc(d.ValidatorAddr)
+	if err != nil {
+		return "", err
+	}
+	resp := "Unbonding Delegation \n"
+	resp += fmt.Sprintf("Delegator: %s\n", bechAcc)
+	resp += fmt.Sprintf("Validator: %s\n", bechVal)
+	resp += fmt.Sprintf("Creation Height: %d\n", d.CreationHeight)
+	resp += fmt.Sprintf("Completion Time: %d\n", d.CompletionTime)
+	resp += fmt.Sprintf("Initial Balance: %s\n", d.InitialBalance.String())
+	resp += fmt.Sprintf("Balance: %s", d.Balance.String())
+
+	return resp, nil
+}
+
+//__________________________________________________________________
+
+// Redelegation records stake which has been moved from a source validator to
+// a destination validator. Until the entry is completed the moved stake
+// remains slashable for infractions committed by the source validator
+// before the redelegation began.
+type Redelegation struct {
+	DelegatorAddr    sdk.Address `json:"delegator_addr"`     // delegator
+	ValidatorSrcAddr sdk.Address `json:"validator_src_addr"` // validator redelegation source owner addr
+	ValidatorDstAddr sdk.Address `json:"validator_dst_addr"` // validator redelegation destination owner addr
+	CreationHeight   int64       `json:"creation_height"`    // height which the redelegation took place
+	CompletionTime   int64       `json:"completion_time"`    // unix time at which the redelegation may be completed
+	InitialBalance   sdk.Coin    `json:"initial_balance"`    // initial balance when redelegation started
+	Balance          sdk.Coin    `json:"balance"`            // current balance
+	SharesSrc        sdk.Rat     `json:"shares_src"`         // amount of source shares redelegating
+	SharesDst        sdk.Rat     `json:"shares_dst"`         // amount of destination shares redelegating
+}
+
+func (d Redelegation) equal(d2 Redelegation) bool {
+	return bytes.Equal(d.DelegatorAddr, d2.DelegatorAddr) &&
+		bytes.Equal(d.ValidatorSrcAddr, d2.ValidatorSrcAddr) &&
+		bytes.Equal(d.ValidatorDstAddr, d2.ValidatorDstAddr) &&
+		d.CreationHeight == d2.CreationHeight &&
+		d.CompletionTime == d2.CompletionTime &&
+		d.InitialBalance.IsEqual(d2.InitialBalance) &&
+		d.Balance.IsEqual(d2.Balance) &&
+		d.SharesSrc.Equal(d2.SharesSrc) &&
+		d.SharesDst.Equal(d2.SharesDst)
+}
+
+//Human Friendly pretty printer
+func (d Redelegation) HumanReadableString() (string, error) {
+	bechAcc, err := sdk.Bech32ifyAcc(d.DelegatorAddr)
+	if err != nil {
+		return "", err
+	}
+	bechValSrc, err := sdk.Bech32ifyAcc(d.ValidatorSrcAddr)
+	if err != nil {
+		return "", err
+	}
+	bechValDst, err := sdk.Bech32ifyAcc(d.ValidatorDstAddr)
+	if err != nil {
+		return "", err
+	}
+	resp := "Redelegation \n"
+	resp += fmt.Sprintf("Delegator: %s\n", bechAcc)
+	resp += fmt.Sprintf("Source Validator: %s\n", bechValSrc)
+	resp += fmt.Sprintf("Destination Validator: %s\n", bechValDst)
+	resp += fmt.Sprintf("Creation Height: %d\n", d.CreationHeight)
+	resp += fmt.Sprintf("Completion Time: %d\n", d.CompletionTime)
+	resp += fmt.Sprintf("Initial Balance: %s\n", d.InitialBalance.String())
+	resp += fmt.Sprintf("Balance: %s\n", d.Balance.String())
+	resp += fmt.Sprintf("Source Shares: %s\n", d.SharesSrc.String())
+	resp += fmt.Sprintf("Destination Shares: %s", d.SharesDst.String())
+
+	return resp, nil
+}
@@ -13,13 +13,14 @@ const (
 	DefaultCodespace sdk.CodespaceType = 4
 
 	// Gaia errors reserve 200 ~ 299.
-	CodeInvalidValidator CodeType = 201
-	CodeInvalidBond      CodeType = 202
-	CodeInvalidInput     CodeType = 203
-	CodeValidatorJailed  CodeType = 204
-	CodeUnauthorized     CodeType = sdk.CodeUnauthorized
-	CodeInternal         CodeType = sdk.CodeInternal
-	CodeUnknownRequest   CodeType = sdk.CodeUnknownRequest
+	CodeInvalidValidator  CodeType = 201
+	CodeInvalidBond       CodeType = 202
+	CodeInvalidInput      CodeType = 203
+	CodeValidatorJailed   CodeType = 204
+	CodeInvalidDelegation CodeType = 205
+	CodeUnauthorized      CodeType = sdk.CodeUnauthorized
+	CodeInternal          CodeType = sdk.CodeInternal
+	CodeUnknownRequest    CodeType = sdk.CodeUnknownRequest
 )
 
 // NOTE: Don't stringer this, we'll put better messages in later.
@@ -31,6 +32,8 @@ func codeToDefaultMsg(code CodeType) string {
 		return "Invalid Bond"
 	case CodeInvalidInput:
 		return "Invalid Input"
+	case CodeInvalidDelegation:
+		return "Invalid Delegation"
 	case CodeUnauthorized:
 		return "Unauthorized"
 	case CodeInternal:
@@ -99,6 +102,28 @@ func ErrBadShares(codespace sdk.CodespaceType) sdk.Error {
 func ErrBadRemoveValidator(codespace sdk.CodespaceType) sdk.Error {
 	return newError(codespace, CodeInvalidValidator, "Error removing validator")
 }
+func ErrBadRedelegationDst(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeInvalidDelegation, "Redelegation destination validator not found")
+}
+func ErrSelfRedelegation(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeInvalidDelegation, "Cannot redelegate to the same validator")
+}
+func ErrTransitiveRedelegation(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeInvalidDelegation,
+		"Redelegation to this validator already in progress, first redelegation to this validator must complete before next redelegation")
+}
+func ErrExistingRedelegation(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeInvalidDelegation,
+		"Redelegation between these validators already in progress, it must complete before beginning a new one")
+}
+func ErrNoRedelegation(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeInvalidDelegation, "No redelegation found")
+}
+func ErrNotMature(codespace sdk.CodespaceType, operation, descriptor string, required, current int64) sdk.Error {
+	msg := fmt.Sprintf("%v is not mature requires a min %v of %v, currently it is %v",
+		operation, descriptor, required, current)
+	return newError(codespace, CodeUnauthorized, msg)
+}
 
 //----------------------------------------
 
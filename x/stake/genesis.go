@@ -12,6 +12,9 @@ type GenesisState struct {
 	Params     Params       `json:"params"`
 	Validators []Validator  `json:"validators"`
 	Bonds      []Delegation `json:"bonds"`
+
+	UnbondingDelegations []UnbondingDelegation `json:"unbonding_delegations"`
+	Redelegations        []Redelegation        `json:"redelegations"`
 }
 
 func NewGenesisState(pool Pool, params Params, validators []Validator, bonds []Delegation) GenesisState {
@@ -51,6 +54,12 @@ func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
 	for _, bond := range data.Bonds {
 		k.setDelegation(ctx, bond)
 	}
+	for _, ubd := range data.UnbondingDelegations {
+		k.setUnbondingDelegation(ctx, ubd)
+	}
+	for _, red := range data.Redelegations {
+		k.setRedelegation(ctx, red)
+	}
 	k.updateBondedValidatorsFull(ctx, store)
 }
 
@@ -60,12 +69,52 @@ func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
 	params := k.GetParams(ctx)
 	validators := k.getAllValidators(ctx)
 	bonds := k.getAllDelegations(ctx)
+	ubds := k.getAllUnbondingDelegations(ctx)
+	reds := k.getAllRedelegations(ctx)
 	return GenesisState{
-		pool,
-		params,
-		validators,
-		bonds,
+		Pool:                 pool,
+		Params:               params,
+		Validators:           validators,
+		Bonds:                bonds,
+		UnbondingDelegations: ubds,
+		Redelegations:        reds,
+	}
+}
+
+// ForZeroHeight - rebase the state exported at a height for a chain
+// restarting at height zero. Past bond heights become zero while creation
+// heights keep their order, so that unbonding delegations and redelegations
+// are still told apart and slashed like before.
+func (data GenesisState) ForZeroHeight(height int64) GenesisState {
+	validators := make([]Validator, len(data.Validators))
+	for i, validator := range data.Validators {
+		validator.BondHeight -= height
+		if validator.BondHeight < 0 {
+			validator.BondHeight = 0
+		}
+		validators[i] = validator
+	}
+	bonds := make([]Delegation, len(data.Bonds))
+	for i, bond := range data.Bonds {
+		bond.Height -= height
+		if bond.Height < 0 {
+			bond.Height = 0
+		}
+		bonds[i] = bond
+	}
+	ubds := make([]UnbondingDelegation, len(data.UnbondingDelegations))
+	for i, ubd := range data.UnbondingDelegations {
+		ubd.CreationHeight -= height
+		ubds[i] = ubd
+	}
+	reds := make([]Redelegation, len(data.Redelegations))
+	for i, red := range data.Redelegations {
+		red.CreationHeight -= height
+		reds[i] = red
 	}
+	data.Validators, data.Bonds = validators, bonds
+	data.UnbondingDelegations, data.Redelegations = ubds, reds
+	return data
 }
 
 // WriteValidators - output current validator set
@@ -2,6 +2,7 @@ package stake
 
 import (
 	"bytes"
+	"strconv"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	abci "github.com/tendermint/abci/types"
@@ -19,6 +20,10 @@ func NewHandler(k Keeper) sdk.Handler {
 			return handleMsgDelegate(ctx, msg, k)
 		case MsgUnbond:
 			return handleMsgUnbond(ctx, msg, k)
+		case MsgBeginRedelegate:
+			return handleMsgBeginRedelegate(ctx, msg, k)
+		case MsgCompleteRedelegate:
+			return handleMsgCompleteRedelegate(ctx, msg, k)
 		default:
 			return sdk.ErrTxDecode("invalid message parse in staking module").Result()
 		}
@@ -42,6 +47,12 @@ func EndBlocker(ctx sdk.Context, k Keeper) (ValidatorUpdates []abci.Validator) {
 	// reset the intra-transaction counter
 	k.setIntraTxCounter(ctx, 0)
 
+	// release the tokens of all matured unbonding delegations
+	k.completeUnbondings(ctx)
+
+	// apply a maximum number of validators changed through the params store
+	k.updateMaxValidators(ctx)
+
 	// calculate validator set changes
 	ValidatorUpdates = k.getTendermintUpdates(ctx)
 	k.clearTendermintUpdates(ctx)
@@ -147,6 +158,22 @@ func handleMsgDelegate(ctx sdk.Context, msg MsgDelegate, k Keeper) sdk.Result {
 func delegate(ctx sdk.Context, k Keeper, delegatorAddr sdk.Address,
 	bondAmt sdk.Coin, validator Validator) (sdk.Tags, sdk.Error) {
 
+	_, _, err := k.coinKeeper.DelegateCoins(ctx, delegatorAddr, sdk.Coins{bondAmt})
+	if err != nil {
+		return nil, err
+	}
+	bondTokens(ctx, k, delegatorAddr, bondAmt.Amount, validator)
+	tags := sdk.NewTags("action", []byte("delegate"), "delegator", delegatorAddr.Bytes(), "validator", validator.Owner.Bytes())
+	return tags, nil
+}
+
+// add tokens already removed from the delegator to a validator, crediting
+// the delegator with the newly issued shares
+func bondTokens(ctx sdk.Context, k Keeper, delegatorAddr sdk.Address,
+	amount int64, validator Validator) (newShares sdk.Rat) {
+
+	k.beforeDelegationSharesModified(ctx, delegatorAddr, validator.Owner)
+
 	// Get or create the delegator bond
 	bond, found := k.GetDelegation(ctx, delegatorAddr, validator.Owner)
 	if !found {
@@ -159,11 +186,7 @@ func delegate(ctx sdk.Context, k Keeper, delegatorAddr sdk.Address,
 
 	// Account new shares, save
 	pool := k.GetPool(ctx)
-	_, _, err := k.coinKeeper.SubtractCoins(ctx, bond.DelegatorAddr, sdk.Coins{bondAmt})
-	if err != nil {
-		return nil, err
-	}
-	validator, pool, newShares := validator.addTokensFromDel(pool, bondAmt.Amount)
+	validator, pool, newShares = validator.addTokensFromDel(pool, amount)
 	bond.Shares = bond.Shares.Add(newShares)
 
 	// Update bond height
@@ -172,50 +195,36 @@ func delegate(ctx sdk.Context, k Keeper, delegatorAddr sdk.Address,
 	k.setPool(ctx, pool)
 	k.setDelegation(ctx, bond)
 	k.updateValidator(ctx, validator)
-	tags := sdk.NewTags("action", []byte("delegate"), "delegator", delegatorAddr.Bytes(), "validator", validator.Owner.Bytes())
-	return tags, nil
+	return newShares
 }
 
-func handleMsgUnbond(ctx sdk.Context, msg MsgUnbond, k Keeper) sdk.Result {
-
-	// check if bond has any shares in it unbond
-	bond, found := k.GetDelegation(ctx, msg.DelegatorAddr, msg.ValidatorAddr)
-	if !found {
-		return ErrNoDelegatorForAddress(k.codespace).Result()
-	}
-
-	var delShares sdk.Rat
+// get the delegator shares to remove from a bond, either a decimal
+// amount or the keyword MAX for all of the bonds shares
+func getDelShares(k Keeper, bond Delegation, shares string) (delShares sdk.Rat, err sdk.Error) {
 
 	// test that there are enough shares to unbond
-	if msg.Shares == "MAX" {
+	if shares == "MAX" {
 		if !bond.Shares.GT(sdk.ZeroRat()) {
-			return ErrNotEnoughBondShares(k.codespace, msg.Shares).Result()
-		}
-	} else {
-		var err sdk.Error
-		delShares, err = sdk.NewRatFromDecimal(msg.Shares)
-		if err != nil {
-			return err.Result()
-		}
-		if bond.Shares.LT(delShares) {
-			return ErrNotEnoughBondShares(k.codespace, msg.Shares).Result()
+			return delShares, ErrNotEnoughBondShares(k.codespace, shares)
 		}
+		return bond.Shares, nil
 	}
-
-	// get validator
-	validator, found := k.GetValidator(ctx, msg.ValidatorAddr)
-	if !found {
-		return ErrNoValidatorForAddress(k.codespace).Result()
+	delShares, err = sdk.NewRatFromDecimal(shares)
+	if err != nil {
+		return delShares, err
 	}
-
-	if ctx.IsCheckTx() {
-		return sdk.Result{}
+	if bond.Shares.LT(delShares) {
+		return delShares, ErrNotEnoughBondShares(k.codespace, shares)
 	}
+	return delShares, nil
+}
 
-	// retrieve the amount of bonds to remove (TODO remove redundancy already serialized)
-	if msg.Shares == "MAX" {
-		delShares = bond.Shares
-	}
+// remove delegator shares from a bond and its validator, returning the
+// amount of tokens the shares were worth
+func unbondShares(ctx sdk.Context, k Keeper, bond Delegation,
+	delShares sdk.Rat, validator Validator) (returnAmount int64) {
+
+	k.beforeDelegationSharesModified(ctx, bond.DelegatorAddr, bond.ValidatorAddr)
 
 	// subtract bond tokens from delegator bond
 	bond.Shares = bond.Shares.Sub(delShares)
@@ -232,18 +241,17 @@ func handleMsgUnbond(ctx sdk.Context, msg MsgUnbond, k Keeper) sdk.Result {
 		}
 
 		k.removeDelegation(ctx, bond)
+		k.onDelegationRemoved(ctx, bond.DelegatorAddr, bond.ValidatorAddr)
 	} else {
 		// Update bond height
 		bond.Height = ctx.BlockHeight()
 		k.setDelegation(ctx, bond)
 	}
 
-	// Add the coins
+	// remove the tokens from the validator
 	pool := k.GetPool(ctx)
-	validator, pool, returnAmount := validator.removeDelShares(pool, delShares)
+	validator, pool, returnAmount = validator.removeDelShares(pool, delShares)
 	k.setPool(ctx, pool)
-	returnCoins := sdk.Coins{{k.GetParams(ctx).BondDenom, returnAmount}}
-	k.coinKeeper.AddCoins(ctx, bond.DelegatorAddr, returnCoins)
 
 	/////////////////////////////////////
 	// revoke validator if necessary
@@ -256,8 +264,158 @@ func handleMsgUnbond(ctx sdk.Context, msg MsgUnbond, k Keeper) sdk.Result {
 	if validator.DelegatorShares.IsZero() {
 		k.removeValidator(ctx, validator.Owner)
 	}
+	return returnAmount
+}
+
+func handleMsgUnbond(ctx sdk.Context, msg MsgUnbond, k Keeper) sdk.Result {
+
+	// check if bond has any shares in it unbond
+	bond, found := k.GetDelegation(ctx, msg.DelegatorAddr, msg.ValidatorAddr)
+	if !found {
+		return ErrNoDelegatorForAddress(k.codespace).Result()
+	}
+	delShares, err := getDelShares(k, bond, msg.Shares)
+	if err != nil {
+		return err.Result()
+	}
+
+	// get validator
+	validator, found := k.GetValidator(ctx, msg.ValidatorAddr)
+	if !found {
+		return ErrNoValidatorForAddress(k.codespace).Result()
+	}
+
+	if ctx.IsCheckTx() {
+		return sdk.Result{}
+	}
+
+	// the returned tokens are held in an unbonding delegation
+	// until the unbonding period has passed
+	returnAmount := unbondShares(ctx, k, bond, delShares, validator)
+	params := k.GetParams(ctx)
+	returnCoin := sdk.Coin{params.BondDenom, returnAmount}
+
+	// unbondings of the same delegation within a block share a queue entry
+	ubd, found := k.GetUnbondingDelegation(ctx, bond.DelegatorAddr, bond.ValidatorAddr, ctx.BlockHeight())
+	if found {
+		ubd.InitialBalance = ubd.InitialBalance.Plus(returnCoin)
+		ubd.Balance = ubd.Balance.Plus(returnCoin)
+	} else {
+		ubd = UnbondingDelegation{
+			DelegatorAddr:  bond.DelegatorAddr,
+			ValidatorAddr:  bond.ValidatorAddr,
+			CreationHeight: ctx.BlockHeight(),
+			CompletionTime: ctx.BlockHeader().Time + params.UnbondingTime,
+			InitialBalance: returnCoin,
+			Balance:        returnCoin,
+		}
+	}
+	k.setUnbondingDelegation(ctx, ubd)
+
+	tags := sdk.NewTags(
+		"action", []byte("unbond"),
+		"delegator", msg.DelegatorAddr.Bytes(),
+		"validator", msg.ValidatorAddr.Bytes(),
+		"completion-time", []byte(strconv.FormatInt(ubd.CompletionTime, 10)),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+func handleMsgBeginRedelegate(ctx sdk.Context, msg MsgBeginRedelegate, k Keeper) sdk.Result {
+
+	// check if bond has any shares in it to redelegate
+	bond, found := k.GetDelegation(ctx, msg.DelegatorAddr, msg.ValidatorSrcAddr)
+	if !found {
+		return ErrNoDelegatorForAddress(k.codespace).Result()
+	}
+	delShares, err := getDelShares(k, bond, msg.Shares)
+	if err != nil {
+		return err.Result()
+	}
+
+	srcValidator, found := k.GetValidator(ctx, msg.ValidatorSrcAddr)
+	if !found {
+		return ErrNoValidatorForAddress(k.codespace).Result()
+	}
+	dstValidator, found := k.GetValidator(ctx, msg.ValidatorDstAddr)
+	if !found {
+		return ErrBadRedelegationDst(k.codespace).Result()
+	}
+	if dstValidator.Revoked {
+		return ErrValidatorRevoked(k.codespace).Result()
+	}
+
+	// stake which is still slashable for the source validator of a previous
+	// redelegation may not hop onwards until that redelegation has completed
+	if k.HasReceivingRedelegation(ctx, msg.DelegatorAddr, msg.ValidatorSrcAddr) {
+		return ErrTransitiveRedelegation(k.codespace).Result()
+	}
+	if _, found := k.GetRedelegation(ctx, msg.DelegatorAddr, msg.ValidatorSrcAddr, msg.ValidatorDstAddr); found {
+		return ErrExistingRedelegation(k.codespace).Result()
+	}
+
+	if ctx.IsCheckTx() {
+		return sdk.Result{}
+	}
+
+	// move the tokens straight into the destination validator
+	returnAmount := unbondShares(ctx, k, bond, delShares, srcValidator)
+	dstValidator, _ = k.GetValidator(ctx, msg.ValidatorDstAddr) // reload, the source may have kicked it
+	dstShares := bondTokens(ctx, k, msg.DelegatorAddr, returnAmount, dstValidator)
+
+	params := k.GetParams(ctx)
+	returnCoin := sdk.Coin{params.BondDenom, returnAmount}
+	red := Redelegation{
+		DelegatorAddr:    msg.DelegatorAddr,
+		ValidatorSrcAddr: msg.ValidatorSrcAddr,
+		ValidatorDstAddr: msg.ValidatorDstAddr,
+		CreationHeight:   ctx.BlockHeight(),
+		CompletionTime:   ctx.BlockHeader().Time + params.UnbondingTime,
+		InitialBalance:   returnCoin,
+		Balance:          returnCoin,
+		SharesSrc:        delShares,
+		SharesDst:        dstShares,
+	}
+	k.setRedelegation(ctx, red)
+
+	tags := sdk.NewTags(
+		"action", []byte("beginRedelegate"),
+		"delegator", msg.DelegatorAddr.Bytes(),
+		"source-validator", msg.ValidatorSrcAddr.Bytes(),
+		"destination-validator", msg.ValidatorDstAddr.Bytes(),
+		"completion-time", []byte(strconv.FormatInt(red.CompletionTime, 10)),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+func handleMsgCompleteRedelegate(ctx sdk.Context, msg MsgCompleteRedelegate, k Keeper) sdk.Result {
+
+	red, found := k.GetRedelegation(ctx, msg.DelegatorAddr, msg.ValidatorSrcAddr, msg.ValidatorDstAddr)
+	if !found {
+		return ErrNoRedelegation(k.codespace).Result()
+	}
+
+	// ensure that enough time has passed
+	if ctx.BlockHeader().Time < red.CompletionTime {
+		return ErrNotMature(k.codespace, "redelegation", "unix time", red.CompletionTime, ctx.BlockHeader().Time).Result()
+	}
 
-	tags := sdk.NewTags("action", []byte("unbond"), "delegator", msg.DelegatorAddr.Bytes(), "validator", msg.ValidatorAddr.Bytes())
+	if ctx.IsCheckTx() {
+		return sdk.Result{}
+	}
+
+	k.removeRedelegation(ctx, red)
+
+	tags := sdk.NewTags(
+		"action", []byte("completeRedelegate"),
+		"delegator", msg.DelegatorAddr.Bytes(),
+		"source-validator", msg.ValidatorSrcAddr.Bytes(),
+		"destination-validator", msg.ValidatorDstAddr.Bytes(),
+	)
 	return sdk.Result{
 		Tags: tags,
 	}
@@ -7,9 +7,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	abci "github.com/tendermint/abci/types"
 	crypto "github.com/tendermint/go-crypto"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
 )
 
 //______________________________________________________________________
@@ -66,7 +69,7 @@ func TestValidatorByPowerIndex(t *testing.T) {
 	assert.True(t, got.IsOK(), "expected create-validator to be ok, got %v", got)
 
 	// slash and revoke the first validator
-	keeper.Slash(ctx, pks[0], 0, sdk.NewRat(1, 2))
+	keeper.Slash(ctx, pks[0], 0, initBond, sdk.NewRat(1, 2))
 	keeper.Revoke(ctx, pks[0])
 	validator, found = keeper.GetValidator(ctx, validatorAddr)
 	require.True(t, found)
@@ -238,13 +241,18 @@ func TestIncrementsMsgUnbond(t *testing.T) {
 		bond, found := keeper.GetDelegation(ctx, delegatorAddr, validatorAddr)
 		require.True(t, found)
 
+		ubd, found := keeper.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr, ctx.BlockHeight())
+		require.True(t, found)
+
 		expBond := initBond - int64(i+1)*unbondShares
 		expDelegatorShares := 2*initBond - int64(i+1)*unbondShares
-		expDelegatorAcc := initBond - expBond
+		expDelegatorAcc := int64(0) // unbonded tokens are held until the unbonding period passes
+		expUnbonding := initBond - expBond
 
 		gotBond := bond.Shares.Evaluate()
 		gotDelegatorShares := validator.DelegatorShares.Evaluate()
 		gotDelegatorAcc := accMapper.GetAccount(ctx, delegatorAddr).GetCoins().AmountOf(params.BondDenom)
+		gotUnbonding := ubd.Balance.Amount
 
 		require.Equal(t, expBond, gotBond,
 			"i: %v\nexpBond: %v\ngotBond: %v\nvalidator: %v\nbond: %v\n",
@@ -255,6 +263,9 @@ func TestIncrementsMsgUnbond(t *testing.T) {
 		require.Equal(t, expDelegatorAcc, gotDelegatorAcc,
 			"i: %v\nexpDelegatorAcc: %v\ngotDelegatorAcc: %v\nvalidator: %v\nbond: %v\n",
 			i, expDelegatorAcc, gotDelegatorAcc, validator, bond)
+		require.Equal(t, expUnbonding, gotUnbonding,
+			"i: %v\nexpUnbonding: %v\ngotUnbonding: %v\nubd: %v\n",
+			i, expUnbonding, gotUnbonding, ubd)
 	}
 
 	// these are more than we have bonded now
@@ -328,10 +339,19 @@ func TestMultipleMsgCreateValidator(t *testing.T) {
 		_, found = keeper.GetValidator(ctx, validatorAddr)
 		require.False(t, found)
 
-		expBalance := initBond
+		expBalance := initBond - 10 // still unbonding
 		gotBalance := accMapper.GetAccount(ctx, validatorPre.Owner).GetCoins().AmountOf(params.BondDenom)
 		require.Equal(t, expBalance, gotBalance, "expected account to have %d, got %d", expBalance, gotBalance)
 	}
+
+	// complete the unbonding period
+	ctx = ctx.WithBlockHeader(abci.Header{Time: params.UnbondingTime})
+	EndBlocker(ctx, keeper)
+	for _, validatorAddr := range validatorAddrs {
+		expBalance := initBond
+		gotBalance := accMapper.GetAccount(ctx, validatorAddr).GetCoins().AmountOf(params.BondDenom)
+		require.Equal(t, expBalance, gotBalance, "expected account to have %d, got %d", expBalance, gotBalance)
+	}
 }
 
 func TestMultipleMsgDelegate(t *testing.T) {
@@ -402,3 +422,220 @@ func TestRevokeValidator(t *testing.T) {
 	got = handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
 	assert.True(t, got.IsOK(), "expected ok, got %v", got)
 }
+
+func TestUnbondingPeriod(t *testing.T) {
+	initBond := int64(1000)
+	ctx, accMapper, keeper := createTestInput(t, false, initBond)
+	params := keeper.GetParams(ctx)
+	validatorAddr, delegatorAddr := addrs[0], addrs[1]
+
+	// create the validator and delegate to it
+	msgCreateValidator := newTestMsgCreateValidator(validatorAddr, pks[0], 10)
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected create-validator to be ok, got %v", got)
+	msgDelegate := newTestMsgDelegate(delegatorAddr, validatorAddr, 100)
+	got = handleMsgDelegate(ctx, msgDelegate, keeper)
+	require.True(t, got.IsOK(), "expected delegation to be ok, got %v", got)
+
+	// begin unbonding at time 100
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 100})
+	msgUnbond := NewMsgUnbond(delegatorAddr, validatorAddr, "MAX")
+	got = handleMsgUnbond(ctx, msgUnbond, keeper)
+	require.True(t, got.IsOK(), "expected unbond to be ok, got %v", got)
+
+	ubd, found := keeper.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr, ctx.BlockHeight())
+	require.True(t, found)
+	require.Equal(t, 100+params.UnbondingTime, ubd.CompletionTime)
+	require.Equal(t, int64(100), ubd.Balance.Amount)
+	require.Equal(t, 1, len(keeper.GetUnbondingDelegations(ctx, delegatorAddr, 10)))
+	require.Equal(t, 1, len(keeper.GetUnbondingDelegationsFromValidator(ctx, validatorAddr)))
+
+	// the coins are not released before the unbonding period has passed
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 100 + params.UnbondingTime - 1})
+	EndBlocker(ctx, keeper)
+	require.Equal(t, initBond-100, accMapper.GetAccount(ctx, delegatorAddr).GetCoins().AmountOf(params.BondDenom))
+	_, found = keeper.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr, ctx.BlockHeight())
+	require.True(t, found)
+
+	// the coins are released once the unbonding period has passed
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 100 + params.UnbondingTime})
+	EndBlocker(ctx, keeper)
+	require.Equal(t, initBond, accMapper.GetAccount(ctx, delegatorAddr).GetCoins().AmountOf(params.BondDenom))
+	_, found = keeper.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr, ctx.BlockHeight())
+	require.False(t, found)
+	require.Equal(t, 0, len(keeper.GetUnbondingDelegations(ctx, delegatorAddr, 10)))
+	require.Equal(t, 0, len(keeper.GetUnbondingDelegationsFromValidator(ctx, validatorAddr)))
+}
+
+func TestDelegateVestingCoins(t *testing.T) {
+	ctx, accMapper, keeper := createTestInput(t, false, 0)
+	validatorAddr, delegatorAddr := addrs[0], addrs[1]
+	params := keeper.GetParams(ctx)
+
+	// the delegator holds coins which only vest after the unbonding period
+	vestingEnd := 2 * params.UnbondingTime
+	bacc := auth.NewBaseAccountWithAddress(delegatorAddr)
+	bacc.Coins = sdk.Coins{{params.BondDenom, 100}}
+	accMapper.SetAccount(ctx, auth.NewDelayedVestingAccount(bacc, vestingEnd))
+	_, _, err := keeper.coinKeeper.MintCoins(ctx, validatorAddr, sdk.Coins{{params.BondDenom, 10}})
+	require.Nil(t, err)
+
+	// the locked coins may be delegated
+	got := handleMsgCreateValidator(ctx, newTestMsgCreateValidator(validatorAddr, pks[0], 10), keeper)
+	require.True(t, got.IsOK(), "expected create-validator to be ok, got %v", got)
+	got = handleMsgDelegate(ctx, newTestMsgDelegate(delegatorAddr, validatorAddr, 100), keeper)
+	require.True(t, got.IsOK(), "expected delegation to be ok, got %v", got)
+	vacc := accMapper.GetAccount(ctx, delegatorAddr).(auth.VestingAccount)
+	require.True(t, vacc.GetDelegatedVesting().IsEqual(sdk.Coins{{params.BondDenom, 100}}))
+
+	// once unbonded they are locked again until they vest
+	got = handleMsgUnbond(ctx, NewMsgUnbond(delegatorAddr, validatorAddr, "MAX"), keeper)
+	require.True(t, got.IsOK(), "expected unbond to be ok, got %v", got)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: params.UnbondingTime})
+	EndBlocker(ctx, keeper)
+	vacc = accMapper.GetAccount(ctx, delegatorAddr).(auth.VestingAccount)
+	require.Equal(t, int64(100), vacc.GetCoins().AmountOf(params.BondDenom))
+	require.True(t, vacc.GetDelegatedVesting().IsZero())
+	require.True(t, vacc.SpendableCoins(ctx.BlockHeader().Time).IsZero())
+	require.True(t, vacc.SpendableCoins(vestingEnd).IsEqual(sdk.Coins{{params.BondDenom, 100}}))
+}
+
+func TestRedelegation(t *testing.T) {
+	initBond := int64(1000)
+	ctx, _, keeper := createTestInput(t, false, initBond)
+	params := keeper.GetParams(ctx)
+	validatorAddr, validatorAddr2, validatorAddr3, delegatorAddr := addrs[0], addrs[1], addrs[2], addrs[3]
+
+	// create the validators and delegate to the first one
+	for i, addr := range []sdk.Address{validatorAddr, validatorAddr2, validatorAddr3} {
+		msgCreateValidator := newTestMsgCreateValidator(addr, pks[i], 10)
+		got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+		require.True(t, got.IsOK(), "expected create-validator to be ok, got %v", got)
+	}
+	msgDelegate := newTestMsgDelegate(delegatorAddr, validatorAddr, 100)
+	got := handleMsgDelegate(ctx, msgDelegate, keeper)
+	require.True(t, got.IsOK(), "expected delegation to be ok, got %v", got)
+
+	// begin redelegating half the shares at time 100
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 100})
+	msgBeginRedelegate := NewMsgBeginRedelegate(delegatorAddr, validatorAddr, validatorAddr2, "50")
+	got = handleMsgBeginRedelegate(ctx, msgBeginRedelegate, keeper)
+	require.True(t, got.IsOK(), "expected begin-redelegate to be ok, got %v", got)
+
+	red, found := keeper.GetRedelegation(ctx, delegatorAddr, validatorAddr, validatorAddr2)
+	require.True(t, found)
+	require.Equal(t, 100+params.UnbondingTime, red.CompletionTime)
+	require.Equal(t, int64(50), red.Balance.Amount)
+	require.True(t, red.SharesSrc.Equal(sdk.NewRat(50)))
+	require.Equal(t, 1, len(keeper.GetRedelegations(ctx, delegatorAddr, 10)))
+	require.Equal(t, 1, len(keeper.GetRedelegationsFromValidator(ctx, validatorAddr)))
+
+	// the tokens are bonded to the destination straight away
+	bond, found := keeper.GetDelegation(ctx, delegatorAddr, validatorAddr)
+	require.True(t, found)
+	require.True(t, bond.Shares.Equal(sdk.NewRat(50)))
+	bond, found = keeper.GetDelegation(ctx, delegatorAddr, validatorAddr2)
+	require.True(t, found)
+	require.True(t, bond.Shares.Equal(red.SharesDst))
+	validator2, found := keeper.GetValidator(ctx, validatorAddr2)
+	require.True(t, found)
+	require.Equal(t, int64(60), validator2.PoolShares.Bonded().Evaluate())
+
+	// a second redelegation between the same validators is rejected
+	got = handleMsgBeginRedelegate(ctx, msgBeginRedelegate, keeper)
+	require.False(t, got.IsOK(), "expected existing redelegation to be rejected")
+
+	// redelegating the received stake onwards is rejected
+	msgBeginRedelegate = NewMsgBeginRedelegate(delegatorAddr, validatorAddr2, validatorAddr3, "10")
+	got = handleMsgBeginRedelegate(ctx, msgBeginRedelegate, keeper)
+	require.False(t, got.IsOK(), "expected transitive redelegation to be rejected")
+
+	// the redelegation cannot be completed before the unbonding period has passed
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 100 + params.UnbondingTime - 1})
+	msgCompleteRedelegate := NewMsgCompleteRedelegate(delegatorAddr, validatorAddr, validatorAddr2)
+	got = handleMsgCompleteRedelegate(ctx, msgCompleteRedelegate, keeper)
+	require.False(t, got.IsOK(), "expected immature redelegation to be rejected")
+
+	// the redelegation completes once the unbonding period has passed
+	ctx = ctx.WithBlockHeader(abci.Header{Time: 100 + params.UnbondingTime})
+	got = handleMsgCompleteRedelegate(ctx, msgCompleteRedelegate, keeper)
+	require.True(t, got.IsOK(), "expected complete-redelegate to be ok, got %v", got)
+	_, found = keeper.GetRedelegation(ctx, delegatorAddr, validatorAddr, validatorAddr2)
+	require.False(t, found)
+	require.Equal(t, 0, len(keeper.GetRedelegations(ctx, delegatorAddr, 10)))
+
+	// now the received stake may be redelegated onwards
+	got = handleMsgBeginRedelegate(ctx, msgBeginRedelegate, keeper)
+	require.True(t, got.IsOK(), "expected begin-redelegate to be ok, got %v", got)
+}
+
+func TestSlashAtPastHeight(t *testing.T) {
+	ctx, _, keeper := createTestInput(t, false, 1000)
+	validatorAddr, validatorAddr2, delegatorAddr, lateDelegatorAddr := addrs[0], addrs[1], addrs[2], addrs[3]
+	invariant := bank.SupplyInvariant(keeper.coinKeeper, keeper.HeldCoins)
+	supply := keeper.coinKeeper.GetSupply(ctx)
+
+	// at height 1 create the validators, delegate and unbond some stake
+	ctx = ctx.WithBlockHeight(1)
+	got := handleMsgCreateValidator(ctx, newTestMsgCreateValidator(validatorAddr, pks[0], 100), keeper)
+	require.True(t, got.IsOK(), "expected create-validator to be ok, got %v", got)
+	got = handleMsgCreateValidator(ctx, newTestMsgCreateValidator(validatorAddr2, pks[1], 10), keeper)
+	require.True(t, got.IsOK(), "expected create-validator to be ok, got %v", got)
+	got = handleMsgDelegate(ctx, newTestMsgDelegate(delegatorAddr, validatorAddr, 110), keeper)
+	require.True(t, got.IsOK(), "expected delegation to be ok, got %v", got)
+	got = handleMsgUnbond(ctx, NewMsgUnbond(delegatorAddr, validatorAddr, "10"), keeper)
+	require.True(t, got.IsOK(), "expected unbond to be ok, got %v", got)
+
+	// the infraction happens at height 2 when the validator holds 200 tokens,
+	// after which stake unbonds, redelegates away and is newly bonded
+	ctx = ctx.WithBlockHeight(3)
+	got = handleMsgUnbond(ctx, NewMsgUnbond(delegatorAddr, validatorAddr, "50"), keeper)
+	require.True(t, got.IsOK(), "expected unbond to be ok, got %v", got)
+	got = handleMsgBeginRedelegate(ctx, NewMsgBeginRedelegate(delegatorAddr, validatorAddr, validatorAddr2, "20"), keeper)
+	require.True(t, got.IsOK(), "expected begin-redelegate to be ok, got %v", got)
+	ctx = ctx.WithBlockHeight(4)
+	got = handleMsgDelegate(ctx, newTestMsgDelegate(lateDelegatorAddr, validatorAddr, 100), keeper)
+	require.True(t, got.IsOK(), "expected delegation to be ok, got %v", got)
+
+	// slash a tenth of the power held at the infraction height
+	ctx = ctx.WithBlockHeight(5)
+	tags := keeper.Slash(ctx, pks[0], 2, 200, sdk.NewRat(1, 10))
+
+	// the unbonding delegation from before the infraction is untouched
+	ubd, found := keeper.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr, 1)
+	require.True(t, found)
+	require.Equal(t, int64(10), ubd.Balance.Amount)
+
+	// the unbonding delegation and redelegation from after the infraction are slashed
+	ubd, found = keeper.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr, 3)
+	require.True(t, found)
+	require.Equal(t, int64(50), ubd.InitialBalance.Amount)
+	require.Equal(t, int64(45), ubd.Balance.Amount)
+	red, found := keeper.GetRedelegation(ctx, delegatorAddr, validatorAddr, validatorAddr2)
+	require.True(t, found)
+	require.Equal(t, int64(18), red.Balance.Amount)
+	pool := keeper.GetPool(ctx)
+	validator2, found := keeper.GetValidator(ctx, validatorAddr2)
+	require.True(t, found)
+	require.Equal(t, int64(28), validator2.PoolShares.Tokens(pool).Evaluate())
+
+	// the validator only loses the remainder, the late stake does not add to the slash
+	validator, found := keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, found)
+	require.Equal(t, int64(217), validator.PoolShares.Tokens(pool).Evaluate())
+
+	// the tags report how much each slashed party lost
+	expTags := sdk.NewTags(
+		"slashed-unbonding-delegator", delegatorAddr.Bytes(),
+		"slashed-unbonding-tokens", []byte("5"),
+		"slashed-redelegation-delegator", delegatorAddr.Bytes(),
+		"slashed-redelegation-tokens", []byte("2"),
+		"slashed-validator", validatorAddr.Bytes(),
+		"slashed-validator-tokens", []byte("13"),
+	)
+	require.Equal(t, expTags, tags)
+
+	// the slashed tokens are burned from the supply
+	require.True(t, supply.Minus(sdk.Coins{{"steak", 20}}).IsEqual(keeper.coinKeeper.GetSupply(ctx)))
+	require.Nil(t, invariant(ctx))
+}
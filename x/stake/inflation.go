@@ -25,6 +25,8 @@ func (k Keeper) processProvisions(ctx sdk.Context) Pool {
 
 	// TODO add to the fees provisions
 	pool.LooseUnbondedTokens += provisions
+	pool.ProvisionedTokens += provisions
+	k.coinKeeper.InflateSupply(ctx, sdk.Coins{{k.GetParams(ctx).BondDenom, provisions}})
 	return pool
 }
 
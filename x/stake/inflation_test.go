@@ -292,11 +292,13 @@ func updateProvisions(t *testing.T, keeper Keeper, pool Pool, ctx sdk.Context, h
 	expInflation := keeper.nextInflation(ctx)
 	expProvisions := (expInflation.Mul(sdk.NewRat(pool.TokenSupply())).Quo(hrsPerYrRat)).Evaluate()
 	startTotalSupply := pool.TokenSupply()
+	startCoinSupply := keeper.coinKeeper.GetSupply(ctx).AmountOf("steak")
 	pool = keeper.processProvisions(ctx)
 	keeper.setPool(ctx, pool)
 
-	//check provisions were added to pool
+	//check provisions were added to pool and the supply
 	require.Equal(t, startTotalSupply+expProvisions, pool.TokenSupply())
+	require.Equal(t, startCoinSupply+expProvisions, keeper.coinKeeper.GetSupply(ctx).AmountOf("steak"))
 
 	return expInflation, expProvisions, pool
 }
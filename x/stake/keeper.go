@@ -3,34 +3,67 @@ package stake
 import (
 	"bytes"
 	"fmt"
+	"strconv"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	abci "github.com/tendermint/abci/types"
 	crypto "github.com/tendermint/go-crypto"
 )
 
+var _ auth.DelegationKeeper = Keeper{}
+
 // keeper of the staking store
 type Keeper struct {
 	storeKey   sdk.StoreKey
 	cdc        *wire.Codec
 	coinKeeper bank.Keeper
+	paramSpace params.Subspace
+	hooks      sdk.StakingHooks
 
 	// codespace
 	codespace sdk.CodespaceType
 }
 
-func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, codespace sdk.CodespaceType) Keeper {
+func NewKeeper(cdc *wire.Codec, key sdk.StoreKey, ck bank.Keeper, paramSpace params.Subspace,
+	codespace sdk.CodespaceType) Keeper {
+
 	keeper := Keeper{
 		storeKey:   key,
 		cdc:        cdc,
 		coinKeeper: ck,
+		paramSpace: paramSpace.WithParamSet(&Params{}),
 		codespace:  codespace,
 	}
 	return keeper
 }
 
+// set the hooks which are called on changes to delegations
+func (k Keeper) WithHooks(sh sdk.StakingHooks) Keeper {
+	if k.hooks != nil {
+		panic("cannot set staking hooks twice")
+	}
+	k.hooks = sh
+	return k
+}
+
+// notify the hooks, if any, that the shares of a delegation are about to change
+func (k Keeper) beforeDelegationSharesModified(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address) {
+	if k.hooks != nil {
+		k.hooks.BeforeDelegationSharesModified(ctx, delegatorAddr, validatorAddr)
+	}
+}
+
+// notify the hooks, if any, that a delegation has been removed
+func (k Keeper) onDelegationRemoved(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address) {
+	if k.hooks != nil {
+		k.hooks.OnDelegationRemoved(ctx, delegatorAddr, validatorAddr)
+	}
+}
+
 //_________________________________________________________________________
 
 // get a single validator
@@ -593,43 +626,290 @@ func (k Keeper) removeDelegation(ctx sdk.Context, bond Delegation) {
 	store.Delete(GetDelegationKey(bond.DelegatorAddr, bond.ValidatorAddr, k.cdc))
 }
 
-//_______________________________________________________________________
+//_____________________________________________________________________
+
+// load an unbonding delegation
+func (k Keeper) GetUnbondingDelegation(ctx sdk.Context, delegatorAddr, validatorAddr sdk.Address,
+	creationHeight int64) (ubd UnbondingDelegation, found bool) {
 
-// load/save the global staking params
-func (k Keeper) GetParams(ctx sdk.Context) Params {
 	store := ctx.KVStore(k.storeKey)
-	return k.getParams(store)
+	return k.getUnbondingDelegation(store, GetUBDKey(delegatorAddr, validatorAddr, creationHeight, k.cdc))
 }
-func (k Keeper) getParams(store sdk.KVStore) (params Params) {
-	b := store.Get(ParamKey)
-	if b == nil {
-		panic("Stored params should not have been nil")
+
+// load an unbonding delegation by its full key (reuse store)
+func (k Keeper) getUnbondingDelegation(store sdk.KVStore, key []byte) (ubd UnbondingDelegation, found bool) {
+	bz := store.Get(key)
+	if bz == nil {
+		return ubd, false
+	}
+	k.cdc.MustUnmarshalBinary(bz, &ubd)
+	return ubd, true
+}
+
+// load all unbonding delegations of a delegator
+func (k Keeper) GetUnbondingDelegations(ctx sdk.Context, delegator sdk.Address,
+	maxRetrieve int16) (ubds []UnbondingDelegation) {
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetUBDsKey(delegator, k.cdc)) //smallest to largest
+
+	ubds = make([]UnbondingDelegation, maxRetrieve)
+	i := 0
+	for ; ; i++ {
+		if !iterator.Valid() || i > int(maxRetrieve-1) {
+			iterator.Close()
+			break
+		}
+		var ubd UnbondingDelegation
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &ubd)
+		ubds[i] = ubd
+		iterator.Next()
+	}
+	return ubds[:i] // trim
+}
+
+// load all unbonding delegations from a particular validator
+func (k Keeper) GetUnbondingDelegationsFromValidator(ctx sdk.Context, validatorAddr sdk.Address) (ubds []UnbondingDelegation) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetUBDsByValIndexKey(validatorAddr, k.cdc))
+	for ; iterator.Valid(); iterator.Next() {
+		ubd, found := k.getUnbondingDelegation(store, iterator.Value())
+		if !found {
+			panic(fmt.Sprintf("unbonding delegation record not found for index key: %v\n", iterator.Key()))
+		}
+		ubds = append(ubds, ubd)
+	}
+	iterator.Close()
+	return ubds
+}
+
+// load all unbonding delegations used during genesis dump
+func (k Keeper) getAllUnbondingDelegations(ctx sdk.Context) (ubds []UnbondingDelegation) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, UnbondingDelegationKey)
+	for ; iterator.Valid(); iterator.Next() {
+		var ubd UnbondingDelegation
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &ubd)
+		ubds = append(ubds, ubd)
+	}
+	iterator.Close()
+	return ubds
+}
+
+// set the unbonding delegation as well as its validator index and its
+// position within the unbonding queue
+func (k Keeper) setUnbondingDelegation(ctx sdk.Context, ubd UnbondingDelegation) {
+	store := ctx.KVStore(k.storeKey)
+	key := GetUBDKey(ubd.DelegatorAddr, ubd.ValidatorAddr, ubd.CreationHeight, k.cdc)
+	store.Set(key, k.cdc.MustMarshalBinary(ubd))
+	store.Set(GetUBDByValIndexKey(ubd.DelegatorAddr, ubd.ValidatorAddr, ubd.CreationHeight, k.cdc), key)
+	store.Set(GetUnbondingQueueKey(ubd.CompletionTime, ubd.DelegatorAddr, ubd.ValidatorAddr, ubd.CreationHeight, k.cdc), key)
+}
+
+// remove the unbonding delegation, its validator index and its queue entry
+func (k Keeper) removeUnbondingDelegation(ctx sdk.Context, ubd UnbondingDelegation) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetUBDKey(ubd.DelegatorAddr, ubd.ValidatorAddr, ubd.CreationHeight, k.cdc))
+	store.Delete(GetUBDByValIndexKey(ubd.DelegatorAddr, ubd.ValidatorAddr, ubd.CreationHeight, k.cdc))
+	store.Delete(GetUnbondingQueueKey(ubd.CompletionTime, ubd.DelegatorAddr, ubd.ValidatorAddr, ubd.CreationHeight, k.cdc))
+}
+
+// get all unbonding delegations which have matured by the provided time,
+// ordered by completion time
+func (k Keeper) getMatureUnbondingDelegations(ctx sdk.Context, currTime int64) (ubds []UnbondingDelegation) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(UnbondingQueueKey, GetUnbondingQueueTimeKey(currTime+1))
+	for ; iterator.Valid(); iterator.Next() {
+		ubd, found := k.getUnbondingDelegation(store, iterator.Value())
+		if !found {
+			panic(fmt.Sprintf("unbonding delegation record not found for queue key: %v\n", iterator.Key()))
+		}
+		ubds = append(ubds, ubd)
+	}
+	iterator.Close()
+	return ubds
+}
+
+// credit the delegators of all matured unbonding delegations with their
+// remaining balance and remove the entries from the store
+func (k Keeper) completeUnbondings(ctx sdk.Context) {
+	logger := ctx.Logger().With("module", "x/stake")
+	for _, ubd := range k.getMatureUnbondingDelegations(ctx, ctx.BlockHeader().Time) {
+		if ubd.Balance.IsPositive() {
+			_, _, err := k.coinKeeper.UndelegateCoins(ctx, ubd.DelegatorAddr, sdk.Coins{ubd.Balance})
+			if err != nil {
+				panic(err) // adding coins to an account cannot fail
+			}
+		}
+		k.removeUnbondingDelegation(ctx, ubd)
+		logger.Info(fmt.Sprintf("Unbonding of %v from validator %s completed for delegator %s",
+			ubd.Balance, ubd.ValidatorAddr, ubd.DelegatorAddr))
+	}
+}
+
+//_____________________________________________________________________
+
+// load a redelegation
+func (k Keeper) GetRedelegation(ctx sdk.Context, delegatorAddr, validatorSrcAddr,
+	validatorDstAddr sdk.Address) (red Redelegation, found bool) {
+
+	store := ctx.KVStore(k.storeKey)
+	return k.getRedelegation(store, GetREDKey(delegatorAddr, validatorSrcAddr, validatorDstAddr, k.cdc))
+}
+
+// load a redelegation by its full key (reuse store)
+func (k Keeper) getRedelegation(store sdk.KVStore, key []byte) (red Redelegation, found bool) {
+	bz := store.Get(key)
+	if bz == nil {
+		return red, false
 	}
+	k.cdc.MustUnmarshalBinary(bz, &red)
+	return red, true
+}
+
+// load all redelegations of a delegator
+func (k Keeper) GetRedelegations(ctx sdk.Context, delegator sdk.Address,
+	maxRetrieve int16) (reds []Redelegation) {
 
-	k.cdc.MustUnmarshalBinary(b, &params)
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetREDsKey(delegator, k.cdc)) //smallest to largest
+
+	reds = make([]Redelegation, maxRetrieve)
+	i := 0
+	for ; ; i++ {
+		if !iterator.Valid() || i > int(maxRetrieve-1) {
+			iterator.Close()
+			break
+		}
+		var red Redelegation
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &red)
+		reds[i] = red
+		iterator.Next()
+	}
+	return reds[:i] // trim
+}
+
+// load all redelegations redelegating away from a particular validator
+func (k Keeper) GetRedelegationsFromValidator(ctx sdk.Context, validatorSrcAddr sdk.Address) (reds []Redelegation) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetREDsFromValSrcIndexKey(validatorSrcAddr, k.cdc))
+	for ; iterator.Valid(); iterator.Next() {
+		red, found := k.getRedelegation(store, iterator.Value())
+		if !found {
+			panic(fmt.Sprintf("redelegation record not found for index key: %v\n", iterator.Key()))
+		}
+		reds = append(reds, red)
+	}
+	iterator.Close()
+	return reds
+}
+
+// has a redelegation of the delegator towards the validator which has not yet completed
+func (k Keeper) HasReceivingRedelegation(ctx sdk.Context, delegatorAddr, validatorDstAddr sdk.Address) bool {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetREDsByDelToValDstIndexKey(delegatorAddr, validatorDstAddr, k.cdc))
+	found := iterator.Valid()
+	iterator.Close()
+	return found
+}
+
+// has a delegation, unbonding delegation or redelegation of the delegator,
+// implements auth.DelegationKeeper
+func (k Keeper) HasDelegations(ctx sdk.Context, delegator sdk.Address) bool {
+	store := ctx.KVStore(k.storeKey)
+	for _, prefix := range [][]byte{
+		GetDelegationsKey(delegator, k.cdc),
+		GetUBDsKey(delegator, k.cdc),
+		GetREDsKey(delegator, k.cdc),
+	} {
+		iterator := sdk.KVStorePrefixIterator(store, prefix)
+		found := iterator.Valid()
+		iterator.Close()
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// load all redelegations used during genesis dump
+func (k Keeper) getAllRedelegations(ctx sdk.Context) (reds []Redelegation) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, RedelegationKey)
+	for ; iterator.Valid(); iterator.Next() {
+		var red Redelegation
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &red)
+		reds = append(reds, red)
+	}
+	iterator.Close()
+	return reds
+}
+
+// set a redelegation and its source and destination validator indexes
+func (k Keeper) setRedelegation(ctx sdk.Context, red Redelegation) {
+	store := ctx.KVStore(k.storeKey)
+	key := GetREDKey(red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr, k.cdc)
+	store.Set(key, k.cdc.MustMarshalBinary(red))
+	store.Set(GetREDByValSrcIndexKey(red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr, k.cdc), key)
+	store.Set(GetREDByValDstIndexKey(red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr, k.cdc), key)
+}
+
+// remove a redelegation and its indexes
+func (k Keeper) removeRedelegation(ctx sdk.Context, red Redelegation) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetREDKey(red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr, k.cdc))
+	store.Delete(GetREDByValSrcIndexKey(red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr, k.cdc))
+	store.Delete(GetREDByValDstIndexKey(red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr, k.cdc))
+}
+
+//_______________________________________________________________________
+
+// load/save the global staking params
+func (k Keeper) GetParams(ctx sdk.Context) (params Params) {
+	k.paramSpace.GetParamSet(ctx, &params)
 	return
 }
 
-// Need a distinct function because setParams depends on an existing previous
-// record of params to exist (to check if maxValidators has changed) - and we
-// panic on retrieval if it doesn't exist - hence if we use setParams for the very
-// first params set it will panic.
+// Need a distinct function because setParams compares the new maximum number
+// of validators against the one the bonded set was last computed with, which
+// is not recorded yet for the very first params set.
 func (k Keeper) setNewParams(ctx sdk.Context, params Params) {
-	store := ctx.KVStore(k.storeKey)
-	b := k.cdc.MustMarshalBinary(params)
-	store.Set(ParamKey, b)
+	k.paramSpace.SetParamSet(ctx, &params)
+	k.setLastMaxValidators(ctx, params.MaxValidators)
 }
 
 func (k Keeper) setParams(ctx sdk.Context, params Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+	k.updateMaxValidators(ctx)
+}
+
+// if max validator count changed, which may also happen by governance
+// changing the params store directly, the validator set must be recalculated
+func (k Keeper) updateMaxValidators(ctx sdk.Context) {
 	store := ctx.KVStore(k.storeKey)
-	exParams := k.getParams(store)
+	maxValidators := k.GetParams(ctx).MaxValidators
+	if maxValidators == k.getLastMaxValidators(ctx) {
+		return
+	}
+	k.setLastMaxValidators(ctx, maxValidators)
+	k.updateBondedValidatorsFull(ctx, store)
+}
 
-	// if max validator count changes, must recalculate validator set
-	if exParams.MaxValidators != params.MaxValidators {
-		k.updateBondedValidatorsFull(ctx, store)
+// the maximum number of validators the bonded validator set was last computed with
+func (k Keeper) getLastMaxValidators(ctx sdk.Context) (maxValidators uint16) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(LastMaxValidatorsKey)
+	if b == nil {
+		panic("Stored last max validators should not have been nil")
 	}
-	b := k.cdc.MustMarshalBinary(params)
-	store.Set(ParamKey, b)
+	k.cdc.MustUnmarshalBinary(b, &maxValidators)
+	return
+}
+
+func (k Keeper) setLastMaxValidators(ctx sdk.Context, maxValidators uint16) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(maxValidators)
+	store.Set(LastMaxValidatorsKey, b)
 }
 
 //_______________________________________________________________________
@@ -654,6 +934,20 @@ func (k Keeper) setPool(ctx sdk.Context, pool Pool) {
 	store.Set(PoolKey, b)
 }
 
+// HeldCoins returns the tokens held by the staking module on behalf of the
+// delegators, including the undistributed inflation provisions
+func (k Keeper) HeldCoins(ctx sdk.Context) sdk.Coins {
+	pool := k.GetPool(ctx)
+	held := pool.BondedTokens + pool.UnbondingTokens + pool.UnbondedTokens + pool.ProvisionedTokens
+	for _, ubd := range k.getAllUnbondingDelegations(ctx) {
+		held += ubd.Balance.Amount
+	}
+	if held == 0 {
+		return sdk.Coins{}
+	}
+	return sdk.Coins{{k.GetParams(ctx).BondDenom, held}}
+}
+
 //__________________________________________________________________________
 
 // get the current in-block validator operation counter
@@ -768,7 +1062,7 @@ func (k Keeper) TotalPower(ctx sdk.Context) sdk.Rat {
 
 // Implements DelegationSet
 
-var _ sdk.ValidatorSet = Keeper{}
+var _ sdk.DelegationSet = Keeper{}
 
 // get the delegation for a particular set of delegator and validator addresses
 func (k Keeper) Delegation(ctx sdk.Context, addrDel sdk.Address, addrVal sdk.Address) sdk.Delegation {
@@ -798,21 +1092,157 @@ func (k Keeper) IterateDelegators(ctx sdk.Context, delAddr sdk.Address, fn func(
 	iterator.Close()
 }
 
-// slash a validator
-func (k Keeper) Slash(ctx sdk.Context, pubkey crypto.PubKey, height int64, fraction sdk.Rat) {
-	// TODO height ignored for now, see https://github.com/cosmos/cosmos-sdk/pull/1011#issuecomment-390253957
+// slash a validator for an infraction committed at the provided height,
+// while it held the provided power. Only stake which contributed to that
+// power is slashed: unbonding delegations and redelegations created at or
+// after the infraction height are slashed alongside the validator, while the
+// stake which unbonded from it beforehand escapes. Stake bonded after the
+// infraction is accounted for by burning no more than the fraction of the
+// power held at the time of the infraction.
+//
+// The returned tags pair each slashed party with the tokens it lost.
+func (k Keeper) Slash(ctx sdk.Context, pubkey crypto.PubKey, infractionHeight int64,
+	power int64, fraction sdk.Rat) (tags sdk.Tags) {
+
 	logger := ctx.Logger().With("module", "x/stake")
-	val, found := k.GetValidatorByPubKey(ctx, pubkey)
+	if fraction.LT(sdk.ZeroRat()) {
+		panic(fmt.Errorf("Attempted to slash with a negative fraction: %v", fraction))
+	}
+	if infractionHeight > ctx.BlockHeight() {
+		panic(fmt.Errorf("Attempted to slash an infraction at future height %d, current height is %d",
+			infractionHeight, ctx.BlockHeight()))
+	}
+
+	validator, found := k.GetValidatorByPubKey(ctx, pubkey)
 	if !found {
-		panic(fmt.Errorf("Attempted to slash a nonexistent validator with address %s", pubkey.Address()))
+		// the validator may have fully unbonded and been removed since the infraction
+		logger.Info(fmt.Sprintf("Ignored attempt to slash a nonexistent validator with address %s", pubkey.Address()))
+		return tags
 	}
-	sharesToRemove := val.PoolShares.Amount.Mul(fraction)
+
+	// the amount of tokens the validator was responsible for at the infraction height
+	slashAmount := k.GetPool(ctx).bondedShareExRate().Mul(sdk.NewRat(power)).Mul(fraction)
+	remainingSlashAmount := slashAmount
+	totalBurned := int64(0)
+
+	// stake which has left the validator since the infraction is still slashable
+	if infractionHeight < ctx.BlockHeight() {
+		for _, ubd := range k.GetUnbondingDelegationsFromValidator(ctx, validator.Owner) {
+			slashed := k.slashUnbondingDelegation(ctx, ubd, infractionHeight, fraction)
+			if slashed == 0 {
+				continue
+			}
+			remainingSlashAmount = remainingSlashAmount.Sub(sdk.NewRat(slashed))
+			totalBurned += slashed
+			tags = tags.AppendTags(sdk.NewTags(
+				"slashed-unbonding-delegator", ubd.DelegatorAddr.Bytes(),
+				"slashed-unbonding-tokens", []byte(strconv.FormatInt(slashed, 10)),
+			))
+		}
+		for _, red := range k.GetRedelegationsFromValidator(ctx, validator.Owner) {
+			slashed := k.slashRedelegation(ctx, red, infractionHeight, fraction)
+			if slashed == 0 {
+				continue
+			}
+			remainingSlashAmount = remainingSlashAmount.Sub(sdk.NewRat(slashed))
+			totalBurned += slashed
+			tags = tags.AppendTags(sdk.NewTags(
+				"slashed-redelegation-delegator", red.DelegatorAddr.Bytes(),
+				"slashed-redelegation-tokens", []byte(strconv.FormatInt(slashed, 10)),
+			))
+		}
+	}
+
+	// burn the remainder from the validator, which cannot lose more than it holds
 	pool := k.GetPool(ctx)
-	val, pool, burned := val.removePoolShares(pool, sharesToRemove)
-	k.setPool(ctx, pool)        // update the pool
-	k.updateValidator(ctx, val) // update the validator, possibly kicking it out
-	logger.Info(fmt.Sprintf("Validator %s slashed by fraction %v, removed %v shares and burned %d tokens", pubkey.Address(), fraction, sharesToRemove, burned))
-	return
+	validator, found = k.GetValidatorByPubKey(ctx, pubkey) // reload, redelegations may have changed the pool
+	if !found {
+		panic(fmt.Errorf("Validator with address %s removed while being slashed", pubkey.Address()))
+	}
+	sharesToRemove := sdk.ZeroRat()
+	validatorTokens := validator.PoolShares.Tokens(pool)
+	if remainingSlashAmount.GT(sdk.ZeroRat()) && validatorTokens.GT(sdk.ZeroRat()) {
+		sharesToRemove = validator.PoolShares.Amount
+		if remainingSlashAmount.LT(validatorTokens) {
+			sharesToRemove = sharesToRemove.Mul(remainingSlashAmount).Quo(validatorTokens)
+		}
+	}
+	validator, pool, burned := validator.removePoolShares(pool, sharesToRemove)
+	k.setPool(ctx, pool)              // update the pool
+	k.updateValidator(ctx, validator) // update the validator, possibly kicking it out
+	totalBurned += burned
+	k.coinKeeper.DeflateSupply(ctx, sdk.Coins{{k.GetParams(ctx).BondDenom, totalBurned}})
+	tags = tags.AppendTags(sdk.NewTags(
+		"slashed-validator", validator.Owner.Bytes(),
+		"slashed-validator-tokens", []byte(strconv.FormatInt(burned, 10)),
+	))
+
+	logger.Info(fmt.Sprintf("Validator %s slashed by fraction %v for an infraction at height %d, "+
+		"removed %v shares and burned %d tokens", pubkey.Address(), fraction, infractionHeight, sharesToRemove, burned))
+	return tags
+}
+
+// slash an unbonding delegation which was created at or after the
+// infraction height, returning the amount of tokens burned
+func (k Keeper) slashUnbondingDelegation(ctx sdk.Context, ubd UnbondingDelegation,
+	infractionHeight int64, fraction sdk.Rat) (slashed int64) {
+
+	// the stake unbonded before the infraction or is no longer slashable
+	if ubd.CreationHeight < infractionHeight || ctx.BlockHeader().Time >= ubd.CompletionTime {
+		return 0
+	}
+
+	// slash proportionally to the initial balance, the remaining balance
+	// may already have been reduced by an earlier slash
+	slashed = sdk.NewRat(ubd.InitialBalance.Amount).Mul(fraction).Evaluate()
+	if slashed > ubd.Balance.Amount {
+		slashed = ubd.Balance.Amount
+	}
+
+	// the balance of an unbonding delegation is no longer part of the pool,
+	// so reducing it burns the tokens
+	ubd.Balance.Amount -= slashed
+	k.setUnbondingDelegation(ctx, ubd)
+	return slashed
+}
+
+// slash a redelegation which was created at or after the infraction height by
+// unbonding and burning the corresponding stake from the destination
+// validator, returning the amount of tokens burned
+func (k Keeper) slashRedelegation(ctx sdk.Context, red Redelegation,
+	infractionHeight int64, fraction sdk.Rat) (slashed int64) {
+
+	// the stake was redelegated before the infraction or is no longer slashable
+	if red.CreationHeight < infractionHeight || ctx.BlockHeader().Time >= red.CompletionTime {
+		return 0
+	}
+
+	// the delegator may since have unbonded part of the redelegated stake
+	delegation, found := k.GetDelegation(ctx, red.DelegatorAddr, red.ValidatorDstAddr)
+	if !found {
+		return 0
+	}
+	dstValidator, found := k.GetValidator(ctx, red.ValidatorDstAddr)
+	if !found {
+		return 0
+	}
+	sharesToUnbond := red.SharesDst.Mul(fraction)
+	if sharesToUnbond.GT(delegation.Shares) {
+		sharesToUnbond = delegation.Shares
+	}
+	if sharesToUnbond.IsZero() {
+		return 0
+	}
+
+	// the unbonded tokens are not returned to anyone, burning them
+	slashed = unbondShares(ctx, k, delegation, sharesToUnbond, dstValidator)
+
+	red.Balance.Amount -= slashed
+	if red.Balance.Amount < 0 {
+		red.Balance.Amount = 0
+	}
+	k.setRedelegation(ctx, red)
+	return slashed
 }
 
 // revoke a validator
@@ -13,17 +13,23 @@ import (
 //nolint
 var (
 	// Keys for store prefixes
-	ParamKey                   = []byte{0x00} // key for parameters relating to staking
-	PoolKey                    = []byte{0x01} // key for the staking pools
-	ValidatorsKey              = []byte{0x02} // prefix for each key to a validator
-	ValidatorsByPubKeyIndexKey = []byte{0x03} // prefix for each key to a validator by pubkey
-	ValidatorsBondedKey        = []byte{0x04} // prefix for each key to bonded/actively validating validators
-	ValidatorsByPowerKey       = []byte{0x05} // prefix for each key to a validator sorted by power
-	ValidatorCliffKey          = []byte{0x06} // key for block-local tx index
-	ValidatorPowerCliffKey     = []byte{0x07} // key for block-local tx index
-	TendermintUpdatesKey       = []byte{0x08} // prefix for each key to a validator which is being updated
-	DelegationKey              = []byte{0x09} // prefix for each key to a delegator's bond
-	IntraTxCounterKey          = []byte{0x10} // key for block-local tx index
+	LastMaxValidatorsKey             = []byte{0x00} // key for the max validators the bonded set was last computed with
+	PoolKey                          = []byte{0x01} // key for the staking pools
+	ValidatorsKey                    = []byte{0x02} // prefix for each key to a validator
+	ValidatorsByPubKeyIndexKey       = []byte{0x03} // prefix for each key to a validator by pubkey
+	ValidatorsBondedKey              = []byte{0x04} // prefix for each key to bonded/actively validating validators
+	ValidatorsByPowerKey             = []byte{0x05} // prefix for each key to a validator sorted by power
+	ValidatorCliffKey                = []byte{0x06} // key for block-local tx index
+	ValidatorPowerCliffKey           = []byte{0x07} // key for block-local tx index
+	TendermintUpdatesKey             = []byte{0x08} // prefix for each key to a validator which is being updated
+	DelegationKey                    = []byte{0x09} // prefix for each key to a delegator's bond
+	IntraTxCounterKey                = []byte{0x10} // key for block-local tx index
+	UnbondingDelegationKey           = []byte{0x11} // prefix for each key to an unbonding-delegation
+	UnbondingDelegationByValIndexKey = []byte{0x12} // prefix for each key to an unbonding-delegation, by validator owner
+	UnbondingQueueKey                = []byte{0x13} // prefix for the timestamps in unbonding queue
+	RedelegationKey                  = []byte{0x14} // prefix for each key to a redelegation
+	RedelegationByValSrcIndexKey     = []byte{0x15} // prefix for each key to a redelegation, by source validator owner
+	RedelegationByValDstIndexKey     = []byte{0x16} // prefix for each key to a redelegation, by destination validator owner
 )
 
 const maxDigitsForAccount = 12 // ~220,000,000 atoms created at launch
@@ -87,3 +93,113 @@ func GetDelegationsKey(delegatorAddr sdk.Address, cdc *wire.Codec) []byte {
 	}
 	return append(DelegationKey, res...)
 }
+
+//______________________________________________________________________________
+
+// get the key for an unbonding delegation by delegator and validator addr
+// created at a particular height
+func GetUBDKey(delegatorAddr, validatorAddr sdk.Address, creationHeight int64, cdc *wire.Codec) []byte {
+	return append(GetUBDKeyPrefix(delegatorAddr, validatorAddr, cdc), bigEndianBytes(creationHeight)...)
+}
+
+// get the prefix for all unbonding delegations between a delegator and a validator
+func GetUBDKeyPrefix(delegatorAddr, validatorAddr sdk.Address, cdc *wire.Codec) []byte {
+	return append(GetUBDsKey(delegatorAddr, cdc), validatorAddr.Bytes()...)
+}
+
+// get the prefix for all unbonding delegations from a delegator
+func GetUBDsKey(delegatorAddr sdk.Address, cdc *wire.Codec) []byte {
+	res, err := cdc.MarshalBinary(&delegatorAddr)
+	if err != nil {
+		panic(err)
+	}
+	return append(UnbondingDelegationKey, res...)
+}
+
+// get the index-key for an unbonding delegation, stored by validator-index
+func GetUBDByValIndexKey(delegatorAddr, validatorAddr sdk.Address, creationHeight int64, cdc *wire.Codec) []byte {
+	return append(append(GetUBDsByValIndexKey(validatorAddr, cdc), delegatorAddr.Bytes()...),
+		bigEndianBytes(creationHeight)...)
+}
+
+// get the prefix keyspace for the indexes of unbonding delegations for a validator
+func GetUBDsByValIndexKey(validatorAddr sdk.Address, cdc *wire.Codec) []byte {
+	res, err := cdc.MarshalBinary(&validatorAddr)
+	if err != nil {
+		panic(err)
+	}
+	return append(UnbondingDelegationByValIndexKey, res...)
+}
+
+// get the key for an entry of the unbonding queue, sorted by completion time
+func GetUnbondingQueueKey(completionTime int64, delegatorAddr, validatorAddr sdk.Address,
+	creationHeight int64, cdc *wire.Codec) []byte {
+
+	return append(GetUnbondingQueueTimeKey(completionTime),
+		GetUBDKey(delegatorAddr, validatorAddr, creationHeight, cdc)...)
+}
+
+// get the prefix for all unbonding queue entries completing at a given time
+func GetUnbondingQueueTimeKey(completionTime int64) []byte {
+	return append(UnbondingQueueKey, bigEndianBytes(completionTime)...)
+}
+
+//______________________________________________________________________________
+
+// get the key for a redelegation
+func GetREDKey(delegatorAddr, validatorSrcAddr, validatorDstAddr sdk.Address, cdc *wire.Codec) []byte {
+	return append(append(GetREDsKey(delegatorAddr, cdc), validatorSrcAddr.Bytes()...),
+		validatorDstAddr.Bytes()...)
+}
+
+// get the prefix for all redelegations from a delegator
+func GetREDsKey(delegatorAddr sdk.Address, cdc *wire.Codec) []byte {
+	res, err := cdc.MarshalBinary(&delegatorAddr)
+	if err != nil {
+		panic(err)
+	}
+	return append(RedelegationKey, res...)
+}
+
+// get the index-key for a redelegation, stored by source-validator-index
+func GetREDByValSrcIndexKey(delegatorAddr, validatorSrcAddr, validatorDstAddr sdk.Address, cdc *wire.Codec) []byte {
+	return append(append(GetREDsFromValSrcIndexKey(validatorSrcAddr, cdc), delegatorAddr.Bytes()...),
+		validatorDstAddr.Bytes()...)
+}
+
+// get the prefix keyspace for all redelegations redelegating away from a source validator
+func GetREDsFromValSrcIndexKey(validatorSrcAddr sdk.Address, cdc *wire.Codec) []byte {
+	res, err := cdc.MarshalBinary(&validatorSrcAddr)
+	if err != nil {
+		panic(err)
+	}
+	return append(RedelegationByValSrcIndexKey, res...)
+}
+
+// get the index-key for a redelegation, stored by destination-validator-index
+func GetREDByValDstIndexKey(delegatorAddr, validatorSrcAddr, validatorDstAddr sdk.Address, cdc *wire.Codec) []byte {
+	return append(append(GetREDsToValDstIndexKey(validatorDstAddr, cdc), delegatorAddr.Bytes()...),
+		validatorSrcAddr.Bytes()...)
+}
+
+// get the prefix keyspace for all redelegations redelegating towards a destination validator
+func GetREDsToValDstIndexKey(validatorDstAddr sdk.Address, cdc *wire.Codec) []byte {
+	res, err := cdc.MarshalBinary(&validatorDstAddr)
+	if err != nil {
+		panic(err)
+	}
+	return append(RedelegationByValDstIndexKey, res...)
+}
+
+// get the prefix keyspace for all redelegations of a delegator redelegating
+// towards a destination validator
+func GetREDsByDelToValDstIndexKey(delegatorAddr, validatorDstAddr sdk.Address, cdc *wire.Codec) []byte {
+	return append(GetREDsToValDstIndexKey(validatorDstAddr, cdc), delegatorAddr.Bytes()...)
+}
+
+// big-endian encoding of a non-negative int64 so keys sort chronologically
+func bigEndianBytes(i int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(i))
+	return bz
+}
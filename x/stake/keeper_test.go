@@ -664,6 +664,29 @@ func TestGetTendermintUpdatesNotValidatorCliff(t *testing.T) {
 	require.Equal(t, validators[2].abciValidator(keeper.cdc), updates[1])
 }
 
+func TestMaxValidatorsChangedInParamsStore(t *testing.T) {
+	ctx, _, keeper := createTestInput(t, false, 0)
+
+	amts := []int64{10, 20, 5}
+	for i, amt := range amts {
+		validator := NewValidator(addrs[i], pks[i], Description{})
+		validator.PoolShares = NewUnbondedShares(sdk.NewRat(amt))
+		validator.DelegatorShares = sdk.NewRat(amt)
+		keeper.updateValidator(ctx, validator)
+	}
+	require.Equal(t, 3, len(keeper.GetValidatorsBonded(ctx)))
+
+	// a change written directly to the params store, as done by governance,
+	// is applied to the validator set at the end of the block
+	keeper.paramSpace.Set(ctx, KeyMaxValidators, uint16(2))
+	EndBlocker(ctx, keeper)
+	bonded := keeper.GetValidatorsBonded(ctx)
+	require.Equal(t, 2, len(bonded))
+	for _, validator := range bonded {
+		require.NotEqual(t, addrs[2], validator.Owner)
+	}
+}
+
 // tests GetDelegation, GetDelegations, SetDelegation, removeDelegation, GetBonds
 func TestBond(t *testing.T) {
 	ctx, _, keeper := createTestInput(t, false, 0)
@@ -689,9 +712,11 @@ func TestBond(t *testing.T) {
 	// check the empty keeper first
 	_, found := keeper.GetDelegation(ctx, addrDels[0], addrVals[0])
 	assert.False(t, found)
+	assert.False(t, keeper.HasDelegations(ctx, addrDels[0]))
 
 	// set and retrieve a record
 	keeper.setDelegation(ctx, bond1to1)
+	assert.True(t, keeper.HasDelegations(ctx, addrDels[0]))
 	resBond, found := keeper.GetDelegation(ctx, addrDels[0], addrVals[0])
 	assert.True(t, found)
 	assert.True(t, bond1to1.equal(resBond))
@@ -759,6 +784,7 @@ func TestBond(t *testing.T) {
 	assert.False(t, found)
 	resBonds = keeper.GetDelegations(ctx, addrDels[1], 5)
 	require.Equal(t, 0, len(resBonds))
+	assert.False(t, keeper.HasDelegations(ctx, addrDels[1]))
 }
 
 func TestParams(t *testing.T) {
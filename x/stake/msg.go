@@ -1,6 +1,8 @@
 package stake
 
 import (
+	"bytes"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	crypto "github.com/tendermint/go-crypto"
 )
@@ -16,6 +18,7 @@ const StakingToken = "steak"
 
 //Verify interface at compile time
 var _, _, _, _ sdk.Msg = &MsgCreateValidator{}, &MsgEditValidator{}, &MsgDelegate{}, &MsgUnbond{}
+var _, _ sdk.Msg = &MsgBeginRedelegate{}, &MsgCompleteRedelegate{}
 
 //______________________________________________________________________
 
@@ -230,8 +233,13 @@ func (msg MsgUnbond) ValidateBasic() sdk.Error {
 	if msg.ValidatorAddr == nil {
 		return ErrBadValidatorAddr(DefaultCodespace)
 	}
-	if msg.Shares != "MAX" {
-		rat, err := sdk.NewRatFromDecimal(msg.Shares)
+	return validateShares(msg.Shares)
+}
+
+// shares must either be the keyword MAX or a positive decimal
+func validateShares(shares string) sdk.Error {
+	if shares != "MAX" {
+		rat, err := sdk.NewRatFromDecimal(shares)
 		if err != nil {
 			return ErrBadShares(DefaultCodespace)
 		}
@@ -241,3 +249,113 @@ func (msg MsgUnbond) ValidateBasic() sdk.Error {
 	}
 	return nil
 }
+
+//______________________________________________________________________
+
+// MsgBeginRedelegate - struct for moving delegator shares from one validator to another
+type MsgBeginRedelegate struct {
+	DelegatorAddr    sdk.Address `json:"delegator_addr"`
+	ValidatorSrcAddr sdk.Address `json:"validator_src_addr"`
+	ValidatorDstAddr sdk.Address `json:"validator_dst_addr"`
+	Shares           string      `json:"shares"`
+}
+
+func NewMsgBeginRedelegate(delegatorAddr, validatorSrcAddr,
+	validatorDstAddr sdk.Address, shares string) MsgBeginRedelegate {
+
+	return MsgBeginRedelegate{
+		DelegatorAddr:    delegatorAddr,
+		ValidatorSrcAddr: validatorSrcAddr,
+		ValidatorDstAddr: validatorDstAddr,
+		Shares:           shares,
+	}
+}
+
+//nolint
+func (msg MsgBeginRedelegate) Type() string              { return MsgType }
+func (msg MsgBeginRedelegate) GetSigners() []sdk.Address { return []sdk.Address{msg.DelegatorAddr} }
+
+// get the bytes for the message signer to sign on
+func (msg MsgBeginRedelegate) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		DelegatorAddr    string `json:"delegator_addr"`
+		ValidatorSrcAddr string `json:"validator_src_addr"`
+		ValidatorDstAddr string `json:"validator_dst_addr"`
+		Shares           string `json:"shares"`
+	}{
+		DelegatorAddr:    sdk.MustBech32ifyAcc(msg.DelegatorAddr),
+		ValidatorSrcAddr: sdk.MustBech32ifyVal(msg.ValidatorSrcAddr),
+		ValidatorDstAddr: sdk.MustBech32ifyVal(msg.ValidatorDstAddr),
+		Shares:           msg.Shares,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check
+func (msg MsgBeginRedelegate) ValidateBasic() sdk.Error {
+	if msg.DelegatorAddr == nil {
+		return ErrBadDelegatorAddr(DefaultCodespace)
+	}
+	if msg.ValidatorSrcAddr == nil || msg.ValidatorDstAddr == nil {
+		return ErrBadValidatorAddr(DefaultCodespace)
+	}
+	if bytes.Equal(msg.ValidatorSrcAddr, msg.ValidatorDstAddr) {
+		return ErrSelfRedelegation(DefaultCodespace)
+	}
+	return validateShares(msg.Shares)
+}
+
+//______________________________________________________________________
+
+// MsgCompleteRedelegate - struct for completing a redelegation once its
+// unbonding period has passed
+type MsgCompleteRedelegate struct {
+	DelegatorAddr    sdk.Address `json:"delegator_addr"`
+	ValidatorSrcAddr sdk.Address `json:"validator_src_addr"`
+	ValidatorDstAddr sdk.Address `json:"validator_dst_addr"`
+}
+
+func NewMsgCompleteRedelegate(delegatorAddr, validatorSrcAddr,
+	validatorDstAddr sdk.Address) MsgCompleteRedelegate {
+
+	return MsgCompleteRedelegate{
+		DelegatorAddr:    delegatorAddr,
+		ValidatorSrcAddr: validatorSrcAddr,
+		ValidatorDstAddr: validatorDstAddr,
+	}
+}
+
+//nolint
+func (msg MsgCompleteRedelegate) Type() string              { return MsgType }
+func (msg MsgCompleteRedelegate) GetSigners() []sdk.Address { return []sdk.Address{msg.DelegatorAddr} }
+
+// get the bytes for the message signer to sign on
+func (msg MsgCompleteRedelegate) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		DelegatorAddr    string `json:"delegator_addr"`
+		ValidatorSrcAddr string `json:"validator_src_addr"`
+		ValidatorDstAddr string `json:"validator_dst_addr"`
+	}{
+		DelegatorAddr:    sdk.MustBech32ifyAcc(msg.DelegatorAddr),
+		ValidatorSrcAddr: sdk.MustBech32ifyVal(msg.ValidatorSrcAddr),
+		ValidatorDstAddr: sdk.MustBech32ifyVal(msg.ValidatorDstAddr),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quick validity check
+func (msg MsgCompleteRedelegate) ValidateBasic() sdk.Error {
+	if msg.DelegatorAddr == nil {
+		return ErrBadDelegatorAddr(DefaultCodespace)
+	}
+	if msg.ValidatorSrcAddr == nil || msg.ValidatorDstAddr == nil {
+		return ErrBadValidatorAddr(DefaultCodespace)
+	}
+	return nil
+}
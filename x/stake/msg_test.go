@@ -154,3 +154,57 @@ func TestMsgUnbond(t *testing.T) {
 //}
 //}
 //}
+
+func TestMsgBeginRedelegate(t *testing.T) {
+	tests := []struct {
+		name             string
+		delegatorAddr    sdk.Address
+		validatorSrcAddr sdk.Address
+		validatorDstAddr sdk.Address
+		shares           string
+		expectPass       bool
+	}{
+		{"max redelegate", addrs[0], addrs[1], addrs[2], "MAX", true},
+		{"decimal redelegate", addrs[0], addrs[1], addrs[2], "0.1", true},
+		{"negative decimal redelegate", addrs[0], addrs[1], addrs[2], "-0.1", false},
+		{"zero redelegate", addrs[0], addrs[1], addrs[2], "0.0", false},
+		{"invalid decimal", addrs[0], addrs[1], addrs[2], "sunny", false},
+		{"self redelegate", addrs[0], addrs[1], addrs[1], "0.1", false},
+		{"empty delegator", emptyAddr, addrs[1], addrs[2], "0.1", false},
+		{"empty source validator", addrs[0], emptyAddr, addrs[2], "0.1", false},
+		{"empty destination validator", addrs[0], addrs[1], emptyAddr, "0.1", false},
+	}
+
+	for _, tc := range tests {
+		msg := NewMsgBeginRedelegate(tc.delegatorAddr, tc.validatorSrcAddr, tc.validatorDstAddr, tc.shares)
+		if tc.expectPass {
+			assert.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		} else {
+			assert.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
+
+func TestMsgCompleteRedelegate(t *testing.T) {
+	tests := []struct {
+		name             string
+		delegatorAddr    sdk.Address
+		validatorSrcAddr sdk.Address
+		validatorDstAddr sdk.Address
+		expectPass       bool
+	}{
+		{"regular", addrs[0], addrs[1], addrs[2], true},
+		{"empty delegator", emptyAddr, addrs[1], addrs[2], false},
+		{"empty source validator", addrs[0], emptyAddr, addrs[2], false},
+		{"empty destination validator", addrs[0], addrs[1], emptyAddr, false},
+	}
+
+	for _, tc := range tests {
+		msg := NewMsgCompleteRedelegate(tc.delegatorAddr, tc.validatorSrcAddr, tc.validatorDstAddr)
+		if tc.expectPass {
+			assert.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		} else {
+			assert.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
@@ -2,8 +2,24 @@ package stake
 
 import (
 	"bytes"
+	"errors"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// name of the staking subspace in the params store
+const DefaultParamspace = "stake"
+
+// keys of the staking parameters in the params store
+var (
+	KeyInflationRateChange = []byte("InflationRateChange")
+	KeyInflationMax        = []byte("InflationMax")
+	KeyInflationMin        = []byte("InflationMin")
+	KeyGoalBonded          = []byte("GoalBonded")
+	KeyUnbondingTime       = []byte("UnbondingTime")
+	KeyMaxValidators       = []byte("MaxValidators")
+	KeyBondDenom           = []byte("BondDenom")
 )
 
 // Params defines the high level settings for staking
@@ -13,10 +29,54 @@ type Params struct {
 	InflationMin        sdk.Rat `json:"inflation_min"`         // minimum inflation rate
 	GoalBonded          sdk.Rat `json:"goal_bonded"`           // Goal of percent bonded atoms
 
+	UnbondingTime int64 `json:"unbonding_time"` // seconds tokens remain slashable after unbonding
+
 	MaxValidators uint16 `json:"max_validators"` // maximum number of validators
 	BondDenom     string `json:"bond_denom"`     // bondable coin denomination
 }
 
+// implements params.ParamSet
+func (p *Params) ParamSetPairs() []params.ParamSetPair {
+	return []params.ParamSetPair{
+		params.NewParamSetPair(KeyInflationRateChange, &p.InflationRateChange, validateFraction),
+		params.NewParamSetPair(KeyInflationMax, &p.InflationMax, validateFraction),
+		params.NewParamSetPair(KeyInflationMin, &p.InflationMin, validateFraction),
+		params.NewParamSetPair(KeyGoalBonded, &p.GoalBonded, validateFraction),
+		params.NewParamSetPair(KeyUnbondingTime, &p.UnbondingTime, validateUnbondingTime),
+		params.NewParamSetPair(KeyMaxValidators, &p.MaxValidators, validateMaxValidators),
+		params.NewParamSetPair(KeyBondDenom, &p.BondDenom, validateBondDenom),
+	}
+}
+
+func validateFraction(value interface{}) error {
+	r := value.(sdk.Rat)
+	if r.LT(sdk.ZeroRat()) || r.GT(sdk.OneRat()) {
+		return errors.New("must be between 0 and 1")
+	}
+	return nil
+}
+
+func validateUnbondingTime(value interface{}) error {
+	if value.(int64) < 0 {
+		return errors.New("must not be negative")
+	}
+	return nil
+}
+
+func validateMaxValidators(value interface{}) error {
+	if value.(uint16) == 0 {
+		return errors.New("must be positive")
+	}
+	return nil
+}
+
+func validateBondDenom(value interface{}) error {
+	if len(value.(string)) == 0 {
+		return errors.New("must not be empty")
+	}
+	return nil
+}
+
 func (p Params) equal(p2 Params) bool {
 	bz1 := msgCdc.MustMarshalBinary(&p)
 	bz2 := msgCdc.MustMarshalBinary(&p2)
@@ -30,6 +90,7 @@ func DefaultParams() Params {
 		InflationMax:        sdk.NewRat(20, 100),
 		InflationMin:        sdk.NewRat(7, 100),
 		GoalBonded:          sdk.NewRat(67, 100),
+		UnbondingTime:       60 * 60 * 24 * 3, // 3 days
 		MaxValidators:       100,
 		BondDenom:           "steak",
 	}
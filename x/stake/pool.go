@@ -12,6 +12,7 @@ type Pool struct {
 	UnbondedTokens      int64   `json:"unbonded_tokens"`       // reserve of unbonded tokens held with validators
 	UnbondingTokens     int64   `json:"unbonding_tokens"`      // tokens moving from bonded to unbonded pool
 	BondedTokens        int64   `json:"bonded_tokens"`         // reserve of bonded tokens
+	ProvisionedTokens   int64   `json:"provisioned_tokens"`    // inflation provisions minted but not yet distributed
 	UnbondedShares      sdk.Rat `json:"unbonded_shares"`       // sum of all shares distributed for the Unbonded Pool
 	UnbondingShares     sdk.Rat `json:"unbonding_shares"`      // shares moving from Bonded to Unbonded Pool
 	BondedShares        sdk.Rat `json:"bonded_shares"`         // sum of all shares distributed for the Bonded Pool
@@ -37,6 +38,7 @@ func InitialPool() Pool {
 		BondedTokens:            0,
 		UnbondingTokens:         0,
 		UnbondedTokens:          0,
+		ProvisionedTokens:       0,
 		BondedShares:            sdk.ZeroRat(),
 		UnbondingShares:         sdk.ZeroRat(),
 		UnbondedShares:          sdk.ZeroRat(),
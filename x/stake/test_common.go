@@ -18,6 +18,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 // dummy addresses used for testing
@@ -51,6 +52,7 @@ func makeTestCodec() *wire.Codec {
 	// Register AppAccount
 	cdc.RegisterInterface((*auth.Account)(nil), nil)
 	cdc.RegisterConcrete(&auth.BaseAccount{}, "test/stake/Account", nil)
+	cdc.RegisterConcrete(&auth.DelayedVestingAccount{}, "test/stake/DelayedVestingAccount", nil)
 	wire.RegisterCrypto(cdc)
 
 	return cdc
@@ -62,6 +64,7 @@ func paramsNoInflation() Params {
 		InflationMax:        sdk.ZeroRat(),
 		InflationMin:        sdk.ZeroRat(),
 		GoalBonded:          sdk.NewRat(67, 100),
+		UnbondingTime:       60 * 60 * 24 * 3,
 		MaxValidators:       100,
 		BondDenom:           "steak",
 	}
@@ -72,11 +75,15 @@ func createTestInput(t *testing.T, isCheckTx bool, initCoins int64) (sdk.Context
 
 	keyStake := sdk.NewKVStoreKey("stake")
 	keyAcc := sdk.NewKVStoreKey("acc")
+	keyBank := sdk.NewKVStoreKey("bank")
+	keyParams := sdk.NewKVStoreKey("params")
 
 	db := dbm.NewMemDB()
 	ms := store.NewCommitMultiStore(db)
 	ms.MountStoreWithDB(keyStake, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyBank, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
 	err := ms.LoadLatestVersion()
 	require.Nil(t, err)
 
@@ -87,14 +94,15 @@ func createTestInput(t *testing.T, isCheckTx bool, initCoins int64) (sdk.Context
 		keyAcc,              // target store
 		&auth.BaseAccount{}, // prototype
 	)
-	ck := bank.NewKeeper(accountMapper)
-	keeper := NewKeeper(cdc, keyStake, ck, DefaultCodespace)
+	ck := bank.NewKeeper(cdc, keyBank, accountMapper, bank.DefaultCodespace)
+	pk := params.NewKeeper(cdc, keyParams)
+	keeper := NewKeeper(cdc, keyStake, ck, pk.Subspace(DefaultParamspace), DefaultCodespace)
 	keeper.setPool(ctx, InitialPool())
 	keeper.setNewParams(ctx, DefaultParams())
 
 	// fill all the addresses with some coins
 	for _, addr := range addrs {
-		ck.AddCoins(ctx, addr, sdk.Coins{
+		ck.MintCoins(ctx, addr, sdk.Coins{
 			{keeper.GetParams(ctx).BondDenom, initCoins},
 		})
 	}
@@ -251,12 +251,14 @@ func (v Validator) DelegatorShareExRate(pool Pool) sdk.Rat {
 var _ sdk.Validator = Validator{}
 
 // nolint - for sdk.Validator
-func (v Validator) GetMoniker() string        { return v.Description.Moniker }
-func (v Validator) GetStatus() sdk.BondStatus { return v.Status() }
-func (v Validator) GetOwner() sdk.Address     { return v.Owner }
-func (v Validator) GetPubKey() crypto.PubKey  { return v.PubKey }
-func (v Validator) GetPower() sdk.Rat         { return v.PoolShares.Bonded() }
-func (v Validator) GetBondHeight() int64      { return v.BondHeight }
+func (v Validator) GetMoniker() string          { return v.Description.Moniker }
+func (v Validator) GetStatus() sdk.BondStatus   { return v.Status() }
+func (v Validator) GetOwner() sdk.Address       { return v.Owner }
+func (v Validator) GetPubKey() crypto.PubKey    { return v.PubKey }
+func (v Validator) GetPower() sdk.Rat           { return v.PoolShares.Bonded() }
+func (v Validator) GetBondHeight() int64        { return v.BondHeight }
+func (v Validator) GetDelegatorShares() sdk.Rat { return v.DelegatorShares }
+func (v Validator) GetCommission() sdk.Rat      { return v.Commission }
 
 //Human Friendly pretty printer
 func (v Validator) HumanReadableString() (string, error) {
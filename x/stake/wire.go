@@ -10,6 +10,8 @@ func RegisterWire(cdc *wire.Codec) {
 	cdc.RegisterConcrete(MsgEditValidator{}, "cosmos-sdk/MsgEditValidator", nil)
 	cdc.RegisterConcrete(MsgDelegate{}, "cosmos-sdk/MsgDelegate", nil)
 	cdc.RegisterConcrete(MsgUnbond{}, "cosmos-sdk/MsgUnbond", nil)
+	cdc.RegisterConcrete(MsgBeginRedelegate{}, "cosmos-sdk/MsgBeginRedelegate", nil)
+	cdc.RegisterConcrete(MsgCompleteRedelegate{}, "cosmos-sdk/MsgCompleteRedelegate", nil)
 }
 
 var msgCdc = wire.NewCodec()